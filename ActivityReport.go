@@ -0,0 +1,128 @@
+package devwatch
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// activityReportSize is the number of directories listed by ActivityReport
+const activityReportSize = 10
+
+// DirActivity is the event count of one watched directory
+type DirActivity struct {
+	Dir       string    // relative to AppRootDir
+	Events    int       // events received since start
+	LastEvent time.Time // time of the latest event
+}
+
+// ActivityReport lists the noisiest directories, most events first
+type ActivityReport []DirActivity
+
+// String formats the report as a table, eg: for a log line or a control endpoint
+func (r ActivityReport) String() string {
+	var b strings.Builder
+	for _, d := range r {
+		fmt.Fprintf(&b, "%8d  %s\n", d.Events, d.Dir)
+	}
+	return b.String()
+}
+
+// ActivityReport returns the directories that produced the most events.
+// Build output folders that were never added to UnobservedFiles usually
+// top the list.
+func (h *DevWatch) ActivityReport() ActivityReport {
+	report := h.activity.report()
+	for i := range report {
+		report[i].Dir = h.relativePath(report[i].Dir)
+	}
+	if len(report) > activityReportSize {
+		report = report[:activityReportSize]
+	}
+	return report
+}
+
+// dirStats is the activity of one directory
+type dirStats struct {
+	events  int
+	handled int // events where at least one handler succeeded
+	last    time.Time
+}
+
+// dirActivity counts events per watched directory
+type dirActivity struct {
+	mu          sync.Mutex
+	dirs        map[string]*dirStats
+	suggestions []IgnoreSuggestion
+}
+
+// stats returns the entry for dir, creating it. Callers must hold a.mu.
+func (a *dirActivity) stats(dir string) *dirStats {
+	if a.dirs == nil {
+		a.dirs = make(map[string]*dirStats)
+	}
+	d := a.dirs[dir]
+	if d == nil {
+		d = &dirStats{}
+		a.dirs[dir] = d
+	}
+	return d
+}
+
+// record notes an event in dir at t
+func (a *dirActivity) record(dir string, t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	d := a.stats(dir)
+	d.events++
+	d.last = t
+}
+
+// handled notes that an event in dir led to successful handler work
+func (a *dirActivity) handled(dir string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stats(dir).handled++
+}
+
+// counts returns the events and handled events of dir
+func (a *dirActivity) counts(dir string) (events, handled int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if d := a.dirs[dir]; d != nil {
+		return d.events, d.handled
+	}
+	return 0, 0
+}
+
+// since returns the directories with events after t
+func (a *dirActivity) since(t time.Time) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var dirs []string
+	for dir, d := range a.dirs {
+		if d.last.After(t) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// report returns every directory sorted by event count
+func (a *dirActivity) report() ActivityReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	report := make(ActivityReport, 0, len(a.dirs))
+	for dir, d := range a.dirs {
+		report = append(report, DirActivity{Dir: dir, Events: d.events, LastEvent: d.last})
+	}
+	slices.SortFunc(report, func(x, y DirActivity) int {
+		if x.Events != y.Events {
+			return y.Events - x.Events
+		}
+		return strings.Compare(x.Dir, y.Dir)
+	})
+	return report
+}
@@ -0,0 +1,31 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityReport(t *testing.T) {
+	root := t.TempDir()
+	w := New(&WatchConfig{AppRootDir: root})
+
+	now := time.Now()
+	for range 5 {
+		w.activity.record(filepath.Join(root, "dist"), now)
+	}
+	w.activity.record(filepath.Join(root, "web"), now)
+	w.activity.record(filepath.Join(root, "web"), now)
+
+	report := w.ActivityReport()
+	if len(report) != 2 {
+		t.Fatalf("expected 2 directories, got %v", report)
+	}
+	if report[0].Dir != "dist" || report[0].Events != 5 || report[1].Dir != "web" || report[1].Events != 2 {
+		t.Errorf("unexpected report order or counts: %+v", report)
+	}
+	if !strings.Contains(report.String(), "5  dist") {
+		t.Errorf("unexpected report text:\n%s", report)
+	}
+}
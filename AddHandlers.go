@@ -1,26 +1,40 @@
 package devwatch
 
+import "slices"
+
 // AddHandlers allows adding handlers dynamically after DevWatch initialization.
 // This is useful when handlers are created after the watcher starts (e.g., deploy handlers).
 // The method extracts UnobservedFiles from each handler and adds them to the no_add_to_watch map.
+// A stage dependency cycle introduced by the new handlers is logged once here;
+// a handler making a stage depend on a deferred result is logged and rejected.
 func (h *DevWatch) AddFilesEventHandlers(handlers ...FilesEventHandlers) {
 	h.noAddMu.Lock()
 	defer h.noAddMu.Unlock()
 
+	hadCycle := stageCycle(h.FilesEventHandlers)
+
 	// Initialize map if needed
 	if h.no_add_to_watch == nil {
 		h.no_add_to_watch = make(map[string]bool)
 	}
 
-	// Add each handler to FilesEventHandlers list
-	h.FilesEventHandlers = append(h.FilesEventHandlers, handlers...)
-
-	// Load unobserved files from each new handler
+	// Add each handler to FilesEventHandlers list and load its unobserved
+	// files, unless a stage would depend on a deferred result (see StagedHandler)
 	for _, handler := range handlers {
-		for _, file := range handler.UnobservedFiles() {
+		deferred := len(h.deferredUpstream(h.FilesEventHandlers))
+		if len(h.deferredUpstream(append(slices.Clip(h.FilesEventHandlers), handler))) > deferred {
+			h.say("stage-deferred", handlerName(handler))
+			continue
+		}
+		h.FilesEventHandlers = append(h.FilesEventHandlers, handler)
+		for _, file := range handlerUnobserved(handler) {
 			h.no_add_to_watch[file] = true
 		}
 	}
 
+	if !hadCycle && stageCycle(h.FilesEventHandlers) {
+		h.say("stage-cycle")
+	}
+
 	//h.Logger("Added", len(handlers), "handler(s) with unobserved files to watcher")
 }
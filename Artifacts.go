@@ -0,0 +1,108 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// ArtifactHandler is an optional capability for handlers producing outputs
+// from a source, eg: "src/app.ts" => ["dist/app.js", "dist/app.js.map"].
+// Outputs is asked after each successful event. Outputs are declared rather
+// than inferred from write timing, since cleanup hooks delete them.
+type ArtifactHandler interface {
+	Outputs(sourcePath string) []string // relative to AppRootDir or absolute
+}
+
+// SourceRemovedHandler is an optional capability for handlers cleaning up
+// after a source that produced artifacts is removed, so stale outputs don't
+// linger in dist/ and keep being served. See also WatchConfig.OnSourceRemoved.
+type SourceRemovedHandler interface {
+	OnSourceRemoved(sourcePath string, outputs []string) error
+}
+
+// artifactRegistry links sources to the outputs produced from them, as
+// slash paths relative to AppRootDir
+type artifactRegistry struct {
+	mu      sync.Mutex
+	outputs map[string][]string
+}
+
+// add records outputs produced from source
+func (r *artifactRegistry) add(source string, outputs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.outputs == nil {
+		r.outputs = make(map[string][]string)
+	}
+	for _, output := range outputs {
+		if output != source && !slices.Contains(r.outputs[source], output) {
+			r.outputs[source] = append(r.outputs[source], output)
+		}
+	}
+}
+
+// take returns and forgets the outputs of source
+func (r *artifactRegistry) take(source string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	outputs := r.outputs[source]
+	delete(r.outputs, source)
+	return outputs
+}
+
+// Artifacts returns the outputs ArtifactHandlers reported for source,
+// relative to AppRootDir
+func (h *DevWatch) Artifacts(source string) []string {
+	h.artifacts.mu.Lock()
+	defer h.artifacts.mu.Unlock()
+	return slices.Clone(h.artifacts.outputs[h.relativePath(h.absPath(source))])
+}
+
+// recordOutputs asks an ArtifactHandler for the outputs of sourcePath
+func (h *DevWatch) recordOutputs(handler FilesEventHandlers, sourcePath string) {
+	ah, ok := handler.(ArtifactHandler)
+	if !ok {
+		return
+	}
+	var outputs []string
+	for _, output := range ah.Outputs(sourcePath) {
+		outputs = append(outputs, h.relativePath(h.absPath(output)))
+	}
+	h.artifacts.add(h.relativePath(sourcePath), outputs...)
+}
+
+// sourceRemoved hands the outputs of a removed source to the
+// SourceRemovedHandlers supporting it and to WatchConfig.OnSourceRemoved
+func (h *DevWatch) sourceRemoved(sourcePath string) {
+	outputs := h.artifacts.take(h.relativePath(sourcePath))
+	if len(outputs) == 0 {
+		return
+	}
+	extension := filepath.Ext(sourcePath)
+	for _, handler := range h.FilesEventHandlers {
+		sh, ok := handler.(SourceRemovedHandler)
+		if !ok {
+			continue
+		}
+		if _, supported := handlerSupports(handler, sourcePath, extension); !supported {
+			continue
+		}
+		if err := sh.OnSourceRemoved(sourcePath, slices.Clone(outputs)); err != nil {
+			h.say("cleanup-handler-error", handlerName(handler), err)
+		}
+	}
+	if h.OnSourceRemoved != nil {
+		if err := h.OnSourceRemoved(sourcePath, outputs); err != nil {
+			h.say("cleanup-error", err)
+		}
+	}
+}
+
+// absPath resolves path against AppRootDir
+func (h *DevWatch) absPath(path string) string {
+	if path != "" && !filepath.IsAbs(path) && h.AppRootDir != "" {
+		return filepath.Join(h.AppRootDir, path)
+	}
+	return path
+}
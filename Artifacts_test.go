@@ -0,0 +1,73 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// tsHandler compiles .ts sources into dist/ and removes them with the source
+type tsHandler struct {
+	root    string
+	removed map[string][]string
+}
+
+func (t *tsHandler) SupportedExtensions() []string { return []string{".ts"} }
+func (t *tsHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	return nil
+}
+func (t *tsHandler) Outputs(sourcePath string) []string {
+	name := filepath.Base(sourcePath)
+	js := "dist/" + name[:len(name)-len(".ts")] + ".js"
+	return []string{js, filepath.Join(t.root, js+".map")}
+}
+func (t *tsHandler) OnSourceRemoved(sourcePath string, outputs []string) error {
+	t.removed[sourcePath] = outputs
+	return nil
+}
+
+func TestArtifacts_SourceRemoved(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(root, "src", "app.ts")
+	if err := os.WriteFile(src, []byte("let a = 1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &tsHandler{root: root, removed: make(map[string][]string)}
+	var hooked []string
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		OnSourceRemoved: func(sourcePath string, outputs []string) error {
+			hooked = outputs
+			return nil
+		},
+		Logger: func(message ...any) {},
+	})
+
+	if err := w.Trigger("src/app.ts", "write"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"dist/app.js", "dist/app.js.map"}
+	if got := w.Artifacts("src/app.ts"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Artifacts = %v, want %v", got, want)
+	}
+
+	os.Remove(src)
+	if err := w.Trigger("src/app.ts", "remove"); err != nil {
+		t.Fatal(err)
+	}
+	if got := handler.removed[src]; !reflect.DeepEqual(got, want) {
+		t.Errorf("OnSourceRemoved got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(hooked, want) {
+		t.Errorf("WatchConfig.OnSourceRemoved got %v, want %v", hooked, want)
+	}
+	if len(w.Artifacts("src/app.ts")) != 0 {
+		t.Error("expected the outputs to be forgotten with the source")
+	}
+}
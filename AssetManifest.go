@@ -0,0 +1,91 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// assetManifest holds the fingerprints of the assets handled successfully
+type assetManifest struct {
+	mu       sync.Mutex
+	once     sync.Once
+	hashes   map[string]string // path relative to AppRootDir => content hash
+	writeErr bool              // last write failed; logged once until it succeeds
+}
+
+// Fingerprints returns a copy of the asset manifest: path relative to
+// AppRootDir => content hash (see WatchConfig.Hasher), eg: to add "?v=<hash>"
+// to asset URLs in templates. Empty unless AssetFingerprints or ManifestPath is set.
+func (h *DevWatch) Fingerprints() map[string]string {
+	h.manifest.mu.Lock()
+	defer h.manifest.mu.Unlock()
+	return maps.Clone(h.manifest.hashes)
+}
+
+// fingerprint updates the manifest after handlers processed a non Go file
+// successfully, and rewrites ManifestPath when set
+func (h *DevWatch) fingerprint(filePath, event string) {
+	if !h.AssetFingerprints && h.ManifestPath == "" {
+		return
+	}
+	h.manifest.once.Do(func() {
+		// never observe our own output
+		h.RegisterInternalPath(h.ManifestPath)
+	})
+
+	rel := h.relativePath(filePath)
+	hash := ""
+	if event != "remove" && event != "rename" {
+		hash = h.calculateFileHash(filePath)
+	}
+
+	h.manifest.mu.Lock()
+	defer h.manifest.mu.Unlock()
+	if h.manifest.hashes == nil {
+		h.manifest.hashes = make(map[string]string)
+	}
+	if hash == "" {
+		if _, ok := h.manifest.hashes[rel]; !ok {
+			return
+		}
+		delete(h.manifest.hashes, rel)
+	} else if h.manifest.hashes[rel] == hash {
+		return
+	} else {
+		h.manifest.hashes[rel] = hash
+	}
+	h.writeManifest()
+}
+
+// writeManifest writes the manifest as JSON to ManifestPath, replacing it
+// atomically. Callers must hold manifest.mu.
+func (h *DevWatch) writeManifest() {
+	if h.ManifestPath == "" {
+		return
+	}
+	path := h.ManifestPath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(h.AppRootDir, path)
+	}
+	data, err := json.MarshalIndent(h.manifest.hashes, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+	}
+	if err == nil {
+		tmp := path + ".tmp"
+		if err = os.WriteFile(tmp, data, 0644); err == nil {
+			err = os.Rename(tmp, path)
+		}
+	}
+	if err != nil {
+		if !h.manifest.writeErr {
+			h.say("manifest-error", err)
+		}
+		h.manifest.writeErr = true
+		return
+	}
+	h.manifest.writeErr = false
+}
@@ -0,0 +1,51 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAssetManifest(t *testing.T) {
+	root := t.TempDir()
+	css := filepath.Join(root, "web", "app.css")
+	if err := os.MkdirAll(filepath.Dir(css), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(css, []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		ManifestPath:       "web/assets.json",
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{SupportedExtensions_: []string{".css", ".json"}}},
+		Logger:             func(message ...any) {},
+	})
+
+	if err := w.Trigger(css, "write"); err != nil {
+		t.Fatal(err)
+	}
+	hash := w.Fingerprints()["web/app.css"]
+	if hash == "" || hash != w.calculateFileHash(css) {
+		t.Fatalf("expected app.css fingerprint, got %v", w.Fingerprints())
+	}
+
+	var written map[string]string
+	data, err := os.ReadFile(filepath.Join(root, "web", "assets.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &written); err != nil || written["web/app.css"] != hash {
+		t.Errorf("unexpected manifest file: %s (%v)", data, err)
+	}
+	if !w.Contain(filepath.Join(root, "web", "assets.json")) {
+		t.Error("expected the manifest to be an internal path")
+	}
+
+	os.Remove(css)
+	w.handleFileEvent("app.css", css, "remove", true)
+	if _, ok := w.Fingerprints()["web/app.css"]; ok {
+		t.Error("expected removed asset to leave the manifest")
+	}
+}
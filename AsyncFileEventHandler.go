@@ -0,0 +1,102 @@
+package devwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// AsyncFileEventHandler is an optional capability for FilesEventHandlers that
+// kick off work in the background (eg: a build that keeps running after the
+// event returned). When implemented, NewFileEventAsync is called instead of
+// NewFileEvent and the browser reload waits for the returned channel.
+type AsyncFileEventHandler interface {
+	// NewFileEventAsync starts processing the event and returns a channel that
+	// receives the final result (nil on success) once the work is done.
+	NewFileEventAsync(fileName, extension, filePath, event string) <-chan error
+}
+
+// defaultAsyncTimeout is used when WatchConfig.AsyncTimeout is not set
+const defaultAsyncTimeout = 30 * time.Second
+
+// asyncTracker counts in-flight async handler work so reloads can wait for it
+type asyncTracker struct {
+	mu    sync.Mutex
+	count int
+	idle  chan struct{} // closed when count drops to zero
+}
+
+func (a *asyncTracker) add() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.count == 0 {
+		a.idle = make(chan struct{})
+	}
+	a.count++
+}
+
+func (a *asyncTracker) done() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.count--
+	if a.count == 0 {
+		close(a.idle)
+	}
+}
+
+// wait blocks until no async work is in flight or timeout expires.
+// Returns false on timeout.
+func (a *asyncTracker) wait(timeout time.Duration) bool {
+	a.mu.Lock()
+	if a.count == 0 {
+		a.mu.Unlock()
+		return true
+	}
+	idle := a.idle
+	a.mu.Unlock()
+
+	if timeout <= 0 {
+		return false
+	}
+	select {
+	case <-idle:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (h *DevWatch) asyncTimeout() time.Duration {
+	if h.AsyncTimeout > 0 {
+		return h.AsyncTimeout
+	}
+	return defaultAsyncTimeout
+}
+
+// waitAsyncResults collects the results of async handlers in the background
+// and schedules a reload once all of them completed, when at least one of
+// them (or a synchronous handler, see syncSucceeded) succeeded.
+func (h *DevWatch) waitAsyncResults(filePath string, received time.Time, results []<-chan error, syncSucceeded bool) {
+	h.async.add()
+	go func() {
+		defer h.async.done()
+
+		succeeded := syncSucceeded
+		deadline := time.After(h.asyncTimeout())
+		for _, ch := range results {
+			select {
+			case err := <-ch:
+				if err == nil {
+					succeeded = true
+				}
+			case <-deadline:
+				h.say("async-timeout", filePath)
+				return
+			}
+		}
+
+		if succeeded {
+			h.stats.handlersDone(received)
+			h.scheduleReload()
+		}
+	}()
+}
@@ -0,0 +1,99 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// asyncBuildHandler simulates a build that finishes after NewFileEventAsync returned
+type asyncBuildHandler struct {
+	delay    time.Duration
+	result   error
+	finished atomic.Bool
+}
+
+func (a *asyncBuildHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	return errors.New("NewFileEvent should not be called on async handlers")
+}
+
+func (a *asyncBuildHandler) NewFileEventAsync(fileName, extension, filePath, event string) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		time.Sleep(a.delay)
+		a.finished.Store(true)
+		done <- a.result
+	}()
+	return done
+}
+
+func (a *asyncBuildHandler) SupportedExtensions() []string     { return []string{".js"} }
+func (a *asyncBuildHandler) MainInputFileRelativePath() string { return "" }
+func (a *asyncBuildHandler) UnobservedFiles() []string         { return nil }
+
+func TestAsyncFileEventHandler_ReloadWaitsForCompletion(t *testing.T) {
+	tempDir := t.TempDir()
+	jsFile := filepath.Join(tempDir, "main.js")
+	if err := os.WriteFile(jsFile, []byte("console.log(1)"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &asyncBuildHandler{delay: 150 * time.Millisecond}
+	var builtBeforeReload atomic.Bool
+	reloadCalled := make(chan struct{}, 1)
+
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		BrowserReload: func() error {
+			builtBeforeReload.Store(handler.finished.Load())
+			reloadCalled <- struct{}{}
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	if err := w.Trigger(jsFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-reloadCalled:
+	case <-time.After(time.Second):
+		t.Fatal("expected reload after async handler completed")
+	}
+	if !builtBeforeReload.Load() {
+		t.Error("reload fired before async handler finished")
+	}
+}
+
+func TestAsyncFileEventHandler_FailureSkipsReload(t *testing.T) {
+	tempDir := t.TempDir()
+	jsFile := filepath.Join(tempDir, "main.js")
+	if err := os.WriteFile(jsFile, []byte("console.log(1)"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var reloadCount int64
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&asyncBuildHandler{delay: 20 * time.Millisecond, result: errors.New("build failed")}},
+		BrowserReload: func() error {
+			atomic.AddInt64(&reloadCount, 1)
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	if err := w.Trigger(jsFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	if got := atomic.LoadInt64(&reloadCount); got != 0 {
+		t.Errorf("expected no reload after failed async build, got %d", got)
+	}
+}
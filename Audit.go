@@ -0,0 +1,114 @@
+package devwatch
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditReport summarizes what the watcher would have done during AuditFor
+type AuditReport struct {
+	Since, Until time.Time
+	Events       int            // file events received
+	Calls        map[string]int // handler name => calls it would have received, initial registration included
+	Reloads      int            // browser reloads that would have fired
+	Skipped      map[string]int // reason => events not dispatched eg: "no-owner", "comment-only"
+}
+
+// String formats the report for a log line or a terminal
+func (r AuditReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "audit %v: %d events, %d reloads\n", r.Until.Sub(r.Since).Round(time.Second), r.Events, r.Reloads)
+	for _, name := range slices.Sorted(maps.Keys(r.Calls)) {
+		fmt.Fprintf(&b, "%8d  calls to %s\n", r.Calls[name], name)
+	}
+	for _, reason := range slices.Sorted(maps.Keys(r.Skipped)) {
+		fmt.Fprintf(&b, "%8d  skipped: %s\n", r.Skipped[reason], reason)
+	}
+	return b.String()
+}
+
+// auditState records the would-be actions while AuditFor runs
+type auditState struct {
+	mu     sync.Mutex
+	until  time.Time
+	report AuditReport
+}
+
+// startAudit enters audit mode for AuditFor and reports once it ends
+func (h *DevWatch) startAudit() {
+	if h.AuditFor <= 0 {
+		return
+	}
+	now := time.Now()
+	h.audit.mu.Lock()
+	h.audit.until = now.Add(h.AuditFor)
+	h.audit.report = AuditReport{Since: now, Calls: make(map[string]int), Skipped: make(map[string]int)}
+	h.audit.mu.Unlock()
+	time.AfterFunc(h.AuditFor, h.finishAudit)
+}
+
+// finishAudit hands the report to OnAudit, or logs it
+func (h *DevWatch) finishAudit() {
+	report := h.AuditReport()
+	if h.OnAudit != nil {
+		h.OnAudit(report)
+		return
+	}
+	h.say("audit-report", report)
+}
+
+// auditing reports whether handler calls and reloads are only recorded
+func (h *DevWatch) auditing() bool {
+	h.audit.mu.Lock()
+	defer h.audit.mu.Unlock()
+	return time.Now().Before(h.audit.until)
+}
+
+// auditCall records a handler call skipped by audit mode
+func (h *DevWatch) auditCall(handler FilesEventHandlers) {
+	h.audit.mu.Lock()
+	defer h.audit.mu.Unlock()
+	h.audit.report.Calls[handlerName(handler)]++
+}
+
+// auditRecord counts a published record of audit mode
+func (h *DevWatch) auditRecord(rec *EventRecord) {
+	if !rec.Audit {
+		return
+	}
+	h.audit.mu.Lock()
+	defer h.audit.mu.Unlock()
+	switch {
+	case rec.Event == "reload":
+		h.audit.report.Reloads++
+	case rec.Skipped != "":
+		h.audit.report.Events++
+		h.audit.report.Skipped[rec.Skipped]++
+	default:
+		h.audit.report.Events++
+	}
+}
+
+// auditReload records the browser reload audit mode holds back
+func (h *DevWatch) auditReload() {
+	rec := h.newEventRecord("", "reload")
+	rec.Audit = true
+	rec.Skipped = "audit"
+	h.publish(rec)
+}
+
+// AuditReport returns what the watcher would have done so far under
+// AuditFor; Until is the end of the audit
+func (h *DevWatch) AuditReport() AuditReport {
+	h.audit.mu.Lock()
+	defer h.audit.mu.Unlock()
+	report := h.audit.report
+	report.Until = h.audit.until
+	report.Calls = maps.Clone(report.Calls)
+	report.Skipped = maps.Clone(report.Skipped)
+	return report
+}
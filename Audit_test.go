@@ -0,0 +1,87 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAuditFor(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var called, reloads int32
+	reports := make(chan AuditReport, 1)
+	exit := make(chan bool)
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".css"},
+		}},
+		BrowserReload: func() error { atomic.AddInt32(&reloads, 1); return nil },
+		AuditFor:      400 * time.Millisecond,
+		OnAudit:       func(r AuditReport) { reports <- r },
+		Logger:        func(message ...any) {},
+		ExitChan:      exit,
+	})
+	records, cancel := w.Subscribe()
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	defer func() {
+		close(exit)
+		wg.Wait()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := os.WriteFile(cssFile, []byte("body { color: red }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var report AuditReport
+	select {
+	case report = <-reports:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected an audit report")
+	}
+	if atomic.LoadInt32(&called) != 0 || atomic.LoadInt32(&reloads) != 0 {
+		t.Fatalf("audit ran %d handler calls and %d reloads, want none", called, reloads)
+	}
+	if report.Events == 0 || report.Reloads == 0 || len(report.Calls) != 1 {
+		t.Errorf("report = %+v, want the event, its reload and the handler calls", report)
+	}
+	for _, calls := range report.Calls {
+		if calls < 2 { // initial registration and the write
+			t.Errorf("Calls = %v, want the initial registration and the write", report.Calls)
+		}
+	}
+	select {
+	case rec := <-records:
+		if !rec.Audit {
+			t.Errorf("record %+v should be marked Audit", rec)
+		}
+	default:
+		t.Error("expected audit records for subscribers")
+	}
+
+	// once the audit ended handlers run again
+	if err := os.WriteFile(cssFile, []byte("body { color: blue }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&called) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&called) == 0 {
+		t.Error("expected handler calls after the audit")
+	}
+}
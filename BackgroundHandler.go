@@ -0,0 +1,15 @@
+package devwatch
+
+// BackgroundHandler is an optional capability for handlers whose work must
+// neither gate nor trigger the browser reload (eg: a linter reporting
+// warnings). Their result is recorded for Subscribe but ignored for reload
+// and pipeline stage decisions.
+type BackgroundHandler interface {
+	Background() bool
+}
+
+// isBackground reports whether handler opted out of reload decisions
+func isBackground(handler FilesEventHandlers) bool {
+	bh, ok := handler.(BackgroundHandler)
+	return ok && bh.Background()
+}
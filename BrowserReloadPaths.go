@@ -0,0 +1,75 @@
+package devwatch
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ReloadTargetHandler is an optional capability for handlers whose work
+// changes other files than the one received, eg: a CSS build triggered by a
+// template reports its stylesheet, so a LiveReload with InjectCSS swaps the
+// stylesheet instead of reloading the page. Paths are relative to AppRootDir
+// or absolute. The received file is still reported when another handler
+// processed it.
+type ReloadTargetHandler interface {
+	ReloadTargets(sourcePath string) []string
+}
+
+// reloadTargets returns the reload paths declared by handler for sourcePath
+func (h *DevWatch) reloadTargets(handler FilesEventHandlers, sourcePath string) ([]string, bool) {
+	rh, ok := handler.(ReloadTargetHandler)
+	if !ok {
+		return nil, false
+	}
+	var targets []string
+	for _, target := range rh.ReloadTargets(sourcePath) {
+		targets = append(targets, h.relativePath(h.absPath(target)))
+	}
+	return targets, true
+}
+
+// changedPaths collects the files handled since the last browser reload
+type changedPaths struct {
+	mu      sync.Mutex
+	changes []ReloadChange
+}
+
+// add records relPath for the next reload; rebuilt tells whether handlers
+// processed it. A file changing again keeps its last event.
+func (c *changedPaths) add(relPath, event string, rebuilt bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i := slices.IndexFunc(c.changes, func(ch ReloadChange) bool { return ch.Path == relPath }); i >= 0 {
+		c.changes[i].Event = event
+		c.changes[i].Rebuilt = c.changes[i].Rebuilt || rebuilt
+		return
+	}
+	c.changes = append(c.changes, ReloadChange{Path: relPath, Type: reloadType(relPath), Event: event, Rebuilt: rebuilt})
+}
+
+// take returns the recorded changes and starts a new set
+func (c *changedPaths) take() []ReloadChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	changes := c.changes
+	c.changes = nil
+	return changes
+}
+
+// browserReload calls BrowserReloadPaths with the files changed since the
+// last reload, or BrowserReload when it isn't set, then the ReloadTransports
+func (h *DevWatch) browserReload(forced bool) error {
+	changes := h.changed.take()
+	var paths []string
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+	var err error
+	if h.BrowserReloadPaths != nil {
+		err = h.BrowserReloadPaths(paths)
+	} else if h.BrowserReload != nil {
+		err = h.BrowserReload()
+	}
+	return errors.Join(err, h.notifyTransports(changes, paths, forced))
+}
@@ -0,0 +1,43 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBrowserReloadPaths(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.css", "b.css"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("a{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reloaded := make(chan []string, 1)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{SupportedExtensions_: []string{".css"}}},
+		BrowserReloadPaths: func(paths []string) error { reloaded <- paths; return nil },
+		Logger:             func(message ...any) {},
+	})
+
+	w.Trigger("a.css", "write")
+	w.Trigger("b.css", "write")
+	select {
+	case paths := <-reloaded:
+		if !slices.Equal(paths, []string{"a.css", "b.css"}) {
+			t.Errorf("unexpected reload paths: %v", paths)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a reload")
+	}
+
+	if err := w.ForceReload(); err != nil {
+		t.Fatal(err)
+	}
+	if paths := <-reloaded; len(paths) != 0 {
+		t.Errorf("expected forced reload without pending paths, got %v", paths)
+	}
+}
@@ -0,0 +1,37 @@
+package devwatch
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// BuildDependencyHandler is an optional capability for handlers whose build
+// reads files besides Go code, eg: a CGO package (["*.c", "*.h"]) or a main
+// shelling out to make (["Makefile", "native/**/*.rs"]). Changes of matching
+// files reach the handler, with their own extension, as files it owns.
+// Patterns without a slash match file names anywhere; patterns with a slash
+// match the end of the path ("**" spans folders, "{a,b}" alternatives).
+type BuildDependencyHandler interface {
+	BuildDependencies() []string
+}
+
+// matchBuildDependency returns the pattern of handler matching filePath
+func matchBuildDependency(handler FilesEventHandlers, filePath string) (string, bool) {
+	bh, ok := handler.(BuildDependencyHandler)
+	if !ok {
+		return "", false
+	}
+	slash := filepath.ToSlash(filePath)
+	for _, pattern := range bh.BuildDependencies() {
+		pattern = filepath.ToSlash(pattern)
+		glob, target := "**/"+pattern, slash
+		if !strings.Contains(pattern, "/") {
+			glob, target = pattern, path.Base(slash)
+		}
+		if matchGlob(glob, target) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,46 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// cgoHandler is a Go handler whose build also reads C sources and a Makefile
+type cgoHandler struct{ recordingHandler }
+
+func (c *cgoHandler) SupportedExtensions() []string     { return []string{".go"} }
+func (c *cgoHandler) MainInputFileRelativePath() string { return "cmd/app/main.go" }
+func (c *cgoHandler) BuildDependencies() []string       { return []string{"*.{c,h}", "native/**/Makefile"} }
+
+func TestBuildDependencies_ReachHandler(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"lib/sum.c":             "int sum(int a, int b) { return a + b; }",
+		"lib/sum.h":             "int sum(int a, int b);",
+		"native/audio/Makefile": "all:",
+		"Makefile":              "all:",
+		"lib/notes.txt":         "todo",
+	})
+	handler := &cgoHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		Logger:             func(message ...any) {},
+	})
+
+	for _, file := range []string{"lib/sum.c", "lib/sum.h", "native/audio/Makefile", "Makefile", "lib/notes.txt"} {
+		if err := w.Trigger(file, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{filepath.Join(root, "lib", "sum.c"), filepath.Join(root, "lib", "sum.h"), filepath.Join(root, "native", "audio", "Makefile")}
+	if !slices.Equal(handler.paths, want) {
+		t.Errorf("handler got %v, want %v", handler.paths, want)
+	}
+
+	matches := w.MatchHandlers(filepath.Join(root, "lib", "sum.h"))
+	if len(matches) != 1 || !matches[0].Matched || matches[0].Reason != "build dependency *.{c,h}" {
+		t.Errorf("matches = %+v", matches)
+	}
+}
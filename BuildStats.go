@@ -0,0 +1,136 @@
+package devwatch
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxBuildSamples bounds the builds kept per artifact
+const maxBuildSamples = 256
+
+// BuildSample is one successful handler run that wrote an artifact
+type BuildSample struct {
+	At       time.Time
+	Size     int64         // artifact size in bytes after the run
+	Duration time.Duration // handler call
+}
+
+// BuildTrend is the size and duration history of one artifact during the
+// session, eg: to notice a wasm bundle growing with each dependency added
+type BuildTrend struct {
+	Handler  string
+	Artifact string        // relative to AppRootDir
+	Samples  []BuildSample // oldest first
+}
+
+// Growth returns the size change in bytes between the first and last sample
+func (t BuildTrend) Growth() int64 {
+	if len(t.Samples) == 0 {
+		return 0
+	}
+	return t.Samples[len(t.Samples)-1].Size - t.Samples[0].Size
+}
+
+// buildStats collects artifact sizes per handler and artifact
+type buildStats struct {
+	mu     sync.Mutex
+	trends []*BuildTrend // first build first
+}
+
+// add records a sample, returning the previous one of the same artifact
+func (s *buildStats) add(handler, artifact string, sample BuildSample) (prev BuildSample, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.trends, func(t *BuildTrend) bool { return t.Handler == handler && t.Artifact == artifact })
+	if i < 0 {
+		s.trends = append(s.trends, &BuildTrend{Handler: handler, Artifact: artifact})
+		i = len(s.trends) - 1
+	}
+	t := s.trends[i]
+	if n := len(t.Samples); n > 0 {
+		prev, ok = t.Samples[n-1], true
+	}
+	if len(t.Samples) == maxBuildSamples {
+		t.Samples = t.Samples[1:]
+	}
+	t.Samples = append(t.Samples, sample)
+	return prev, ok
+}
+
+// snapshot returns a copy of the trends
+func (s *buildStats) snapshot() []BuildTrend {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	trends := make([]BuildTrend, 0, len(s.trends))
+	for _, t := range s.trends {
+		trends = append(trends, BuildTrend{Handler: t.Handler, Artifact: t.Artifact, Samples: slices.Clone(t.Samples)})
+	}
+	return trends
+}
+
+// buildOutputs returns the files handler declares writing for sourcePath:
+// its wasm output, ArtifactHandler outputs and unobserved files, relative
+// to AppRootDir
+func (h *DevWatch) buildOutputs(handler FilesEventHandlers, sourcePath string) []string {
+	var outputs []string
+	if wh, ok := handler.(WasmOutputHandler); ok && wh.WasmOutputPath() != "" {
+		outputs = append(outputs, wh.WasmOutputPath())
+	}
+	if ah, ok := handler.(ArtifactHandler); ok {
+		outputs = append(outputs, ah.Outputs(sourcePath)...)
+	}
+	outputs = append(outputs, handlerUnobserved(handler)...)
+
+	var rel []string
+	for _, output := range outputs {
+		if output == "" {
+			continue
+		}
+		if r := h.relativePath(h.absPath(output)); !slices.Contains(rel, r) {
+			rel = append(rel, r)
+		}
+	}
+	return rel
+}
+
+// recordBuild records the size of each artifact handler wrote while
+// handling sourcePath in took, warning past BuildGrowthWarning
+func (h *DevWatch) recordBuild(handler FilesEventHandlers, sourcePath string, took time.Duration) {
+	now := time.Now()
+	name := handlerName(handler)
+	for _, artifact := range h.buildOutputs(handler, sourcePath) {
+		info, err := os.Stat(h.absPath(artifact))
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		prev, ok := h.builds.add(name, artifact, BuildSample{At: now, Size: info.Size(), Duration: took})
+		if ok && h.BuildGrowthWarning > 0 && prev.Size > 0 && float64(info.Size()-prev.Size) > h.BuildGrowthWarning*float64(prev.Size) {
+			h.say("build-growth", artifact, (info.Size()-prev.Size)*100/prev.Size, info.Size())
+		}
+	}
+}
+
+// WriteBuildCSV writes every recorded build as CSV, one row per artifact
+// and run, with the header: time,handler,artifact,bytes,duration_ms
+func (h *DevWatch) WriteBuildCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "handler", "artifact", "bytes", "duration_ms"})
+	for _, t := range h.builds.snapshot() {
+		for _, s := range t.Samples {
+			cw.Write([]string{
+				s.At.Format(time.RFC3339Nano),
+				t.Handler,
+				t.Artifact,
+				strconv.FormatInt(s.Size, 10),
+				strconv.FormatInt(s.Duration.Milliseconds(), 10),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
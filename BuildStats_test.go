@@ -0,0 +1,82 @@
+package devwatch
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// wasmBuilder writes a main.wasm one byte larger than the source on each build
+type wasmBuilder struct {
+	dir string
+}
+
+func (b *wasmBuilder) NewFileEvent(fileName, extension, filePath, event string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.dir, "main.wasm"), append(data, '!'), 0644)
+}
+
+func (b *wasmBuilder) SupportedExtensions() []string { return []string{".css"} }
+func (b *wasmBuilder) WasmOutputPath() string        { return "main.wasm" }
+
+func TestBuildStats(t *testing.T) {
+	tempDir := t.TempDir()
+	source := filepath.Join(tempDir, "app.css")
+
+	var mu sync.Mutex
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&wasmBuilder{dir: tempDir}},
+		BuildGrowthWarning: 0.5,
+		Logger: func(message ...any) {
+			mu.Lock()
+			logs = append(logs, strings.TrimSpace(fmt.Sprintln(message...)))
+			mu.Unlock()
+		},
+	})
+
+	for _, content := range []string{"aaaaaaaaa", "bbbbbbbbbbbbbbbbbbb"} { // 10 then 20 bytes built
+		if err := os.WriteFile(source, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Trigger(source, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	builds := w.Stats().Builds
+	if len(builds) != 1 {
+		t.Fatalf("Builds = %+v, want one artifact", builds)
+	}
+	trend := builds[0]
+	if trend.Artifact != "main.wasm" || len(trend.Samples) != 2 {
+		t.Fatalf("trend = %+v, want 2 samples of main.wasm", trend)
+	}
+	if trend.Samples[0].Size != 10 || trend.Growth() != 10 {
+		t.Errorf("sizes = %+v growth %d, want 10 then 20 bytes", trend.Samples, trend.Growth())
+	}
+
+	mu.Lock()
+	warned := strings.Contains(strings.Join(logs, "\n"), "main.wasm grew 100%")
+	mu.Unlock()
+	if !warned {
+		t.Errorf("expected a growth warning in %q", logs)
+	}
+
+	var csv bytes.Buffer
+	if err := w.WriteBuildCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 3 || lines[0] != "time,handler,artifact,bytes,duration_ms" || !strings.Contains(lines[2], ",main.wasm,20,") {
+		t.Errorf("unexpected CSV:\n%s", csv.String())
+	}
+}
@@ -0,0 +1,95 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CDPReload is a ReloadTransport reloading the tabs of a browser started
+// with remote debugging (eg: chrome --remote-debugging-port=9222) through
+// the Chrome DevTools Protocol, for pages without a reload script
+type CDPReload struct {
+	Endpoint  string        // DevTools HTTP endpoint, default "http://127.0.0.1:9222"
+	URLPrefix string        // only tabs whose URL starts with it eg: "http://localhost:8080/", default every tab
+	Timeout   time.Duration // per request, default 5s
+}
+
+// cdpTarget is an entry of the DevTools /json/list endpoint
+type cdpTarget struct {
+	Type                 string `json:"type"`
+	URL                  string `json:"url"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+// NotifyReload reloads, bypassing the cache, every tab matching URLPrefix
+func (c *CDPReload) NotifyReload(msg ReloadMessage) error {
+	endpoint, timeout := c.Endpoint, c.Timeout
+	if endpoint == "" {
+		endpoint = "http://127.0.0.1:9222"
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Get(strings.TrimSuffix(endpoint, "/") + "/json/list")
+	if err != nil {
+		return errors.New("CDPReload: " + err.Error())
+	}
+	defer resp.Body.Close()
+	var targets []cdpTarget
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return errors.New("CDPReload: targets: " + err.Error())
+	}
+
+	var errs []error
+	for _, target := range targets {
+		if target.Type != "page" || target.WebSocketDebuggerURL == "" || !strings.HasPrefix(target.URL, c.URLPrefix) {
+			continue
+		}
+		if err := cdpReloadTarget(target.WebSocketDebuggerURL, timeout); err != nil {
+			errs = append(errs, errors.New("CDPReload: "+target.URL+": "+err.Error()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cdpReloadTarget sends Page.reload to a tab and waits for its answer
+func cdpReloadTarget(wsURL string, timeout time.Duration) error {
+	conn, br, err := dialWS(wsURL, timeout) // the deadline of timeout covers the answer too
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := writeWSFrame(conn, wsText, []byte(`{"id":1,"method":"Page.reload","params":{"ignoreCache":true}}`), true); err != nil {
+		return err
+	}
+	for {
+		op, payload, err := readWSFrame(br, false)
+		if err != nil {
+			return err
+		}
+		if op == wsClose {
+			return errors.New("closed by the browser")
+		}
+		if op != wsText {
+			continue
+		}
+		var answer struct {
+			ID    int `json:"id"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(payload, &answer) != nil || answer.ID != 1 {
+			continue // an event
+		}
+		if answer.Error != nil {
+			return errors.New(answer.Error.Message)
+		}
+		return nil
+	}
+}
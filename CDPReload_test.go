@@ -0,0 +1,88 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDevTools serves /json/list with one page per url and records the
+// methods received by their debugger WebSockets
+type fakeDevTools struct {
+	mu      sync.Mutex
+	urls    []string
+	reloads []string // urls of the reloaded pages
+	fail    bool     // answer Page.reload with an error
+}
+
+func (d *fakeDevTools) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/json/list" {
+		targets := []cdpTarget{{Type: "service_worker", URL: "http://localhost:8080/sw.js", WebSocketDebuggerURL: "ws://" + r.Host + "/devtools/sw"}}
+		for i, url := range d.urls {
+			targets = append(targets, cdpTarget{Type: "page", URL: url, WebSocketDebuggerURL: fmt.Sprintf("ws://%s/devtools/page/%d", r.Host, i)})
+		}
+		json.NewEncoder(w).Encode(targets)
+		return
+	}
+	var page int
+	if _, err := fmt.Sscanf(r.URL.Path, "/devtools/page/%d", &page); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	conn, br, err := upgradeWS(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_, payload, err := readWSFrame(br, true)
+	if err != nil {
+		return
+	}
+	var call struct {
+		ID     int    `json:"id"`
+		Method string `json:"method"`
+	}
+	json.Unmarshal(payload, &call)
+	if call.Method == "Page.reload" {
+		d.mu.Lock()
+		d.reloads = append(d.reloads, d.urls[page])
+		d.mu.Unlock()
+	}
+	writeWSFrame(conn, wsText, []byte(`{"method":"Page.frameStartedLoading","params":{}}`), false)
+	if d.fail {
+		writeWSFrame(conn, wsText, []byte(fmt.Sprintf(`{"id":%d,"error":{"message":"reload refused"}}`, call.ID)), false)
+	} else {
+		writeWSFrame(conn, wsText, []byte(fmt.Sprintf(`{"id":%d,"result":{}}`, call.ID)), false)
+	}
+}
+
+func TestCDPReload(t *testing.T) {
+	devtools := &fakeDevTools{urls: []string{"http://localhost:8080/", "https://example.com/"}}
+	srv := httptest.NewServer(devtools)
+	t.Cleanup(srv.Close)
+
+	c := &CDPReload{Endpoint: srv.URL, URLPrefix: "http://localhost:8080/"}
+	if err := c.NotifyReload(ReloadMessage{}); err != nil {
+		t.Fatal(err)
+	}
+	devtools.mu.Lock()
+	reloads := devtools.reloads
+	devtools.mu.Unlock()
+	if len(reloads) != 1 || reloads[0] != "http://localhost:8080/" {
+		t.Errorf("expected only the matching tab to reload, got %v", reloads)
+	}
+
+	devtools.fail = true
+	if err := c.NotifyReload(ReloadMessage{}); err == nil || !strings.Contains(err.Error(), "reload refused") {
+		t.Errorf("expected the DevTools error, got %v", err)
+	}
+
+	c = &CDPReload{Endpoint: "http://127.0.0.1:1"}
+	if err := c.NotifyReload(ReloadMessage{}); err == nil {
+		t.Error("expected an error without a browser")
+	}
+}
@@ -0,0 +1,116 @@
+package devwatch
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is an in-memory key/value store owned by the watcher and shared by
+// its handlers, eg: parsed package data computed by the server build and
+// reused by the wasm build and the test runner. Handlers receive a view
+// scoped to their name through the context (see CacheFrom); Shared returns
+// the view common to all handlers. Values must be safe for concurrent reads.
+type Cache struct {
+	store *cacheStore
+	scope string // key prefix eg: "server/"
+}
+
+type cacheStore struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	sets    int // Set calls since the last sweep of expired entries
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time // zero: never
+}
+
+// cacheSweepEvery is the number of Set calls between sweeps of expired entries
+const cacheSweepEvery = 256
+
+// Cache returns the shared cache of the watcher
+func (h *DevWatch) Cache() *Cache {
+	h.cacheOnce.Do(func() { h.cache = &Cache{store: &cacheStore{}} })
+	return h.cache
+}
+
+// Get returns the value stored for key unless it expired
+func (c *Cache) Get(key string) (any, bool) {
+	key = c.scope + key
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	entry, ok := c.store.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(c.store.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores value for key; it expires after ttl (0 keeps it until deleted)
+func (c *Cache) Set(key string, value any, ttl time.Duration) {
+	entry := cacheEntry{value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	if c.store.entries == nil {
+		c.store.entries = make(map[string]cacheEntry)
+	}
+	c.store.entries[c.scope+key] = entry
+
+	c.store.sets++
+	if c.store.sets >= cacheSweepEvery {
+		c.store.sets = 0
+		now := time.Now()
+		for k, e := range c.store.entries {
+			if !e.expires.IsZero() && now.After(e.expires) {
+				delete(c.store.entries, k)
+			}
+		}
+	}
+}
+
+// Delete removes key
+func (c *Cache) Delete(key string) {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	delete(c.store.entries, c.scope+key)
+}
+
+// Clear removes every key of this view (the whole cache for Shared)
+func (c *Cache) Clear() {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	for k := range c.store.entries {
+		if strings.HasPrefix(k, c.scope) {
+			delete(c.store.entries, k)
+		}
+	}
+}
+
+// Scope returns a view whose keys don't collide with other scopes, eg: per handler
+func (c *Cache) Scope(name string) *Cache {
+	return &Cache{store: c.store, scope: c.scope + name + "/"}
+}
+
+// Shared returns the view common to all handlers
+func (c *Cache) Shared() *Cache {
+	return &Cache{store: c.store}
+}
+
+type cacheKey struct{}
+
+// CacheFrom returns the cache scoped to the running handler from the context
+// received by ContextFileChangeHandler, or nil outside handlers
+func CacheFrom(ctx context.Context) *Cache {
+	c, _ := ctx.Value(cacheKey{}).(*Cache)
+	return c
+}
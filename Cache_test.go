@@ -0,0 +1,95 @@
+package devwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// cachingHandler reads and writes the cache received through the context
+type cachingHandler struct {
+	name string
+	run  func(c *Cache)
+}
+
+func (c *cachingHandler) SupportedExtensions() []string { return []string{".go"} }
+func (c *cachingHandler) Name() string                  { return c.name }
+func (c *cachingHandler) OwnsAllGoFiles() bool          { return true }
+func (c *cachingHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	panic("NewFileChangeContext must be preferred")
+}
+func (c *cachingHandler) NewFileChangeContext(ctx context.Context, change FileChange) error {
+	c.run(CacheFrom(ctx))
+	return nil
+}
+
+func TestCache_SharedBetweenHandlers(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var shared, scoped any
+	var sharedOK, scopedOK bool
+	server := &cachingHandler{name: "server", run: func(c *Cache) {
+		c.Set("parsed", "own", 0)
+		c.Shared().Set("packages", []string{"a/api"}, time.Minute)
+	}}
+	wasm := &cachingHandler{name: "wasm", run: func(c *Cache) {
+		shared, sharedOK = c.Shared().Get("packages")
+		scoped, scopedOK = c.Get("parsed")
+	}}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{server, wasm},
+		Logger:             func(message ...any) {},
+	})
+	if err := w.Trigger("main.go", "write"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !sharedOK || len(shared.([]string)) != 1 {
+		t.Errorf("expected the shared value set by server, got %v", shared)
+	}
+	if scopedOK {
+		t.Errorf("handler scoped keys must not leak, got %v", scoped)
+	}
+	if v, ok := w.Cache().Scope("server").Get("parsed"); !ok || v != "own" {
+		t.Errorf("expected the server scoped value, got %v", v)
+	}
+	if CacheFrom(context.Background()) != nil {
+		t.Error("expected no cache outside handlers")
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	c := New(&WatchConfig{}).Cache()
+	c.Set("short", 1, 20*time.Millisecond)
+	c.Set("long", 2, 0)
+	if _, ok := c.Get("short"); !ok {
+		t.Fatal("expected the value before its TTL")
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Error("expected the value to expire")
+	}
+	if v, ok := c.Get("long"); !ok || v != 2 {
+		t.Error("expected values without TTL to stay")
+	}
+
+	scoped := c.Scope("test")
+	scoped.Set("k", 3, 0)
+	scoped.Clear()
+	if _, ok := scoped.Get("k"); ok {
+		t.Error("expected Clear to remove the scoped keys")
+	}
+	if _, ok := c.Get("long"); !ok {
+		t.Error("Clear of a scope must keep the other keys")
+	}
+	c.Delete("long")
+	if _, ok := c.Get("long"); ok {
+		t.Error("expected Delete to remove the key")
+	}
+}
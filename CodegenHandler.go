@@ -0,0 +1,92 @@
+package devwatch
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CodegenHandler is an optional capability for handlers generating Go code
+// (eg: protoc from .proto files). Watcher events of the generated paths are
+// suppressed; instead, once the handler succeeded, the Go handlers run for
+// each generated package that changed, so protoc → build → reload needs no
+// glue and builds once.
+type CodegenHandler interface {
+	GeneratedPaths() []string // files or folders relative to AppRootDir eg: ["gen"]
+}
+
+// Codegen is a CodegenHandler running a command in the "codegen" pipeline
+// stage, eg:
+//
+//	&devwatch.Codegen{
+//		ExecHandler: devwatch.ExecHandler{
+//			Command:    []string{"sh", "-c", "protoc --go_out=gen $DEVWATCH_PATH"},
+//			Extensions: []string{".proto"},
+//			Dir:        appRoot,
+//		},
+//		Generated: []string{"gen"},
+//	}
+type Codegen struct {
+	ExecHandler
+	Generated []string // outputs relative to AppRootDir eg: []string{"gen"}
+}
+
+// GeneratedPaths implements CodegenHandler
+func (c *Codegen) GeneratedPaths() []string { return c.Generated }
+
+// Stage implements StagedHandler
+func (c *Codegen) Stage() string { return "codegen" }
+
+// DependsOn implements StagedHandler
+func (c *Codegen) DependsOn() []string { return nil }
+
+// generatedPaths returns the absolute generated paths declared by handler
+func (h *DevWatch) generatedPaths(handler FilesEventHandlers) []string {
+	ch, ok := handler.(CodegenHandler)
+	if !ok {
+		return nil
+	}
+	var paths []string
+	for _, path := range ch.GeneratedPaths() {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(h.AppRootDir, path)
+		}
+		paths = append(paths, filepath.Clean(path))
+	}
+	return paths
+}
+
+// isGenerated reports whether path is the output of a CodegenHandler
+func (h *DevWatch) isGenerated(path string) bool {
+	for _, handler := range h.FilesEventHandlers {
+		for _, generated := range h.generatedPaths(handler) {
+			if path == generated || strings.HasPrefix(path, generated+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generatedChanges returns one Go file per generated package written by
+// handler since start
+func (h *DevWatch) generatedChanges(handler FilesEventHandlers, start time.Time) []string {
+	// coarse mtime resolution on some file systems
+	since := start.Truncate(time.Second)
+	seen := make(map[string]bool)
+	var changed []string
+	for _, root := range h.generatedPaths(handler) {
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".go" || seen[filepath.Dir(path)] {
+				return nil
+			}
+			if info, err := d.Info(); err == nil && !info.ModTime().Before(since) {
+				seen[filepath.Dir(path)] = true
+				changed = append(changed, path)
+			}
+			return nil
+		})
+	}
+	return changed
+}
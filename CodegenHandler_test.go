@@ -0,0 +1,70 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// goPathsHandler records the .go files it handled
+type goPathsHandler struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (g *goPathsHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.paths = append(g.paths, filePath)
+	return nil
+}
+func (g *goPathsHandler) SupportedExtensions() []string { return []string{".go"} }
+func (g *goPathsHandler) OwnsAllGoFiles() bool          { return true }
+func (g *goPathsHandler) Stage() string                 { return "compile" }
+func (g *goPathsHandler) DependsOn() []string           { return []string{"codegen"} }
+
+func TestCodegenHandler(t *testing.T) {
+	root := t.TempDir()
+	protoFile := filepath.Join(root, "api.proto")
+	if err := os.WriteFile(protoFile, []byte("syntax = \"proto3\";"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	codegen := &Codegen{
+		ExecHandler: ExecHandler{
+			Command:    []string{"sh", "-c", "mkdir -p gen/pb && echo 'package pb' > gen/pb/api.pb.go && echo 'package pb' > gen/pb/types.pb.go"},
+			Extensions: []string{".proto"},
+			Dir:        root,
+		},
+		Generated: []string{"gen"},
+	}
+	compile := &goPathsHandler{}
+	var reloads atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{compile, codegen},
+		BrowserReload:      func() error { reloads.Add(1); return nil },
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	if err := w.Trigger(protoFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if len(compile.paths) != 1 || filepath.Dir(compile.paths[0]) != filepath.Join(root, "gen", "pb") {
+		t.Errorf("expected one Go build for the generated package, got %v", compile.paths)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if got := reloads.Load(); got != 1 {
+		t.Errorf("expected a single reload after the generated build, got %d", got)
+	}
+
+	if !w.isGenerated(filepath.Join(root, "gen", "pb", "api.pb.go")) {
+		t.Error("generated files must be suppressed from watcher events")
+	}
+	if w.isGenerated(filepath.Join(root, "generator.go")) {
+		t.Error("only paths inside the generated folder are suppressed")
+	}
+}
@@ -3,14 +3,25 @@ package devwatch
 import (
 	"path/filepath"
 	"strings"
+	"sync"
 )
 
+// Contain reports whether path is unobserved: ignored by UnobservedFiles,
+// a handler's UnobservedFiles, DefaultIgnores, a hidden file or a devwatch internal path.
+// Decisions are cached per path until the rules change.
 func (h *DevWatch) Contain(path string) bool {
+	h.initUnobserved()
+	version := h.ruleVersion()
+	if decision, ok := h.containCache.get(path, version); ok {
+		return decision
+	}
+	decision := h.contain(path)
+	h.containCache.put(path, version, decision)
+	return decision
+}
 
-	// Normaliza la ruta a formato Unix para compatibilidad multiplataforma
-	// Convertir manualmente las barras invertidas a barras normales
-	normPath := strings.ReplaceAll(path, "\\", "/")
-
+// initUnobserved fills no_add_to_watch from WatchConfig.UnobservedFiles on first use
+func (h *DevWatch) initUnobserved() {
 	// Initialize the no_add_to_watch map if needed, BEFORE any checks
 	// Use a mutex to avoid concurrent map read/write races when tests or
 	// different goroutines call Contain concurrently while the map is being
@@ -30,19 +41,22 @@ func (h *DevWatch) Contain(path string) bool {
 		}
 	}
 	h.noAddMu.Unlock()
+}
 
-	// Try to convert absolute path to relative path for matching
-	// UnobservedFiles() returns relative paths, so we need to compare relative to relative
-	relPath := normPath
-	if h.AppRootDir != "" {
-		normalizedRoot := strings.ReplaceAll(h.AppRootDir, "\\", "/")
-		// Ensure root doesn't end with /
-		normalizedRoot = strings.TrimSuffix(normalizedRoot, "/")
-		if strings.HasPrefix(normPath, normalizedRoot+"/") {
-			relPath = strings.TrimPrefix(normPath, normalizedRoot+"/")
-		}
+// contain evaluates the rules for path without the cache
+func (h *DevWatch) contain(path string) bool {
+	// files written by devwatch itself are never observed
+	if h.isInternalPath(path) {
+		return true
 	}
 
+	// Normaliza la ruta a formato Unix para compatibilidad multiplataforma
+	np := h.normalize(path)
+	normPath := np.Slash
+
+	// UnobservedFiles() returns relative paths, so we need to compare relative to relative
+	relPath := np.Rel
+
 	// Check for exact match against the full paths in the ignore list FIRST
 	h.noAddMu.RLock()
 	// Try both absolute and relative paths
@@ -57,9 +71,14 @@ func (h *DevWatch) Contain(path string) bool {
 		}
 	}
 
-	// Split the normalized path into components and check each part
-	pathParts := strings.Split(normPath, "/")
-	for _, part := range pathParts {
+	// Check each component of the normalized path (without splitting: no allocations)
+	for rest := normPath; rest != ""; {
+		part := rest
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			part, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = ""
+		}
 		if part == "" {
 			continue
 		}
@@ -73,9 +92,8 @@ func (h *DevWatch) Contain(path string) bool {
 	}
 
 	// Additionally, check for paths that start with an ignored path + separator
-	for ignoredPath := range h.no_add_to_watch {
-		ignoredNorm := filepath.ToSlash(ignoredPath)
-		if strings.HasPrefix(normPath, ignoredNorm+"/") {
+	for _, ignoredPrefix := range h.ignorePrefixes() {
+		if strings.HasPrefix(normPath, ignoredPrefix) {
 			/* if strings.Contains(normPath, ".git") && h.Writer != nil {
 				fmt.Fprintf(h.Writer, "[DEBUG] Prefix match found: %s starts with %s/ - RETURNING TRUE\n", normPath, ignoredNorm)
 			} */
@@ -85,20 +103,27 @@ func (h *DevWatch) Contain(path string) bool {
 	}
 	h.noAddMu.RUnlock()
 
-	// Check if the file extension matches any ignored pattern
-	ext := filepath.Ext(normPath)
-	if ext != "" {
-		h.noAddMu.RLock()
-		if _, exists := h.no_add_to_watch[ext]; exists {
-			h.noAddMu.RUnlock()
-			return true
-		}
-		h.noAddMu.RUnlock()
+	// VCS metadata and tool folders (WatchConfig.DefaultIgnores)
+	if h.defaultIgnored(np) && !vendorTracked(np.Rel) {
+		return true
+	}
+
+	// Check if any file extension (including compound ones like ".gen.go")
+	// matches an ignored pattern
+	h.noAddMu.RLock()
+	ignoredExt := false
+	eachExtension(normPath, func(ext string) bool {
+		_, ignoredExt = h.no_add_to_watch[ext]
+		return !ignoredExt
+	})
+	h.noAddMu.RUnlock()
+	if ignoredExt {
+		return true
 	}
 
 	// ignore other hidden files (but not .git which is handled above)
 	baseName := filepath.Base(normPath)
-	if strings.HasPrefix(baseName, ".") && baseName != ".git" {
+	if strings.HasPrefix(baseName, ".") && baseName != ".git" && !h.filenameClaimed(baseName) {
 		/* if strings.Contains(normPath, ".git") && h.Writer != nil {
 			fmt.Fprintf(h.Writer, "[DEBUG] Hidden file (not .git): %s - RETURNING TRUE\n", normPath)
 		} */
@@ -111,3 +136,26 @@ func (h *DevWatch) Contain(path string) bool {
 
 	return false
 }
+
+// ignoreRules caches the ignore entries compiled as "entry/" prefixes.
+// Entries are only ever added, so a size change means the cache is stale.
+type ignoreRules struct {
+	mu       sync.Mutex
+	built    bool
+	size     int
+	prefixes []string
+}
+
+// ignorePrefixes returns the compiled prefixes. Callers must hold noAddMu (read).
+func (h *DevWatch) ignorePrefixes() []string {
+	h.rules.mu.Lock()
+	defer h.rules.mu.Unlock()
+	if !h.rules.built || h.rules.size != len(h.no_add_to_watch) {
+		h.rules.prefixes = make([]string, 0, len(h.no_add_to_watch))
+		for ignoredPath := range h.no_add_to_watch {
+			h.rules.prefixes = append(h.rules.prefixes, slashPath(ignoredPath)+"/")
+		}
+		h.rules.built, h.rules.size = true, len(h.no_add_to_watch)
+	}
+	return h.rules.prefixes
+}
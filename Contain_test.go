@@ -0,0 +1,54 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestContainAllocations guards the event hot path against per-event allocations
+func TestContainAllocations(t *testing.T) {
+	root := t.TempDir()
+	w := New(&WatchConfig{
+		AppRootDir:      root,
+		UnobservedFiles: func() []string { return []string{".git", "node_modules", "web/dist", ".exe"} },
+	})
+	observed := filepath.Join(root, "web", "css", "style.tmpl.css")
+	ignored := filepath.Join(root, "node_modules", "lib", "app.js")
+	w.Contain(observed) // build ignore rules
+
+	if w.Contain(observed) || !w.Contain(ignored) {
+		t.Fatal("unexpected Contain result")
+	}
+	if n := testing.AllocsPerRun(100, func() { w.Contain(observed) }); n != 0 {
+		t.Errorf("Contain allocates %v times per call, want 0", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { eventTypeOf(1) }); n != 0 {
+		t.Errorf("eventTypeOf allocates %v times per call, want 0", n)
+	}
+}
+
+func TestContainCache(t *testing.T) {
+	root := t.TempDir()
+	w := New(&WatchConfig{
+		AppRootDir:      root,
+		UnobservedFiles: func() []string { return []string{".git"} },
+	})
+	file := filepath.Join(root, "build", "app.js")
+
+	if w.Contain(file) || w.Contain(file) {
+		t.Fatal("expected file to be observed")
+	}
+	if s := w.ContainCacheStats(); s.Hits != 1 || s.Misses != 1 || s.Entries != 1 {
+		t.Errorf("unexpected cache stats: %+v", s)
+	}
+
+	// new rules invalidate the cached decision
+	w.AddFilesEventHandlers(&FakeFilesEventHandler{Unobserved: []string{"build"}})
+	if !w.Contain(file) {
+		t.Error("expected cached decision to be invalidated by new ignore rules")
+	}
+	w.RegisterInternalPath("state")
+	if !w.Contain(filepath.Join(root, "state", "journal")) {
+		t.Error("expected internal path registered after caching to be unobserved")
+	}
+}
@@ -0,0 +1,290 @@
+package devwatch
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Daemon shares one watcher with other processes (a TUI, a test runner, an
+// editor plugin) over a unix socket, so they don't each create their own
+// inotify watches. Clients connect with Attach and receive the event stream
+// of Subscribe; they can also Trigger paths and ForceReload.
+//
+// The protocol is newline delimited JSON: the daemon sends {"ready":true}
+// once subscribed, then {"event":EventRecord} and {"reply":id,"error":"..."}
+// messages; clients send {"id":n,"op":"trigger"|"reload","path":"...","event":"..."}.
+type Daemon struct {
+	h      *DevWatch
+	ln     net.Listener
+	socket string
+	mu     sync.Mutex
+	conns  map[net.Conn]bool
+	closed bool
+}
+
+type daemonRequest struct {
+	ID    uint64 `json:"id"`
+	Op    string `json:"op"` // trigger or reload
+	Path  string `json:"path,omitempty"`
+	Event string `json:"event,omitempty"`
+}
+
+type daemonMessage struct {
+	Ready bool         `json:"ready,omitempty"` // first message, the client receives every later event
+	Event *EventRecord `json:"event,omitempty"`
+	Reply uint64       `json:"reply,omitempty"`
+	Error string       `json:"error,omitempty"`
+}
+
+// Share starts serving the watcher on the unix socket path (relative paths
+// are resolved against AppRootDir, eg: ".devwatch.sock"). It fails when
+// another daemon answers on the socket; a socket file left by a crashed
+// daemon is replaced. Close stops serving and removes the socket file.
+func (h *DevWatch) Share(socket string) (*Daemon, error) {
+	if !filepath.IsAbs(socket) && h.AppRootDir != "" {
+		socket = filepath.Join(h.AppRootDir, socket)
+	}
+	if _, err := os.Stat(socket); err == nil {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			conn.Close()
+			return nil, errors.New("Share: a devwatch daemon is already serving " + socket)
+		}
+		os.Remove(socket) // stale socket of a crashed daemon
+	}
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	h.RegisterInternalPath(socket)
+	d := &Daemon{h: h, ln: ln, socket: socket, conns: make(map[net.Conn]bool)}
+	go d.accept()
+	return d, nil
+}
+
+// Socket returns the path clients pass to Attach
+func (d *Daemon) Socket() string { return d.socket }
+
+// Clients returns the number of attached clients
+func (d *Daemon) Clients() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.conns)
+}
+
+// Close disconnects the clients, stops listening and removes the socket
+// file. The watcher itself keeps running.
+func (d *Daemon) Close() error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	for conn := range d.conns {
+		conn.Close()
+	}
+	d.mu.Unlock()
+	err := d.ln.Close()
+	os.Remove(d.socket)
+	return err
+}
+
+func (d *Daemon) accept() {
+	for {
+		conn, err := d.ln.Accept()
+		if err != nil {
+			return // closed
+		}
+		d.mu.Lock()
+		if d.closed {
+			d.mu.Unlock()
+			conn.Close()
+			return
+		}
+		d.conns[conn] = true
+		d.mu.Unlock()
+		go d.serve(conn)
+	}
+}
+
+// serve streams events to a client and answers its requests until it
+// disconnects
+func (d *Daemon) serve(conn net.Conn) {
+	events, cancel := d.h.Subscribe()
+	defer func() {
+		cancel()
+		conn.Close()
+		d.mu.Lock()
+		delete(d.conns, conn)
+		d.mu.Unlock()
+	}()
+
+	var writeMu sync.Mutex
+	enc := json.NewEncoder(conn)
+	send := func(msg daemonMessage) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return enc.Encode(msg)
+	}
+
+	if send(daemonMessage{Ready: true}) != nil {
+		return
+	}
+	go func() {
+		for rec := range events {
+			if send(daemonMessage{Event: &rec}) != nil {
+				conn.Close()
+				return
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var req daemonRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			d.h.say("daemon-invalid-request", err)
+			continue
+		}
+		var err error
+		switch req.Op {
+		case "trigger":
+			err = d.h.Trigger(req.Path, req.Event)
+		case "reload":
+			err = d.h.ForceReload()
+		default:
+			err = errors.New("unknown op " + req.Op)
+		}
+		msg := daemonMessage{Reply: req.ID}
+		if err != nil {
+			msg.Error = err.Error()
+		}
+		if send(msg) != nil {
+			return
+		}
+	}
+}
+
+// DaemonClient is a connection to a watcher shared with Share
+type DaemonClient struct {
+	conn    net.Conn
+	events  chan EventRecord
+	mu      sync.Mutex // guards enc, nextID, pending and done
+	enc     *json.Encoder
+	nextID  uint64
+	pending map[uint64]chan error
+	done    bool
+}
+
+// errDaemonClosed is returned for requests still waiting when the
+// connection ends
+var errDaemonClosed = errors.New("devwatch daemon connection closed")
+
+// Attach connects to the daemon serving socket. Events receives the
+// records of every processed event like Subscribe, dropping them while the
+// client falls behind, and is closed when the connection ends.
+func Attach(socket string) (*DaemonClient, error) {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	c := &DaemonClient{
+		conn:    conn,
+		events:  make(chan EventRecord, subscriberBuffer),
+		enc:     json.NewEncoder(conn),
+		pending: make(map[uint64]chan error),
+	}
+	// wait until the daemon subscribed so no event after Attach is missed
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(nil, 1<<20)
+	var hello daemonMessage
+	if !scanner.Scan() || json.Unmarshal(scanner.Bytes(), &hello) != nil || !hello.Ready {
+		conn.Close()
+		return nil, errors.New("Attach: no devwatch daemon handshake on " + socket)
+	}
+	go c.read(scanner)
+	return c, nil
+}
+
+// Events returns the event stream of the shared watcher
+func (c *DaemonClient) Events() <-chan EventRecord { return c.events }
+
+// Trigger runs the handler pipeline for path in the daemon, see DevWatch.Trigger
+func (c *DaemonClient) Trigger(path, event string) error {
+	return c.call(daemonRequest{Op: "trigger", Path: path, Event: event})
+}
+
+// ForceReload reloads the browser through the daemon, see DevWatch.ForceReload
+func (c *DaemonClient) ForceReload() error {
+	return c.call(daemonRequest{Op: "reload"})
+}
+
+// Close disconnects from the daemon
+func (c *DaemonClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *DaemonClient) call(req daemonRequest) error {
+	reply := make(chan error, 1)
+	c.mu.Lock()
+	if c.done {
+		c.mu.Unlock()
+		return errDaemonClosed
+	}
+	c.nextID++
+	req.ID = c.nextID
+	c.pending[req.ID] = reply
+	err := c.enc.Encode(req)
+	if err != nil {
+		delete(c.pending, req.ID)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return <-reply
+}
+
+// read dispatches daemon messages until the connection ends
+func (c *DaemonClient) read(scanner *bufio.Scanner) {
+	defer func() {
+		c.mu.Lock()
+		c.done = true
+		for id, reply := range c.pending {
+			reply <- errDaemonClosed
+			delete(c.pending, id)
+		}
+		c.mu.Unlock()
+		close(c.events)
+	}()
+
+	for scanner.Scan() {
+		var msg daemonMessage
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil {
+			continue
+		}
+		if msg.Event != nil {
+			select {
+			case c.events <- *msg.Event:
+			default:
+			}
+			continue
+		}
+		c.mu.Lock()
+		reply, ok := c.pending[msg.Reply]
+		delete(c.pending, msg.Reply)
+		c.mu.Unlock()
+		if ok {
+			if msg.Error != "" {
+				reply <- errors.New(msg.Error)
+			} else {
+				reply <- nil
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDaemon_Attach(t *testing.T) {
+	// unix socket paths are limited to ~100 bytes, keep the root short
+	root, err := os.MkdirTemp("", "dw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	if err := os.WriteFile(filepath.Join(root, "style.css"), []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var called int32
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".css"},
+		}},
+		BrowserReload: func() error { return nil },
+		Logger:        func(message ...any) {},
+	})
+
+	d, err := w.Share(".devwatch.sock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.Contain(d.Socket()) {
+		t.Error("the socket must not be observed")
+	}
+	if _, err := w.Share(".devwatch.sock"); err == nil {
+		t.Error("expected error for a socket already served")
+	}
+
+	first, err := Attach(d.Socket())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Attach(d.Socket())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+
+	if err := first.Trigger("style.css", "write"); err != nil {
+		t.Fatalf("Trigger through the daemon: %v", err)
+	}
+	if atomic.LoadInt32(&called) != 1 {
+		t.Fatal("expected the shared watcher to run the handler")
+	}
+	if err := first.Trigger("missing.css", "write"); err == nil {
+		t.Error("expected the daemon error to reach the client")
+	}
+
+	// both clients share the event stream
+	for _, c := range []*DaemonClient{first, second} {
+		select {
+		case rec := <-c.Events():
+			if filepath.Base(rec.Path) != "style.css" || len(rec.Handlers) != 1 {
+				t.Errorf("unexpected record %+v", rec)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected an event record")
+		}
+	}
+
+	first.Close()
+	if err := first.Trigger("style.css", "write"); err == nil {
+		t.Error("expected error after Close")
+	}
+
+	d.Close()
+	select {
+	case _, ok := <-second.Events():
+		for ok {
+			_, ok = <-second.Events()
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the event stream to close with the daemon")
+	}
+	if _, err := os.Stat(d.Socket()); !os.IsNotExist(err) {
+		t.Error("expected the socket file to be removed")
+	}
+
+	// a socket left by a crashed daemon is replaced
+	if err := os.WriteFile(d.Socket(), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	d, err = w.Share(".devwatch.sock")
+	if err != nil {
+		t.Fatalf("expected stale socket to be replaced: %v", err)
+	}
+	d.Close()
+}
@@ -0,0 +1,122 @@
+package devwatch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DebouncePolicy selects how a burst of events reaches a DebouncedHandler
+type DebouncePolicy int
+
+const (
+	// EveryEvent calls the handler for each event (the default)
+	EveryEvent DebouncePolicy = iota
+	// LeadingEdge calls the handler at once for the first event of a burst.
+	// Events arriving before the handler saw no event for the quiet period
+	// are coalesced into one more call at the end, so the last state is built.
+	LeadingEdge
+	// TrailingEdge waits until the handler saw no event for the quiet
+	// period, then calls it once, eg: for IDE "save all" of a refactor
+	TrailingEdge
+)
+
+// DebouncedHandler is an optional capability for handlers choosing how
+// bursts of events reach them. A coalesced call receives the last event of
+// the burst, so policies suit handlers rebuilding a whole target (a Go
+// binary, a bundle) rather than processing each file. The browser reload
+// waits for the coalesced call like for AsyncFileEventHandler work.
+type DebouncedHandler interface {
+	Debounce() (policy DebouncePolicy, quiet time.Duration)
+}
+
+// debounceBurst is the burst of events a debounced handler is receiving
+type debounceBurst struct {
+	id      int // index in FilesEventHandlers
+	handler FilesEventHandlers
+	timer   *time.Timer
+	gen     int          // incremented by each event, a stale timer's generation is older
+	last    *FileChange  // waiting for the end of the burst, nil when none
+	waiters []chan error // results of the events coalesced into last
+}
+
+// debouncer holds the bursts per handler index
+type debouncer struct {
+	mu     sync.Mutex
+	bursts map[int]*debounceBurst
+}
+
+// debounce applies the DebouncePolicy of handler, of index id, to change. It returns
+// later=true with a channel receiving the result of the coalesced call when
+// the handler must not be called now.
+func (h *DevWatch) debounce(id int, handler FilesEventHandlers, change FileChange) (result <-chan error, later bool) {
+	dh, ok := handler.(DebouncedHandler)
+	if !ok {
+		return nil, false
+	}
+	policy, quiet := dh.Debounce()
+	if policy == EveryEvent || quiet <= 0 {
+		return nil, false
+	}
+
+	h.debounces.mu.Lock()
+	defer h.debounces.mu.Unlock()
+	if h.debounces.bursts == nil {
+		h.debounces.bursts = make(map[int]*debounceBurst)
+	}
+	burst := h.debounces.bursts[id]
+	starts := burst == nil
+	if starts {
+		burst = &debounceBurst{id: id, handler: handler}
+		h.debounces.bursts[id] = burst
+	} else {
+		burst.timer.Stop()
+	}
+	burst.gen++
+	gen := burst.gen
+	burst.timer = time.AfterFunc(quiet, func() { h.endBurst(burst, gen) })
+
+	if starts && policy == LeadingEdge {
+		return nil, false
+	}
+	ch := make(chan error, 1)
+	burst.last = &change
+	burst.waiters = append(burst.waiters, ch)
+	return ch, true
+}
+
+// endBurst runs the coalesced call of a burst once the quiet period passed
+func (h *DevWatch) endBurst(burst *debounceBurst, gen int) {
+	h.debounces.mu.Lock()
+	if h.debounces.bursts[burst.id] != burst || burst.gen != gen {
+		h.debounces.mu.Unlock()
+		return // a later event restarted the timer
+	}
+	delete(h.debounces.bursts, burst.id)
+	last, waiters := burst.last, burst.waiters
+	h.debounces.mu.Unlock()
+	if last == nil {
+		return // leading edge call only
+	}
+
+	h.acquireDispatch()
+	rec := h.newEventRecord(last.FilePath, last.Event)
+	start := time.Now()
+	var err error
+	if ah, ok := burst.handler.(AsyncFileEventHandler); ok {
+		err = <-ah.NewFileEventAsync(last.FileName, last.Extension, last.FilePath, last.Event)
+	} else {
+		err = h.callHandler(context.Background(), burst.handler, *last)
+	}
+	rec.addResult(burst.handler, start, err)
+	rec.Reload = err == nil
+	if err != nil && h.OnError != nil && DiagnosticsOf(err) != nil {
+		h.OnError(err)
+	}
+	h.releaseDispatch()
+	h.publish(rec)
+
+	for _, ch := range waiters {
+		ch <- err
+	}
+}
@@ -0,0 +1,139 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// burstBuilder records its calls and debounces with policy
+type burstBuilder struct {
+	policy DebouncePolicy
+	mu     sync.Mutex
+	calls  []string
+}
+
+func (b *burstBuilder) NewFileEvent(fileName, extension, filePath, event string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, fileName)
+	return nil
+}
+
+func (b *burstBuilder) SupportedExtensions() []string { return []string{".css"} }
+
+func (b *burstBuilder) Debounce() (DebouncePolicy, time.Duration) {
+	return b.policy, 100 * time.Millisecond
+}
+
+func (b *burstBuilder) recorded() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+func TestDebouncedHandler(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy DebouncePolicy
+		now    []string // calls right after the burst
+		later  []string // calls once the quiet period passed
+	}{
+		{"every event", EveryEvent, []string{"a.css", "b.css", "c.css"}, []string{"a.css", "b.css", "c.css"}},
+		{"leading edge", LeadingEdge, []string{"a.css"}, []string{"a.css", "c.css"}},
+		{"trailing edge", TrailingEdge, nil, []string{"c.css"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			builder := &burstBuilder{policy: tc.policy}
+			var reloads atomic.Int32
+			w := New(&WatchConfig{
+				AppRootDir:         tempDir,
+				FilesEventHandlers: []FilesEventHandlers{builder},
+				BrowserReload:      func() error { reloads.Add(1); return nil },
+				Logger:             func(message ...any) {},
+			})
+
+			// "save all"
+			for _, name := range []string{"a.css", "b.css", "c.css"} {
+				path := filepath.Join(tempDir, name)
+				os.WriteFile(path, []byte(name), 0644)
+				if err := w.Trigger(path, "write"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := builder.recorded(); !slices.Equal(got, tc.now) {
+				t.Errorf("calls during the burst = %v, want %v", got, tc.now)
+			}
+
+			time.Sleep(300 * time.Millisecond)
+			if got := builder.recorded(); !slices.Equal(got, tc.later) {
+				t.Errorf("calls after the burst = %v, want %v", got, tc.later)
+			}
+			if reloads.Load() == 0 {
+				t.Error("expected a browser reload")
+			}
+		})
+	}
+}
+
+func TestDebouncedHandler_SameType(t *testing.T) {
+	tempDir := t.TempDir()
+	a, b := &burstBuilder{policy: TrailingEdge}, &burstBuilder{policy: TrailingEdge}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{a, b},
+		Logger:             func(message ...any) {},
+	})
+	path := filepath.Join(tempDir, "a.css")
+	os.WriteFile(path, []byte("a"), 0644)
+	if err := w.Trigger(path, "write"); err != nil {
+		t.Fatal(err)
+	}
+
+	// unnamed handlers of one type keep their own burst
+	time.Sleep(300 * time.Millisecond)
+	if got, want := [][]string{a.recorded(), b.recorded()}, []string{"a.css"}; !slices.Equal(got[0], want) || !slices.Equal(got[1], want) {
+		t.Errorf("calls = %v, want %v for each handler", got, want)
+	}
+}
+
+// sliceDebounce is a value type debounced handler that can't key a map
+type sliceDebounce struct {
+	exts  []string
+	calls *atomic.Int32
+}
+
+func (s sliceDebounce) NewFileEvent(fileName, extension, filePath, event string) error {
+	s.calls.Add(1)
+	return nil
+}
+
+func (s sliceDebounce) SupportedExtensions() []string { return s.exts }
+
+func (s sliceDebounce) Debounce() (DebouncePolicy, time.Duration) {
+	return TrailingEdge, 100 * time.Millisecond
+}
+
+func TestDebouncedHandler_ValueHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	var calls atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{sliceDebounce{exts: []string{".css"}, calls: &calls}},
+		Logger:             func(message ...any) {},
+	})
+	for _, name := range []string{"a.css", "b.css"} {
+		path := filepath.Join(tempDir, name)
+		os.WriteFile(path, []byte(name), 0644)
+		go w.Trigger(path, "write")
+	}
+	time.Sleep(300 * time.Millisecond)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected one coalesced call, got %d", got)
+	}
+}
@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultIgnores are directory names never watched inside the watch roots:
+// version control metadata and tool folders that only add noise. See
+// WatchConfig.DefaultIgnores and DefaultIgnoreDirs.
+var defaultIgnores = []string{".git", ".hg", ".svn", ".jj", ".bzr", "node_modules", "vendor", ".terraform"}
+
+// ignoredDirs returns the default ignored directory names of the config,
+// nil when DefaultIgnores is off
+func (c *WatchConfig) ignoredDirs() []string {
+	if c.DefaultIgnores != nil && !*c.DefaultIgnores {
+		return nil
+	}
+	if c.DefaultIgnoreDirs != nil {
+		return c.DefaultIgnoreDirs
+	}
+	return defaultIgnores
+}
+
+// defaultIgnored reports whether a component of np below a watch root is a default ignored directory
+func (h *DevWatch) defaultIgnored(np normalizedPath) bool {
+	dirs := h.ignoredDirs()
+	if len(dirs) == 0 {
+		return false
+	}
+	rel := np.Rel
+	if isAbsSlash(rel) { // outside AppRootDir: only match below an extra root
+		rel = ""
+		for _, root := range h.ExtraRootDirs {
+			if r, err := GetRelPath(absSlashPath(root), np.Abs); err == nil {
+				rel = r
+				break
+			}
+		}
+	}
+	if h.VendorChanges && isVendored(rel) {
+		rel = rel[len("vendor/"):] // the module vendor/ folder is watched
+	}
+	for rest := rel; rest != ""; {
+		part := rest
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			part, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = ""
+		}
+		if slices.Contains(dirs, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultIgnores(t *testing.T) {
+	root := t.TempDir()
+	extra := t.TempDir()
+	w := New(&WatchConfig{AppRootDir: root, ExtraRootDirs: []string{extra}})
+
+	for _, p := range []string{
+		filepath.Join(root, ".hg", "store", "data"),
+		filepath.Join(root, "web", "node_modules", "lib", "app.js"),
+		filepath.Join(root, "vendor", "github.com", "x", "x.go"),
+		filepath.Join(extra, ".svn", "entries"),
+		filepath.Join(root, "infra", ".terraform"),
+	} {
+		if !w.Contain(p) {
+			t.Errorf("expected %s to be ignored by default", p)
+		}
+	}
+	if w.Contain(filepath.Join(root, "web", "app.js")) {
+		t.Error("expected regular file to be observed")
+	}
+
+	// a root living below a folder named like a default ignore is still watched
+	nested := filepath.Join(t.TempDir(), "vendor", "app")
+	if New(&WatchConfig{AppRootDir: nested}).Contain(filepath.Join(nested, "main.go")) {
+		t.Error("expected DefaultIgnores to only apply below the watch roots")
+	}
+
+	w = New(&WatchConfig{AppRootDir: root, DefaultIgnores: new(bool)})
+	if w.Contain(filepath.Join(root, "vendor", "github.com", "x", "x.go")) {
+		t.Error("expected vendor to be observed with DefaultIgnores off")
+	}
+
+	// a config's own list leaves other watchers alone
+	w = New(&WatchConfig{AppRootDir: root, DefaultIgnoreDirs: []string{"dist"}})
+	if !w.Contain(filepath.Join(root, "dist", "app.js")) {
+		t.Error("expected dist to be ignored by DefaultIgnoreDirs")
+	}
+	if w.Contain(filepath.Join(root, "vendor", "github.com", "x", "x.go")) {
+		t.Error("expected DefaultIgnoreDirs to replace the built-in list")
+	}
+	if !New(&WatchConfig{AppRootDir: root}).Contain(filepath.Join(root, "vendor", "x.go")) {
+		t.Error("expected the built-in list unchanged for other configs")
+	}
+}
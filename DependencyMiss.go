@@ -0,0 +1,29 @@
+package devwatch
+
+import "strings"
+
+// DependencyMiss is the debug diagnostic emitted when a .go file changed but
+// no Go handler owns it, so nothing is rebuilt.
+type DependencyMiss struct {
+	Path     string   // changed file
+	Package  string   // resolved package import path eg: "myapp/internal/users"
+	Handlers []string // handlers consulted, none of them depends on Package
+}
+
+func (d DependencyMiss) String() string {
+	return "no handler owns this file: " + d.Path +
+		" package=" + d.Package +
+		" consulted=[" + strings.Join(d.Handlers, ", ") + "]"
+}
+
+// reportDependencyMiss logs a DependencyMiss when Debug is enabled
+func (h *DevWatch) reportDependencyMiss(filePath string, consulted []string) {
+	if !h.Debug {
+		return
+	}
+	h.say("dependency-miss", DependencyMiss{
+		Path:     h.relativePath(filePath),
+		Package:  h.goPackageOf(filePath),
+		Handlers: consulted,
+	}.String())
+}
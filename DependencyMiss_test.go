@@ -0,0 +1,65 @@
+package devwatch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDependencyMissDiagnostic(t *testing.T) {
+	tempDir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":           "module testapp\n\ngo 1.21\n",
+		"main.go":          "package main\n\nfunc main() {}\n",
+		"orphan/orphan.go": "package orphan\n\nfunc Unused() {}\n",
+	} {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&namedGoHandler{
+			FakeFilesEventHandler: FakeFilesEventHandler{SupportedExtensions_: []string{".go"}, MainInputFile: "main.go"},
+			name:                  "server",
+		}},
+		Logger: func(message ...any) { logs = append(logs, fmt.Sprint(message...)) },
+	})
+
+	// silent unless Debug is enabled
+	w.Trigger("orphan/orphan.go", "write")
+	for _, l := range logs {
+		if strings.Contains(l, "no handler owns") {
+			t.Fatalf("diagnostic logged without Debug: %s", l)
+		}
+	}
+
+	w.Debug = true
+	w.Trigger("orphan/orphan.go", "write")
+	want := "no handler owns this file: orphan/orphan.go package=testapp/orphan consulted=[server]"
+	found := false
+	for _, l := range logs {
+		if strings.Contains(l, want) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected diagnostic %q in logs %v", want, logs)
+	}
+}
+
+// namedGoHandler is a Go handler with a stable name
+type namedGoHandler struct {
+	FakeFilesEventHandler
+	name string
+}
+
+func (n *namedGoHandler) Name() string { return n.name }
@@ -0,0 +1,201 @@
+package devwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/build/constraint"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// detectMaxDepth is how many folder levels DetectProject looks into for
+// main files and the tailwind stylesheet
+const detectMaxDepth = 3
+
+// Project describes what DetectProject found in a project folder. Fields
+// can be adjusted before calling Handlers.
+type Project struct {
+	Root      string
+	Module    string   // module path from go.mod, empty without one
+	Mains     []string // server main files relative to Root eg: "cmd/app/main.go"
+	WasmMains []string // main files built for js/wasm eg: "web/wasm/main.go"
+	WasmOut   string   // where wasm mains are built, relative to Root (default web/public when it exists, else next to the main)
+	NPMBuild  bool     // package.json declares a "build" script
+	Tailwind  string   // stylesheet holding the tailwind directives, relative to Root
+}
+
+// DetectProject inspects root for the usual markers of a Go web project:
+// go.mod, main.go files (js/wasm ones by their build constraint),
+// package.json and tailwind.config.*
+func DetectProject(root string) Project {
+	p := Project{Root: root, Module: readModulePath(root)}
+
+	tailwind := false
+	for _, name := range tailwindConfigs {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			tailwind = true
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var pkg struct {
+			Scripts map[string]string `json:"scripts"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			_, p.NPMBuild = pkg.Scripts["build"]
+		}
+	}
+	if info, err := os.Stat(filepath.Join(root, "web", "public")); err == nil && info.IsDir() {
+		p.WasmOut = "web/public"
+	}
+
+	filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, file)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			name := d.Name()
+			if file != root && (strings.Count(rel, "/") >= detectMaxDepth || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				name == "testdata" || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case d.Name() == "main.go" && p.Module != "":
+			if isWasm, isMain := mainFileKind(file); isMain && isWasm {
+				p.WasmMains = append(p.WasmMains, rel)
+			} else if isMain {
+				p.Mains = append(p.Mains, rel)
+			}
+		case tailwind && p.Tailwind == "" && path.Ext(rel) == ".css" && !strings.HasSuffix(rel, ".out.css"):
+			if data, err := os.ReadFile(file); err == nil && (bytes.Contains(data, []byte("@tailwind")) || bytes.Contains(data, []byte(`@import "tailwindcss"`))) {
+				p.Tailwind = rel
+			}
+		}
+		return nil
+	})
+	return p
+}
+
+// mainFileKind reports whether a Go file belongs to package main and whether
+// its build constraint targets js/wasm
+func mainFileKind(file string) (isWasm, isMain bool) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case constraint.IsGoBuild(line):
+			if expr, err := constraint.Parse(line); err == nil {
+				isWasm = expr.Eval(func(tag string) bool { return tag == "js" || tag == "wasm" })
+			}
+		case strings.HasPrefix(line, "package "):
+			return isWasm, strings.TrimSpace(strings.TrimPrefix(line, "package ")) == "main"
+		}
+	}
+	return false, false
+}
+
+// detectedHandler is an ExecHandler wired by Project.Handlers
+type detectedHandler struct {
+	ExecHandler
+	main    string   // Go main input, see MainInputHandler
+	outputs []string // written by the command, see UnobservedHandler
+}
+
+func (d *detectedHandler) MainInputFileRelativePath() string { return d.main }
+func (d *detectedHandler) UnobservedFiles() []string         { return d.outputs }
+
+// Handlers returns the default pipeline for the project:
+//   - each server main is built with "go build" into .devwatch/bin
+//   - each js/wasm main is built with GOOS=js GOARCH=wasm into WasmOut
+//   - the tailwind stylesheet is rebuilt by a Tailwind handler into <name>.out.css
+//   - "npm run build" runs on script changes when package.json declares it
+//
+// See WatchConfig.AutoDetect to use it when no handler is configured.
+func (p Project) Handlers() []FilesEventHandlers {
+	var handlers []FilesEventHandlers
+	exe := ""
+	if runtime.GOOS == "windows" {
+		exe = ".exe"
+	}
+	for _, main := range p.Mains {
+		pkg := path.Dir(main)
+		name := path.Base(pkg)
+		if pkg == "." {
+			name = path.Base(p.Module)
+		}
+		handlers = append(handlers, &detectedHandler{
+			ExecHandler: ExecHandler{
+				Command:     []string{"go", "build", "-o", filepath.Join(".devwatch", "bin", name+exe), "./" + pkg},
+				Extensions:  []string{".go"},
+				Dir:         p.Root,
+				HandlerName: "go build " + pkg,
+			},
+			main: main,
+		})
+	}
+	for _, main := range p.WasmMains {
+		pkg := path.Dir(main)
+		out := path.Join(p.WasmOut, "main.wasm")
+		if p.WasmOut == "" {
+			out = path.Join(pkg, "main.wasm")
+		}
+		handlers = append(handlers, &detectedHandler{
+			ExecHandler: ExecHandler{
+				Command:     []string{"go", "build", "-o", filepath.FromSlash(out), "./" + pkg},
+				Extensions:  []string{".go"},
+				Dir:         p.Root,
+				Env:         []string{"GOOS=js", "GOARCH=wasm"},
+				HandlerName: "wasm " + pkg,
+			},
+			main:    main,
+			outputs: []string{out},
+		})
+	}
+	if p.Tailwind != "" {
+		handlers = append(handlers, &Tailwind{
+			Dir:    p.Root,
+			Input:  p.Tailwind,
+			Output: strings.TrimSuffix(p.Tailwind, ".css") + ".out.css",
+			Minify: true,
+		})
+	}
+	if p.NPMBuild {
+		handlers = append(handlers, &detectedHandler{
+			ExecHandler: ExecHandler{
+				Command:     []string{"npm", "run", "build"},
+				Extensions:  []string{".js", ".jsx", ".ts", ".tsx", ".vue", ".svelte"},
+				Dir:         p.Root,
+				HandlerName: "npm build",
+			},
+			outputs: []string{"dist", "build"},
+		})
+	}
+	return handlers
+}
+
+// autoDetect wires the default pipeline of the project when AutoDetect is
+// set and no handler is configured
+func (h *DevWatch) autoDetect() {
+	if !h.AutoDetect || len(h.FilesEventHandlers) > 0 || h.AppRootDir == "" {
+		return
+	}
+	handlers := DetectProject(h.AppRootDir).Handlers()
+	if len(handlers) == 0 {
+		h.say("detect-none", h.AppRootDir)
+		return
+	}
+	for _, handler := range handlers {
+		h.say("detect-handler", handlerName(handler))
+	}
+	h.AddFilesEventHandlers(handlers...)
+}
@@ -0,0 +1,89 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDetectProject_GoWebProject(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"go.mod":                   "module example.com/shop\n\ngo 1.24\n",
+		"cmd/server/main.go":       "package main\n\nfunc main() {}\n",
+		"web/wasm/main.go":         "//go:build js && wasm\n\npackage main\n\nfunc main() {}\n",
+		"web/public/index.html":    "<html></html>",
+		"web/css/input.css":        "@tailwind base;\n",
+		"tailwind.config.js":       "module.exports = {}",
+		"package.json":             `{"scripts": {"build": "esbuild web/app.ts"}}`,
+		"internal/users/main.go":   "package users\n",
+		"node_modules/x/main.go":   "package main\n",
+		"cmd/server/testdata/a.go": "package main\n",
+	})
+
+	p := DetectProject(root)
+	if p.Module != "example.com/shop" {
+		t.Errorf("Module = %q", p.Module)
+	}
+	if !slices.Equal(p.Mains, []string{"cmd/server/main.go"}) {
+		t.Errorf("Mains = %v", p.Mains)
+	}
+	if !slices.Equal(p.WasmMains, []string{"web/wasm/main.go"}) || p.WasmOut != "web/public" {
+		t.Errorf("WasmMains = %v, WasmOut = %q", p.WasmMains, p.WasmOut)
+	}
+	if p.Tailwind != "web/css/input.css" || !p.NPMBuild {
+		t.Errorf("Tailwind = %q, NPMBuild = %v", p.Tailwind, p.NPMBuild)
+	}
+
+	w := New(&WatchConfig{AppRootDir: root, AutoDetect: true, Logger: func(message ...any) {}})
+	w.autoDetect()
+	var names []string
+	for _, handler := range w.FilesEventHandlers {
+		names = append(names, handlerName(handler))
+	}
+	if want := []string{"go build cmd/server", "wasm web/wasm", "tailwind", "npm build"}; !slices.Equal(names, want) {
+		t.Errorf("handlers = %v, want %v", names, want)
+	}
+	if errs := w.Validate(); len(errs) != 0 {
+		t.Errorf("default pipeline must validate, got %v", errs)
+	}
+	for _, path := range []string{"web/public/main.wasm", "dist/app.js"} {
+		if !w.Contain(filepath.Join(root, path)) {
+			t.Errorf("build output %s must be unobserved", path)
+		}
+	}
+	if !w.isGenerated(filepath.Join(root, "web", "css", "input.out.css")) {
+		t.Error("tailwind output must be generated")
+	}
+}
+
+func TestDetectProject_KeepsConfiguredHandlers(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"go.mod":  "module example.com/app\n",
+		"main.go": "package main\n\nfunc main() {}\n",
+	})
+	handler := &recordingHandler{}
+	w := New(&WatchConfig{AppRootDir: root, AutoDetect: true, FilesEventHandlers: []FilesEventHandlers{handler}, Logger: func(message ...any) {}})
+	w.autoDetect()
+	if len(w.FilesEventHandlers) != 1 {
+		t.Errorf("configured handlers must be kept alone, got %d", len(w.FilesEventHandlers))
+	}
+	if got := DetectProject(root).Handlers(); len(got) != 1 || handlerName(got[0]) != "go build ." {
+		t.Errorf("root main must be built, got %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package devwatch
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is a compiler or linter message located in a file
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Col      int    `json:"col,omitempty"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Message  string `json:"message"`
+	Rule     string `json:"rule,omitempty"` // eg: "no-unused-vars", "TS2322"
+}
+
+var (
+	// go build / go vet: "./api/api.go:12:5: undefined: foo", go test: "    api_test.go:8: got 2"
+	goDiagnostic = regexp.MustCompile(`^\s*(?:vet: )?(\S+\.go):(\d+)(?::(\d+))?: (.+)$`)
+	// tsc: "src/app.ts(3,7): error TS2322: ..." or pretty "src/app.ts:3:7 - error TS2322: ..."
+	tscDiagnostic = regexp.MustCompile(`^(\S+\.[cm]?tsx?)(?:\((\d+),(\d+)\): |:(\d+):(\d+) - )(error|warning) (TS\d+): (.+)$`)
+	// eslint stylish: a file line followed by "  3:7  error  'x' is defined but never used  no-unused-vars"
+	eslintFile    = regexp.MustCompile(`^(/|\.|\w:\\|\w)\S*\.(?:[cm]?[jt]sx?|vue)$`)
+	eslintMessage = regexp.MustCompile(`^\s+(\d+):(\d+)\s+(error|warning)\s+(.+?)(?:\s{2,}(\S+))?$`)
+	// eslint unix/compact: "src/app.js: line 3, col 7, Error - 'x' is defined but never used. (no-unused-vars)"
+	eslintCompact = regexp.MustCompile(`^(\S+): line (\d+), col (\d+), (Error|Warning) - (.+?)(?: \((\S+)\))?$`)
+)
+
+// ParseDiagnostics extracts the diagnostics from the output of go build,
+// go vet, eslint (stylish and compact formats) and tsc. Lines in other
+// formats are ignored.
+func ParseDiagnostics(output string) []Diagnostic {
+	var diags []Diagnostic
+	eslintCurrent := "" // file of the eslint stylish block being read
+	for line := range strings.Lines(output) {
+		line = strings.TrimRight(line, "\r\n")
+		if m := goDiagnostic.FindStringSubmatch(line); m != nil {
+			diags = append(diags, Diagnostic{File: m[1], Line: atoi(m[2]), Col: atoi(m[3]), Severity: "error", Message: m[4]})
+		} else if m := tscDiagnostic.FindStringSubmatch(line); m != nil {
+			l, c := m[2], m[3]
+			if l == "" {
+				l, c = m[4], m[5]
+			}
+			diags = append(diags, Diagnostic{File: m[1], Line: atoi(l), Col: atoi(c), Severity: m[6], Message: m[8], Rule: m[7]})
+		} else if m := eslintCompact.FindStringSubmatch(line); m != nil {
+			diags = append(diags, Diagnostic{File: m[1], Line: atoi(m[2]), Col: atoi(m[3]), Severity: strings.ToLower(m[4]), Message: m[5], Rule: m[6]})
+		} else if eslintFile.MatchString(line) {
+			eslintCurrent = line
+		} else if m := eslintMessage.FindStringSubmatch(line); m != nil && eslintCurrent != "" {
+			diags = append(diags, Diagnostic{File: eslintCurrent, Line: atoi(m[1]), Col: atoi(m[2]), Severity: m[3], Message: m[4], Rule: m[5]})
+		} else if strings.TrimSpace(line) == "" {
+			eslintCurrent = ""
+		}
+	}
+	return diags
+}
+
+// DiagnosticsOf returns the diagnostics carried by err (eg: an ExecHandler failure or a TemplateError)
+func DiagnosticsOf(err error) []Diagnostic {
+	var execErr *ExecError
+	if errors.As(err, &execErr) {
+		return execErr.Diagnostics
+	}
+	var tmplErr *TemplateError
+	if errors.As(err, &tmplErr) {
+		return []Diagnostic{tmplErr.Diagnostic}
+	}
+	return nil
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,62 @@
+package devwatch
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []Diagnostic
+	}{
+		{"go build", "# example/api\n./api/api.go:12:5: undefined: foo\n",
+			[]Diagnostic{{File: "./api/api.go", Line: 12, Col: 5, Severity: "error", Message: "undefined: foo"}}},
+		{"go vet", "# example\nvet: ./main.go:7:2: fmt.Printf format %d has arg s of wrong type string\n",
+			[]Diagnostic{{File: "./main.go", Line: 7, Col: 2, Severity: "error", Message: "fmt.Printf format %d has arg s of wrong type string"}}},
+		{"tsc", "src/app.ts(3,7): error TS2322: Type 'string' is not assignable to type 'number'.\n",
+			[]Diagnostic{{File: "src/app.ts", Line: 3, Col: 7, Severity: "error", Message: "Type 'string' is not assignable to type 'number'.", Rule: "TS2322"}}},
+		{"tsc pretty", "src/app.tsx:4:1 - warning TS6133: 'x' is declared but its value is never read.\n",
+			[]Diagnostic{{File: "src/app.tsx", Line: 4, Col: 1, Severity: "warning", Message: "'x' is declared but its value is never read.", Rule: "TS6133"}}},
+		{"eslint stylish", "\n/app/src/main.js\n  3:7   error    'x' is assigned a value but never used  no-unused-vars\n  9:1   warning  Unexpected console statement            no-console\n\n✖ 2 problems (1 error, 1 warning)\n",
+			[]Diagnostic{
+				{File: "/app/src/main.js", Line: 3, Col: 7, Severity: "error", Message: "'x' is assigned a value but never used", Rule: "no-unused-vars"},
+				{File: "/app/src/main.js", Line: 9, Col: 1, Severity: "warning", Message: "Unexpected console statement", Rule: "no-console"},
+			}},
+		{"eslint compact", "src/main.js: line 3, col 7, Error - 'x' is defined but never used. (no-unused-vars)\n",
+			[]Diagnostic{{File: "src/main.js", Line: 3, Col: 7, Severity: "error", Message: "'x' is defined but never used.", Rule: "no-unused-vars"}}},
+		{"unknown output", "ok  \texample/api\t0.01s\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseDiagnostics(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecHandler_Diagnostics(t *testing.T) {
+	root := t.TempDir()
+	handler := &ExecHandler{Command: []string{"sh", "-c", "echo './a.go:1:2: syntax error' >&2; exit 1"}, Dir: root}
+	err := handler.NewFileEvent("a.go", ".go", filepath.Join(root, "a.go"), "write")
+
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected ExecError, got %v", err)
+	}
+	diags := DiagnosticsOf(err)
+	if len(diags) != 1 || diags[0].File != "./a.go" || diags[0].Line != 1 || diags[0].Message != "syntax error" {
+		t.Errorf("unexpected diagnostics %+v", diags)
+	}
+
+	var rec EventRecord
+	rec.addResult(handler, time.Now(), err)
+	if got := rec.Handlers[0].Diagnostics; !reflect.DeepEqual(got, diags) {
+		t.Errorf("handler result diagnostics %+v, want %+v", got, diags)
+	}
+}
@@ -0,0 +1,43 @@
+package devwatch
+
+import "errors"
+
+// defaultEnvFiles are the environment files EnvHandler watches by default
+var defaultEnvFiles = []string{".env", ".env.local"}
+
+// EnvHandler observes environment files despite the hidden-file rule.
+// Environment variables can't be hot-applied, so a change runs Restart
+// (eg: restart the backend process) and, unless Reload is set, skips the
+// browser reload: the restarted backend decides when the page is ready.
+//
+//	watcher.AddFilesEventHandlers(&devwatch.EnvHandler{Restart: func(string) error { return server.Restart() }})
+type EnvHandler struct {
+	Files   []string                    // default: .env, .env.local
+	Restart func(filePath string) error // required
+	Reload  bool                        // also reload the browser after Restart
+}
+
+func (e *EnvHandler) SupportedExtensions() []string { return nil }
+
+// SupportedFilenames implements FilenameHandler
+func (e *EnvHandler) SupportedFilenames() []string {
+	if len(e.Files) == 0 {
+		return defaultEnvFiles
+	}
+	return e.Files
+}
+
+// Background implements BackgroundHandler: the browser reload is skipped
+// unless Reload is set
+func (e *EnvHandler) Background() bool { return !e.Reload }
+
+// Name implements NamedHandler
+func (e *EnvHandler) Name() string { return "env" }
+
+// NewFileEvent restarts for any change, including removals
+func (e *EnvHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	if e.Restart == nil {
+		return errors.New("EnvHandler: Restart is nil")
+	}
+	return e.Restart(filePath)
+}
@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnvHandler(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{".env", ".env.local", ".secret"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("PORT=8080\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var restarted []string
+	env := &EnvHandler{Restart: func(filePath string) error {
+		restarted = append(restarted, filepath.Base(filePath))
+		return nil
+	}}
+	var reloads atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{env},
+		BrowserReload:      func() error { reloads.Add(1); return nil },
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	for _, name := range []string{".env", ".env.local"} {
+		if err := w.Trigger(name, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(restarted) != 2 {
+		t.Errorf("expected a restart per env file, got %v", restarted)
+	}
+	if !w.Contain(filepath.Join(root, ".secret")) {
+		t.Error("other hidden files stay unobserved")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if reloads.Load() != 0 {
+		t.Errorf("env changes must skip the browser reload, got %d", reloads.Load())
+	}
+
+	env.Reload = true
+	if err := w.Trigger(".env", "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if reloads.Load() != 1 {
+		t.Errorf("expected a reload with Reload set, got %d", reloads.Load())
+	}
+}
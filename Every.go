@@ -0,0 +1,120 @@
+package devwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// stormQuietPeriod is how long after the last file event scheduled tasks wait
+// before running, so they don't compete with builds during event storms.
+const stormQuietPeriod = 500 * time.Millisecond
+
+// scheduledTask is a periodic action registered with Every
+type scheduledTask struct {
+	name     string
+	interval time.Duration
+	fn       func() error
+}
+
+// scheduler runs periodic tasks for as long as the watch loop is running
+type scheduler struct {
+	mu    sync.Mutex
+	tasks []scheduledTask
+	stop  chan struct{} // nil when the watch loop is not running
+	wg    sync.WaitGroup
+}
+
+// Every registers a periodic task (eg: run lint every 10 minutes, prune caches)
+// that runs while the watcher is active. Ticks are postponed while handlers
+// are running or events are still arriving, so tasks never compete with
+// compilation. Tasks registered before FileWatcherStart begin with the watch loop.
+func (h *DevWatch) Every(name string, interval time.Duration, task func() error) {
+	if interval <= 0 || task == nil {
+		h.say("every-invalid", name)
+		return
+	}
+	t := scheduledTask{name: name, interval: interval, fn: task}
+
+	h.sched.mu.Lock()
+	defer h.sched.mu.Unlock()
+	h.sched.tasks = append(h.sched.tasks, t)
+	if h.sched.stop != nil {
+		h.runTask(t, h.sched.stop)
+	}
+}
+
+// startScheduler launches all registered tasks; called when the watch loop starts
+func (h *DevWatch) startScheduler() {
+	h.sched.mu.Lock()
+	defer h.sched.mu.Unlock()
+	if h.sched.stop != nil {
+		return
+	}
+	h.sched.stop = make(chan struct{})
+	for _, t := range h.sched.tasks {
+		h.runTask(t, h.sched.stop)
+	}
+}
+
+// stopScheduler stops all task goroutines and waits for running tasks to return
+func (h *DevWatch) stopScheduler() {
+	h.sched.mu.Lock()
+	if h.sched.stop == nil {
+		h.sched.mu.Unlock()
+		return
+	}
+	close(h.sched.stop)
+	h.sched.stop = nil
+	h.sched.mu.Unlock()
+	h.sched.wg.Wait()
+}
+
+// runTask starts the goroutine for a single task. Callers must hold sched.mu.
+func (h *DevWatch) runTask(t scheduledTask, stop chan struct{}) {
+	h.sched.wg.Add(1)
+	go func() {
+		defer h.sched.wg.Done()
+		ticker := time.NewTicker(t.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			// paused while compiling or during event storms
+			for h.busy() {
+				select {
+				case <-stop:
+					return
+				case <-time.After(stormQuietPeriod / 5):
+				}
+			}
+
+			if err := t.fn(); err != nil {
+				h.say("task-error", t.name, err)
+			}
+		}
+	}()
+}
+
+// busy reports whether handlers are running or file events arrived recently
+func (h *DevWatch) busy() bool {
+	if h.dispatching.Load() > 0 {
+		return true
+	}
+	last := h.lastEventAt.Load()
+	return last != 0 && time.Since(time.Unix(0, last)) < stormQuietPeriod
+}
+
+// markDispatch tracks a running handler dispatch; call dispatchDone when done
+func (h *DevWatch) markDispatch() {
+	h.dispatching.Add(1)
+	h.lastEventAt.Store(time.Now().UnixNano())
+}
+
+// dispatchDone ends a dispatch started with markDispatch
+func (h *DevWatch) dispatchDone() {
+	h.dispatching.Add(-1)
+}
@@ -0,0 +1,58 @@
+package devwatch
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestEvery(t *testing.T) {
+	w := New(&WatchConfig{
+		AppRootDir: t.TempDir(),
+		Logger:     func(message ...any) { t.Log(message...) },
+		ExitChan:   make(chan bool, 1),
+	})
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.watcher = watcher
+
+	var runs int32
+	w.Every("count", 20*time.Millisecond, func() error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		w.watchEvents()
+		close(done)
+	}()
+
+	time.Sleep(120 * time.Millisecond)
+	if got := atomic.LoadInt32(&runs); got < 2 {
+		t.Fatalf("expected task to run periodically, got %d runs", got)
+	}
+
+	// paused while a dispatch is in progress
+	w.markDispatch()
+	paused := atomic.LoadInt32(&runs)
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&runs); got > paused+1 {
+		t.Errorf("task kept running during dispatch: %d -> %d", paused, got)
+	}
+	w.dispatchDone()
+
+	w.ExitChan <- true
+	<-done
+
+	// stopped with the watch loop
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(60 * time.Millisecond)
+	if got := atomic.LoadInt32(&runs); got != stopped {
+		t.Errorf("task kept running after exit: %d -> %d", stopped, got)
+	}
+}
@@ -0,0 +1,109 @@
+package devwatch
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ExecHandler runs a command for each file event, eg: a build script or
+// "make css". Event metadata is passed as environment variables so scripts
+// can act per event without argument templating:
+//
+//	DEVWATCH_PATH  absolute path of the file
+//	DEVWATCH_EVENT create, remove, write or rename
+//	DEVWATCH_EXT   extension eg: ".css"
+//	DEVWATCH_PKG   for .go files, the package directory relative to Dir eg: "internal/api"
+type ExecHandler struct {
+	Command     []string  // eg: []string{"sh", "scripts/build.sh"}
+	Extensions  []string  // eg: []string{".css", ".js"}
+	Dir         string    // working directory, usually AppRootDir
+	Env         []string  // extra environment eg: []string{"GOOS=js", "GOARCH=wasm"}
+	Output      io.Writer // also receives the command stdout and stderr (always reported in errors)
+	HandlerName string    // default: the command name
+}
+
+func (e *ExecHandler) SupportedExtensions() []string { return e.Extensions }
+
+// Name implements NamedHandler
+func (e *ExecHandler) Name() string {
+	if e.HandlerName != "" || len(e.Command) == 0 {
+		return e.HandlerName
+	}
+	return filepath.Base(e.Command[0])
+}
+
+// NewFileEvent runs Command with the event environment
+func (e *ExecHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	if len(e.Command) == 0 {
+		return errors.New("ExecHandler: empty Command")
+	}
+	cmd := exec.Command(e.Command[0], e.Command[1:]...)
+	cmd.Dir = e.Dir
+	cmd.Env = append(append(os.Environ(), e.Env...), e.eventEnv(extension, filePath, event)...)
+	_, err := runCommand(cmd, e.Name(), e.Output)
+	return err
+}
+
+// runCommand runs cmd capturing its combined output (also copied to w when
+// set). A failure is returned as an ExecError.
+func runCommand(cmd *exec.Cmd, handler string, w io.Writer) (string, error) {
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	if w != nil {
+		cmd.Stdout = io.MultiWriter(w, &out)
+		cmd.Stderr = cmd.Stdout
+	}
+	err := cmd.Run()
+	output := strings.TrimSpace(out.String())
+	if err != nil {
+		return output, &ExecError{Handler: handler, Err: err, Output: output, Diagnostics: ParseDiagnostics(output)}
+	}
+	return output, nil
+}
+
+// ExecError is returned by ExecHandler and TestRunner when the command
+// fails. Diagnostics holds the compiler and linter messages parsed from its
+// output.
+type ExecError struct {
+	Handler     string
+	Err         error
+	Output      string // stdout and stderr
+	Diagnostics []Diagnostic
+}
+
+func (e *ExecError) Error() string {
+	msg := e.Handler + ": " + e.Err.Error()
+	if e.Output != "" {
+		msg += ": " + e.Output
+	}
+	return msg
+}
+
+func (e *ExecError) Unwrap() error { return e.Err }
+
+// eventEnv returns the DEVWATCH_* variables of an event
+func (e *ExecHandler) eventEnv(extension, filePath, event string) []string {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		abs = filePath
+	}
+	pkg := ""
+	if extension == ".go" {
+		pkg = filepath.Dir(filePath)
+		if rel, err := filepath.Rel(e.Dir, pkg); err == nil && e.Dir != "" {
+			pkg = rel
+		}
+		pkg = filepath.ToSlash(pkg)
+	}
+	return []string{
+		"DEVWATCH_PATH=" + abs,
+		"DEVWATCH_EVENT=" + event,
+		"DEVWATCH_EXT=" + extension,
+		"DEVWATCH_PKG=" + pkg,
+	}
+}
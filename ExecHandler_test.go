@@ -0,0 +1,39 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecHandler(t *testing.T) {
+	root := t.TempDir()
+	goFile := filepath.Join(root, "internal", "api", "api.go")
+	if err := os.MkdirAll(filepath.Dir(goFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	handler := &ExecHandler{
+		Command:    []string{"sh", "-c", `echo "$DEVWATCH_EVENT $DEVWATCH_EXT $DEVWATCH_PKG $DEVWATCH_PATH" > out.txt`},
+		Extensions: []string{".go"},
+		Dir:        root,
+	}
+	if err := handler.NewFileEvent("api.go", ".go", goFile, "create"); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(filepath.Join(root, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(out)), "create .go internal/api "+goFile; got != want {
+		t.Errorf("got env %q, want %q", got, want)
+	}
+	if handler.Name() != "sh" {
+		t.Errorf("expected command name, got %q", handler.Name())
+	}
+
+	failing := &ExecHandler{Command: []string{"sh", "-c", "echo broken >&2; exit 3"}, Dir: root}
+	if err := failing.NewFileEvent("a.css", ".css", filepath.Join(root, "a.css"), "write"); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected error with command output, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+)
+
+// Extensions returns every extension key of path, longest first, so compound
+// suffixes can be matched and ignored separately from the last segment.
+// Example: "web/index.tmpl.html" -> [".tmpl.html", ".html"]
+// Example: "api/user.pb.go" -> [".pb.go", ".go"]
+func Extensions(path string) []string {
+	var exts []string
+	eachExtension(path, func(ext string) bool {
+		exts = append(exts, ext)
+		return true
+	})
+	return exts
+}
+
+// eachExtension calls fn with every extension key of path, longest first,
+// without allocating; it stops when fn returns false
+func eachExtension(path string, fn func(ext string) bool) {
+	base := filepath.Base(slashPath(path))
+	found := false
+	// skip index 0 so hidden files (".env.local") don't yield the whole name
+	for i := 1; i < len(base); i++ {
+		if base[i] == '.' && i < len(base)-1 {
+			found = true
+			if !fn(base[i:]) {
+				return
+			}
+		}
+	}
+	if !found {
+		if ext := filepath.Ext(base); ext != "" {
+			fn(ext)
+		}
+	}
+}
+
+// matchExtension returns the longest extension key of path listed in supported
+func matchExtension(path string, supported []string) (match string, ok bool) {
+	if len(supported) == 0 {
+		return "", false
+	}
+	eachExtension(path, func(ext string) bool {
+		match, ok = ext, slices.Contains(supported, ext)
+		return !ok
+	})
+	if !ok {
+		return "", false
+	}
+	return match, true
+}
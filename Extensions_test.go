@@ -0,0 +1,73 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtensions(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"web/index.tmpl.html", []string{".tmpl.html", ".html"}},
+		{"api/user.pb.go", []string{".pb.go", ".go"}},
+		{"main.go", []string{".go"}},
+		{"Makefile", nil},
+		{".env", []string{".env"}},
+		{".env.local", []string{".local"}},
+		{"C:\\app\\model.gen.go", []string{".gen.go", ".go"}},
+	}
+	for _, tt := range tests {
+		if got := Extensions(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Extensions(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// templateHandler only wants compound template files
+type templateHandler struct {
+	extensions []string
+}
+
+func (th *templateHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	th.extensions = append(th.extensions, extension)
+	return nil
+}
+
+func (th *templateHandler) SupportedExtensions() []string     { return []string{".tmpl.html"} }
+func (th *templateHandler) MainInputFileRelativePath() string { return "" }
+func (th *templateHandler) UnobservedFiles() []string         { return nil }
+
+func TestCompoundExtensionMatching(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"page.tmpl.html", "index.html", "model.gen.go"} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates := &templateHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{templates},
+		UnobservedFiles:    func() []string { return []string{".gen.go"} },
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	w.Trigger("page.tmpl.html", "write")
+	w.Trigger("index.html", "write")
+
+	if want := []string{".tmpl.html"}; !reflect.DeepEqual(templates.extensions, want) {
+		t.Errorf("extensions received = %v, want %v", templates.extensions, want)
+	}
+
+	if !w.Contain(filepath.Join(tempDir, "model.gen.go")) {
+		t.Error("expected compound ignore key .gen.go to hide model.gen.go")
+	}
+	if w.Contain(filepath.Join(tempDir, "model.go")) {
+		t.Error("expected model.go to stay observed")
+	}
+}
@@ -0,0 +1,159 @@
+package devwatch
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// FileChange describes a single file event. Handlers implementing
+// FileChangeHandler receive it instead of the NewFileEvent arguments.
+type FileChange struct {
+	FileName  string // eg: "main.go"
+	Extension string // eg: ".go"
+	FilePath  string // eg: "/home/user/app/main.go"
+	Event     string // create, remove, write, rename
+	Source    string // WatchConfig.RootSources name of the file eg: "api", "" when unnamed
+
+	// Diff is a unified diff (single hunk) of the change against the previous
+	// content. Only set when WatchConfig.DiffMaxBytes > 0, the file is not
+	// bigger than that, its previous content is known and it is not a
+	// sensitive file (see IsSensitiveFile and WatchConfig.SensitivePatterns).
+	Diff string
+	// ChangedLines are the 1-based line ranges of the new content touched by the change
+	ChangedLines []LineRange
+}
+
+// LineRange is an inclusive 1-based range of lines. End < Start means the
+// change only removed lines before Start.
+type LineRange struct {
+	Start, End int
+}
+
+// FileChangeHandler is an optional capability for handlers that want the full
+// FileChange payload (eg: diff) instead of the plain NewFileEvent arguments.
+type FileChangeHandler interface {
+	NewFileChange(change FileChange) error
+}
+
+// ContextFileChangeHandler is an optional capability for handlers that want
+// a context besides the FileChange payload: it carries the handler span
+// (see Tracer) and the handler scoped Cache (see CacheFrom).
+type ContextFileChangeHandler interface {
+	NewFileChangeContext(ctx context.Context, change FileChange) error
+}
+
+// callHandler delivers change to handler using the richest interface it
+// implements, inside a "devwatch.handler" span child of ctx
+func (h *DevWatch) callHandler(ctx context.Context, handler FilesEventHandlers, change FileChange) (err error) {
+	name := handlerName(handler)
+	ctx, span := h.startSpan(ctx, "devwatch.handler", "handler", name, "path", change.FilePath)
+	defer func() { span.End(err) }()
+
+	return h.profileHandler(ctx, name, change.FilePath, func() error {
+		if ch, ok := handler.(ContextFileChangeHandler); ok {
+			return ch.NewFileChangeContext(context.WithValue(ctx, cacheKey{}, h.Cache().Scope(name)), change)
+		}
+		if fh, ok := handler.(FileChangeHandler); ok {
+			return fh.NewFileChange(change)
+		}
+		return handler.NewFileEvent(change.FileName, change.Extension, change.FilePath, change.Event)
+	})
+}
+
+// snapshotContent stores the current content of filePath for later diffs.
+// Files bigger than DiffMaxBytes are forgotten.
+func (h *DevWatch) snapshotContent(filePath string) {
+	if h.DiffMaxBytes <= 0 || h.isSensitive(filePath) {
+		return
+	}
+	h.contentMu.Lock()
+	defer h.contentMu.Unlock()
+	if h.contents == nil {
+		h.contents = make(map[string]string)
+	}
+
+	info, err := statRetry(filePath)
+	if err != nil || info.IsDir() || info.Size() > int64(h.DiffMaxBytes) {
+		delete(h.contents, filePath)
+		return
+	}
+	data, err := readFileRetry(filePath)
+	if err != nil {
+		delete(h.contents, filePath)
+		return
+	}
+	h.contents[filePath] = string(data)
+}
+
+// addDiff fills change.Diff and change.ChangedLines comparing the stored
+// snapshot with the current content, then updates the snapshot.
+func (h *DevWatch) addDiff(change *FileChange) {
+	if h.DiffMaxBytes <= 0 || h.isSensitive(change.FilePath) {
+		return
+	}
+	h.contentMu.Lock()
+	previous, known := h.contents[change.FilePath]
+	h.contentMu.Unlock()
+
+	if change.Event == "remove" {
+		h.contentMu.Lock()
+		delete(h.contents, change.FilePath)
+		h.contentMu.Unlock()
+		return
+	}
+
+	h.snapshotContent(change.FilePath)
+	if !known {
+		return
+	}
+	h.contentMu.Lock()
+	current, ok := h.contents[change.FilePath]
+	h.contentMu.Unlock()
+	if !ok || current == previous {
+		return
+	}
+
+	change.Diff, change.ChangedLines = unifiedDiff(change.FileName, previous, current)
+}
+
+// unifiedDiff returns a single-hunk unified diff between oldText and newText
+// covering the region between their common prefix and suffix lines.
+func unifiedDiff(name, oldText, newText string) (string, []LineRange) {
+	oldLines := strings.SplitAfter(oldText, "\n")
+	newLines := strings.SplitAfter(newText, "\n")
+
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	removed := oldLines[prefix : len(oldLines)-suffix]
+	added := newLines[prefix : len(newLines)-suffix]
+
+	var b strings.Builder
+	b.WriteString("--- a/" + name + "\n")
+	b.WriteString("+++ b/" + name + "\n")
+	b.WriteString("@@ -" + hunkRange(prefix+1, len(removed)) + " +" + hunkRange(prefix+1, len(added)) + " @@\n")
+	for _, line := range removed {
+		b.WriteString("-" + strings.TrimSuffix(line, "\n") + "\n")
+	}
+	for _, line := range added {
+		b.WriteString("+" + strings.TrimSuffix(line, "\n") + "\n")
+	}
+
+	return b.String(), []LineRange{{Start: prefix + 1, End: prefix + len(added)}}
+}
+
+// hunkRange formats a unified diff range ("start,count")
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	return strconv.Itoa(start) + "," + strconv.Itoa(count)
+}
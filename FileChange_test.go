@@ -0,0 +1,74 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// changeRecorder captures FileChange payloads
+type changeRecorder struct {
+	changes []FileChange
+}
+
+func (c *changeRecorder) NewFileEvent(fileName, extension, filePath, event string) error {
+	panic("NewFileEvent must not be called when NewFileChange is implemented")
+}
+
+func (c *changeRecorder) NewFileChange(change FileChange) error {
+	c.changes = append(c.changes, change)
+	return nil
+}
+
+func (c *changeRecorder) SupportedExtensions() []string     { return []string{".html"} }
+func (c *changeRecorder) MainInputFileRelativePath() string { return "" }
+func (c *changeRecorder) UnobservedFiles() []string         { return nil }
+
+func TestFileChangeDiff(t *testing.T) {
+	tempDir := t.TempDir()
+	htmlFile := filepath.Join(tempDir, "index.html")
+	if err := os.WriteFile(htmlFile, []byte("<html>\n<body>\nold\n</body>\n</html>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &changeRecorder{}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{recorder},
+		DiffMaxBytes:       1024,
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+	w.snapshotContent(htmlFile)
+
+	if err := os.WriteFile(htmlFile, []byte("<html>\n<body>\nnew\nline\n</body>\n</html>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(htmlFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recorder.changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(recorder.changes))
+	}
+	change := recorder.changes[0]
+	wantDiff := "--- a/index.html\n+++ b/index.html\n@@ -3,1 +3,2 @@\n-old\n+new\n+line\n"
+	if change.Diff != wantDiff {
+		t.Errorf("Diff =\n%s\nwant\n%s", change.Diff, wantDiff)
+	}
+	if want := []LineRange{{Start: 3, End: 4}}; !reflect.DeepEqual(change.ChangedLines, want) {
+		t.Errorf("ChangedLines = %v, want %v", change.ChangedLines, want)
+	}
+
+	// files above the size limit carry no diff
+	w.DiffMaxBytes = 10
+	if err := os.WriteFile(htmlFile, []byte("<html>\n<body>\nbigger content\n</body>\n</html>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(htmlFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if got := recorder.changes[1].Diff; got != "" {
+		t.Errorf("expected no diff above DiffMaxBytes, got %q", got)
+	}
+}
@@ -7,24 +7,57 @@ import (
 )
 
 func (h *DevWatch) FileWatcherStart(wg *sync.WaitGroup) {
+	defer wg.Done()
 
-	if h.watcher == nil {
+	if h.SingleInstance {
+		if err := h.LockInstance(); err != nil {
+			h.say("instance-locked", err)
+			if h.OnError != nil {
+				h.OnError(err)
+			}
+			return
+		}
+		// also released when the watcher can't be created
+		defer h.unlockInstance()
+	}
+
+	if h.currentWatcher() == nil {
 		if watcher, err := fsnotify.NewWatcher(); err != nil {
-			h.Logger("Error New Watcher: ", err)
+			h.say("new-watcher-error", err)
 			return
 		} else {
+			h.watcherMu.Lock()
 			h.watcher = watcher
+			h.watcherMu.Unlock()
 		}
 	}
 
+	h.autoDetect()
+	for _, err := range h.Validate() {
+		h.say("config-error", err)
+	}
+
+	h.startServer()
+
+	h.startAudit()
 	// Start watching in the main routine
 	go h.watchEvents()
 	h.InitialRegistration()
+	h.openBrowserOnStart()
+	if h.WarmOwnership {
+		go h.warmOwnership()
+	}
 
-	h.Logger("Listening for File Changes ...")
+	h.say("listening")
 	// Wait for exit signal after watching is active
 
-	<-h.ExitChan
-	h.watcher.Close()
-	wg.Done()
+	select {
+	case <-h.ExitChan:
+	case <-h.stopChan(): // graceful Shutdown
+	}
+	h.currentWatcher().Close()
+	if h.Serve != nil {
+		h.Serve.close()
+	}
+	h.closeJournal()
 }
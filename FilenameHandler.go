@@ -0,0 +1,85 @@
+package devwatch
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// FilenameHandler is an optional capability for handlers that subscribe to
+// files by exact name, eg: ["Dockerfile", "Makefile", ".env", "Caddyfile"].
+// Listed hidden files (".env") are observed despite the hidden-file rule.
+type FilenameHandler interface {
+	SupportedFilenames() []string
+}
+
+// FileMatcher is an optional capability for handlers that decide by
+// themselves, eg: sniffing the content of extension-less scripts.
+type FileMatcher interface {
+	MatchFile(filePath string) bool
+}
+
+// handlerSupports reports whether handler subscribes to filePath by
+// extension (including compound ones like ".tmpl.html"), file name, matcher
+// or build dependency pattern.
+// It returns the extension key to report to the handler.
+func handlerSupports(handler FilesEventHandlers, filePath, extension string) (string, bool) {
+	if ext, ok := matchExtension(filePath, handler.SupportedExtensions()); ok {
+		return ext, true
+	}
+	if fh, ok := handler.(FilenameHandler); ok {
+		if slices.Contains(fh.SupportedFilenames(), filepath.Base(filePath)) {
+			return extension, true
+		}
+	}
+	if fm, ok := handler.(FileMatcher); ok && fm.MatchFile(filePath) {
+		return extension, true
+	}
+	if _, ok := matchBuildDependency(handler, filePath); ok {
+		return extension, true
+	}
+	return "", false
+}
+
+// handlerClaims reports whether handler subscribes to filePath explicitly,
+// by file name or matcher rather than by extension
+func handlerClaims(handler FilesEventHandlers, filePath string) bool {
+	if fh, ok := handler.(FilenameHandler); ok && slices.Contains(fh.SupportedFilenames(), filepath.Base(filePath)) {
+		return true
+	}
+	fm, ok := handler.(FileMatcher)
+	return ok && fm.MatchFile(filePath)
+}
+
+// filenameClaimed reports whether some handler subscribes to baseName explicitly
+func (h *DevWatch) filenameClaimed(baseName string) bool {
+	for _, handler := range h.FilesEventHandlers {
+		if fh, ok := handler.(FilenameHandler); ok && slices.Contains(fh.SupportedFilenames(), baseName) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasShebang reports whether filePath starts with a "#!" line mentioning
+// interpreter (eg: "bash", "python"). Useful in FileMatcher implementations
+// for extension-less scripts.
+func HasShebang(filePath, interpreter string) bool {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, 128)
+	n, _ := f.Read(head)
+	head = head[:n]
+	if !bytes.HasPrefix(head, []byte("#!")) {
+		return false
+	}
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		head = head[:i]
+	}
+	return bytes.Contains(head, []byte(interpreter))
+}
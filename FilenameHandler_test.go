@@ -0,0 +1,65 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildFilesHandler subscribes to extension-less build files and shell scripts
+type buildFilesHandler struct {
+	events []string
+}
+
+func (b *buildFilesHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	b.events = append(b.events, fileName)
+	return nil
+}
+
+func (b *buildFilesHandler) SupportedFilenames() []string      { return []string{"Dockerfile", ".env"} }
+func (b *buildFilesHandler) MatchFile(filePath string) bool    { return HasShebang(filePath, "bash") }
+func (b *buildFilesHandler) SupportedExtensions() []string     { return nil }
+func (b *buildFilesHandler) MainInputFileRelativePath() string { return "" }
+func (b *buildFilesHandler) UnobservedFiles() []string         { return nil }
+
+func TestFilenameHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"Dockerfile": "FROM golang",
+		".env":       "PORT=8080",
+		"deploy":     "#!/usr/bin/env bash\necho deploy",
+		"notes":      "plain text",
+		".secret":    "hidden",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := &buildFilesHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	for _, name := range []string{"Dockerfile", ".env", "deploy", "notes"} {
+		if err := w.Trigger(name, "write"); err != nil {
+			t.Fatalf("Trigger(%s): %v", name, err)
+		}
+	}
+	if err := w.Trigger(".secret", "write"); err == nil {
+		t.Error("expected unclaimed hidden file to stay unobserved")
+	}
+
+	want := []string{"Dockerfile", ".env", "deploy"}
+	if len(handler.events) != len(want) {
+		t.Fatalf("events = %v, want %v", handler.events, want)
+	}
+	for i := range want {
+		if handler.events[i] != want[i] {
+			t.Errorf("events = %v, want %v", handler.events, want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package devwatch
+
+// SetFocusMode turns focus mode on or off. While on, handlers keep building
+// on every change but the browser is not reloaded and OnNotify is not
+// called (eg: while editing in devtools); skipped reloads are published as
+// "reload" records skipped with "focus". Turning it off sends the latest
+// notification of each handler and reloads once if anything changed
+// meanwhile. ForceReload still reloads in focus mode. LiveReload exposes it
+// on its "focus" endpoint.
+func (h *DevWatch) SetFocusMode(on bool) {
+	if h.focus.Swap(on) == on {
+		return
+	}
+	if on {
+		h.say("focus-on")
+		return
+	}
+	h.say("focus-off")
+	h.flushNotifications()
+	if h.focusMissed.Swap(false) {
+		h.scheduleReload()
+	}
+}
+
+// FocusMode reports whether browser reloads are paused by SetFocusMode
+func (h *DevWatch) FocusMode() bool {
+	return h.focus.Load()
+}
+
+// focusHold reports whether a reload must be held back by focus mode and records it
+func (h *DevWatch) focusHold() bool {
+	if !h.focus.Load() {
+		return false
+	}
+	h.focusMissed.Store(true)
+	rec := h.newEventRecord("", "reload")
+	rec.Skipped = "focus"
+	h.publish(rec)
+	return true
+}
@@ -0,0 +1,131 @@
+package devwatch
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFocusMode(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "app.css"), []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var reloads, built atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{&countingHandler{calls: &built}},
+		BrowserReload:      func() error { reloads.Add(1); return nil },
+		Logger:             func(message ...any) {},
+	})
+	records, cancel := w.Subscribe()
+	defer cancel()
+
+	w.SetFocusMode(true)
+	if !w.FocusMode() {
+		t.Fatal("expected focus mode on")
+	}
+	w.Trigger("app.css", "write")
+	w.Trigger("app.css", "write")
+	time.Sleep(150 * time.Millisecond)
+	if built.Load() != 2 || reloads.Load() != 0 {
+		t.Fatalf("expected builds without reloads, got %d builds %d reloads", built.Load(), reloads.Load())
+	}
+	skipped := false
+	for len(records) > 0 {
+		if rec := <-records; rec.Event == "reload" && rec.Skipped == "focus" {
+			skipped = true
+		}
+	}
+	if !skipped {
+		t.Error("expected a reload record skipped by focus mode")
+	}
+
+	w.SetFocusMode(false)
+	time.Sleep(150 * time.Millisecond)
+	if reloads.Load() != 1 {
+		t.Errorf("expected exactly one reload after focus mode, got %d", reloads.Load())
+	}
+}
+
+type countingHandler struct{ calls *atomic.Int32 }
+
+func (c *countingHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	c.calls.Add(1)
+	return nil
+}
+func (c *countingHandler) SupportedExtensions() []string { return []string{".css"} }
+
+func TestFocusMode_HoldsNotifications(t *testing.T) {
+	root := t.TempDir()
+	cssFile := filepath.Join(root, "app.css")
+	if err := os.WriteFile(cssFile, []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	flaky := &flakyHandler{fail: true}
+	var notes []Notification
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{flaky},
+		OnNotify:           func(n Notification) { notes = append(notes, n) },
+		LoopLimit:          -1,
+		Logger:             func(message ...any) {},
+	})
+
+	w.SetFocusMode(true)
+	w.Trigger(cssFile, "write")
+	os.WriteFile(cssFile, []byte("b{}"), 0644)
+	w.Trigger(cssFile, "write")
+	if len(notes) != 0 {
+		t.Fatalf("expected no notification in focus mode, got %v", notes)
+	}
+
+	w.SetFocusMode(false)
+	if len(notes) != 1 || notes[0].Handler != "flaky" || notes[0].Failures != 2 {
+		t.Errorf("expected the latest failure once focus mode ends, got %+v", notes)
+	}
+}
+
+func TestLiveReload_FocusEndpoint(t *testing.T) {
+	w := New(&WatchConfig{Logger: func(message ...any) {}})
+	srv := httptest.NewServer(&LiveReload{Focus: w})
+	t.Cleanup(srv.Close)
+	focus := srv.URL + LiveReloadPrefix + "focus"
+
+	resp, err := http.Post(focus+"?on=1", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"focus":true}` || !w.FocusMode() {
+		t.Errorf("POST on=1: got %s, focus mode %v", body, w.FocusMode())
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, focus+"?on=0", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || !w.FocusMode() {
+		t.Errorf("expected other sites not to toggle focus mode, got status %d", resp.StatusCode)
+	}
+
+	http.Post(focus+"?on=0", "", nil)
+	resp, err = http.Get(focus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"focus":false}` {
+		t.Errorf("GET after on=0: got %s", body)
+	}
+}
@@ -0,0 +1,32 @@
+package devwatch
+
+import "context"
+
+// ForceReload immediately invokes BrowserReload and the ReloadTransports, bypassing the debounce timer
+// and handler gating. Any pending debounced reload is cancelled so the browser
+// is not reloaded twice. Useful for handlers that finish asynchronous work
+// (eg: deploy) after their NewFileEvent returned.
+func (h *DevWatch) ForceReload() error {
+	h.reloadMutex.Lock()
+	if h.reloadTimer != nil && !h.reloadTimer.Stop() {
+		select {
+		case <-h.reloadTimer.C:
+		default:
+		}
+	}
+	h.limiter.deferred = false
+	h.reloadMutex.Unlock()
+
+	if err := h.verifyArtifacts(); err != nil {
+		return err
+	}
+
+	defer h.stats.reloaded()
+	if !h.reloadConfigured() {
+		return nil
+	}
+	_, span := h.startSpan(context.Background(), "devwatch.reload", "forced", "true")
+	err := h.browserReload(true)
+	span.End(err)
+	return err
+}
@@ -0,0 +1,35 @@
+package devwatch
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForceReload(t *testing.T) {
+	var reloadCount int64
+	w := New(&WatchConfig{
+		BrowserReload: func() error {
+			atomic.AddInt64(&reloadCount, 1)
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	// a pending debounced reload is replaced by the forced one
+	w.scheduleReload()
+	if err := w.ForceReload(); err != nil {
+		t.Fatalf("ForceReload returned error: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if got := atomic.LoadInt64(&reloadCount); got != 1 {
+		t.Fatalf("expected exactly 1 reload, got %d", got)
+	}
+
+	w.BrowserReload = func() error { return errors.New("reload failed") }
+	if err := w.ForceReload(); err == nil {
+		t.Error("expected ForceReload to return BrowserReload error")
+	}
+}
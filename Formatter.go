@@ -0,0 +1,110 @@
+package devwatch
+
+import (
+	"bytes"
+	"go/format"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Formatter rewrites saved Go files before any handler runs (see
+// WatchConfig.Format), so compile stages always see formatted code. The
+// write event caused by the rewrite is suppressed: it does not start a
+// second build cycle. Files that don't parse and generated files are left
+// untouched.
+type Formatter struct {
+	// Command formats the file in place, its path is appended
+	// eg: []string{"goimports", "-w"}. Default: gofmt in process.
+	Command []string
+}
+
+// format rewrites filePath and reports whether its content changed
+func (f *Formatter) format(filePath string) (bool, error) {
+	before, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	if len(f.Command) > 0 {
+		cmd := exec.Command(f.Command[0], append(f.Command[1:], filePath)...)
+		if _, err := runCommand(cmd, f.Command[0], nil); err != nil {
+			return false, err
+		}
+		after, err := os.ReadFile(filePath)
+		return err == nil && !bytes.Equal(before, after), err
+	}
+
+	formatted, err := format.Source(before)
+	if err != nil || bytes.Equal(before, formatted) {
+		return false, nil // syntax errors are reported by the compile stage
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(filePath, formatted, info.Mode().Perm())
+}
+
+// formatOnSave runs the configured Formatter on a created or written Go
+// file and remembers the version it wrote so its echo event is dropped
+func (h *DevWatch) formatOnSave(filePath, event string) {
+	if h.Format == nil || (event != "create" && event != "write") || IsGeneratedGoFile(filePath) {
+		return
+	}
+	changed, err := h.Format.format(filePath)
+	if err != nil {
+		h.say("format-error", err)
+		return
+	}
+	if changed {
+		h.echoes.add(canonicalPath(filePath), h.calculateFileHash(filePath))
+		h.say("formatted", h.relativePath(filePath))
+	}
+}
+
+// selfWriteTTL bounds how long a file version written by devwatch waits for
+// its echo event
+const selfWriteTTL = 2 * time.Second
+
+// selfWrites are the file versions written by devwatch itself, keyed by
+// canonical path. An event whose content hash matches is an echo.
+type selfWrites struct {
+	mu       sync.Mutex
+	versions map[string]selfWrite
+}
+
+type selfWrite struct {
+	hash string
+	at   time.Time
+}
+
+func (s *selfWrites) add(key, hash string) {
+	if hash == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions == nil {
+		s.versions = make(map[string]selfWrite)
+	}
+	s.versions[key] = selfWrite{hash: hash, at: time.Now()}
+}
+
+// consume reports whether an event for key with content hash is the echo of
+// a self write. Partial writes (eg: the truncate before the content) don't
+// match and keep waiting for the full version until selfWriteTTL.
+func (s *selfWrites) consume(key, hash string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.versions[key]
+	if !ok {
+		return false
+	}
+	expired := time.Since(w.at) >= selfWriteTTL
+	if w.hash == hash || expired {
+		delete(s.versions, key)
+	}
+	return w.hash == hash && !expired
+}
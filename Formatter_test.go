@@ -0,0 +1,101 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// allGoHandler counts every .go event
+type allGoHandler struct{ calls atomic.Int32 }
+
+func (a *allGoHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	a.calls.Add(1)
+	return nil
+}
+func (a *allGoHandler) SupportedExtensions() []string { return []string{".go"} }
+func (a *allGoHandler) OwnsAllGoFiles() bool          { return true }
+
+func TestFormatOnSave(t *testing.T) {
+	root := t.TempDir()
+	goFile := filepath.Join(root, "main.go")
+	if err := os.WriteFile(goFile, []byte("package main\nfunc main(){}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &allGoHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		Format:             &Formatter{},
+		Logger:             func(message ...any) { t.Log(message...) },
+		ExitChan:           make(chan bool, 1),
+	})
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.watcher = watcher
+	done := make(chan struct{})
+	go func() {
+		w.watchEvents()
+		close(done)
+	}()
+
+	waitCalls := func(want int32) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for handler.calls.Load() < want && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		time.Sleep(100 * time.Millisecond) // let unexpected events arrive
+		if got := handler.calls.Load(); got != want {
+			t.Fatalf("handler called %d times, want %d", got, want)
+		}
+	}
+
+	watcher.Events <- fsnotify.Event{Name: goFile, Op: fsnotify.Write}
+	waitCalls(1)
+	content, _ := os.ReadFile(goFile)
+	if want := "package main\n\nfunc main() {}\n"; string(content) != want {
+		t.Errorf("file not formatted: %q", content)
+	}
+
+	// the rewrite's own event is an echo
+	watcher.Events <- fsnotify.Event{Name: goFile, Op: fsnotify.Write}
+	waitCalls(1)
+
+	// later saves are handled again
+	if err := os.WriteFile(goFile, []byte("package main\n\nfunc main() { println() }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	watcher.Events <- fsnotify.Event{Name: goFile, Op: fsnotify.Write}
+	waitCalls(2)
+
+	w.ExitChan <- true
+	<-done
+}
+
+func TestSelfWrites(t *testing.T) {
+	var s selfWrites
+	s.add("a.go", "v2")
+	if s.consume("a.go", "partial") {
+		t.Error("a different version is not an echo")
+	}
+	if !s.consume("a.go", "v2") {
+		t.Error("expected the written version to be an echo")
+	}
+	if s.consume("a.go", "v2") {
+		t.Error("an echo is consumed once")
+	}
+
+	s.add("b.go", "v1")
+	s.versions["b.go"] = selfWrite{hash: "v1", at: time.Now().Add(-selfWriteTTL)}
+	if s.consume("b.go", "v1") {
+		t.Error("expired self writes are not echoes")
+	}
+}
@@ -2,45 +2,80 @@ package devwatch
 
 import (
 	"errors"
-	"path/filepath"
+	"path"
+	"strings"
 )
 
 // GetFileName returns the filename from a path
 // Example: "theme/index.html" -> "index.html"
-func GetFileName(path string) (string, error) {
-	if path == "" {
+func GetFileName(filePath string) (string, error) {
+	if filePath == "" {
 		return "", errors.New("GetFileName empty path")
 	}
 
 	// Check if path ends with a separator (either / or \)
-	if len(path) > 0 && (path[len(path)-1] == '/' || path[len(path)-1] == '\\') {
+	if strings.HasSuffix(filePath, "/") || strings.HasSuffix(filePath, "\\") {
 		return "", errors.New("GetFileName invalid path: ends with separator")
 	}
 
-	// Normalize backslashes to slashes for cross-platform compatibility
-	normPath := path
-	for i := 0; i < len(normPath); i++ {
-		if normPath[i] == '\\' {
-			// Replace all backslashes with slashes
-			normPath = ""
-			for j := 0; j < len(path); j++ {
-				if path[j] == '\\' {
-					normPath += "/"
-				} else {
-					normPath += string(path[j])
-				}
-			}
-			break
+	fileName := path.Base(slashPath(filePath))
+	if fileName == "." || fileName == ".." || fileName == "/" || isDriveRoot(fileName) {
+		return "", errors.New("GetFileName invalid path")
+	}
+
+	return fileName, nil
+}
+
+// GetRelPath returns filePath relative to root using forward slashes.
+// Both may use either separator; Windows drive letters compare case
+// insensitive. Returns "." when they are the same path and an error when
+// filePath is outside root.
+// Example: GetRelPath("C:\\app", "c:/app/web/main.go") -> "web/main.go"
+func GetRelPath(root, filePath string) (string, error) {
+	if root == "" || filePath == "" {
+		return "", errors.New("GetRelPath empty path")
+	}
+	r, p := slashPath(root), slashPath(filePath)
+	if isAbsSlash(r) != isAbsSlash(p) {
+		r, p = absSlashPath(r), absSlashPath(p)
+	}
+
+	switch {
+	case p == r:
+		return ".", nil
+	case r == ".":
+		if p == ".." || strings.HasPrefix(p, "../") {
+			return "", errors.New("GetRelPath path outside root: " + filePath)
 		}
+		return p, nil
+	}
+	// r is clean: only "/" (or a drive root "C:/") ends with a separator
+	n := len(r)
+	if !strings.HasSuffix(r, "/") {
+		n++
 	}
+	if len(p) <= n || !strings.HasPrefix(p, r) || p[n-1] != '/' {
+		return "", errors.New("GetRelPath path outside root: " + filePath)
+	}
+	return p[n:], nil
+}
 
-	fileName := filepath.Base(normPath)
-	if fileName == "." || fileName == string(filepath.Separator) {
-		return "", errors.New("GetFileName invalid path")
+// SplitNameExt returns the file name of a path without its extension and the extension.
+// Hidden files without another dot have no extension.
+// Example: "theme/index.html" -> "index", ".html" | "web/.env" -> ".env", ""
+func SplitNameExt(filePath string) (name, ext string) {
+	base := path.Base(slashPath(filePath))
+	if base == "." || base == ".." || base == "/" || isDriveRoot(base) {
+		return "", ""
 	}
-	if len(normPath) > 0 && normPath[len(normPath)-1] == filepath.Separator {
-		return "", errors.New("GetFileName invalid path")
+	ext = path.Ext(base)
+	if ext == base {
+		return base, ""
 	}
+	return strings.TrimSuffix(base, ext), ext
+}
 
-	return fileName, nil
+// isDriveRoot reports whether p is a bare Windows drive ("C:")
+func isDriveRoot(p string) bool {
+	return len(p) == 2 && p[1] == ':'
 }
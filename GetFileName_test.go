@@ -51,6 +51,36 @@ func TestGetFileName(t *testing.T) {
 			expected: "",
 			wantErr:  true,
 		},
+		{
+			name:     "path ends in backslash",
+			path:     "theme\\",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "windows drive path",
+			path:     "C:\\app\\web\\main.go",
+			expected: "main.go",
+			wantErr:  false,
+		},
+		{
+			name:     "drive root",
+			path:     "C:",
+			expected: "",
+			wantErr:  true,
+		},
+		{
+			name:     "dot segments",
+			path:     "./theme/../theme/index.html",
+			expected: "index.html",
+			wantErr:  false,
+		},
+		{
+			name:     "parent dir",
+			path:     "..",
+			expected: "",
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -66,3 +96,59 @@ func TestGetFileName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRelPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{"nested file", "/home/user/app", "/home/user/app/web/main.go", "web/main.go", false},
+		{"root with trailing separator", "/home/user/app/", "/home/user/app/web/main.go", "web/main.go", false},
+		{"same path", "/home/user/app", "/home/user/app/", ".", false},
+		{"dot segments", "/home/user/app", "/home/user/app/web/../main.go", "main.go", false},
+		{"drive letters ignore case", "C:\\app", "c:/app/web/main.go", "web/main.go", false},
+		{"relative to dot root", ".", "./web/main.go", "web/main.go", false},
+		{"outside root", "/home/user/app", "/home/user/other/main.go", "", true},
+		{"sibling with same prefix", "/home/user/app", "/home/user/app2/main.go", "", true},
+		{"escapes dot root", ".", "../main.go", "", true},
+		{"empty path", "/home/user/app", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetRelPath(tt.root, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRelPath(%q, %q) error = %v, wantErr %v", tt.root, tt.path, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Fatalf("GetRelPath(%q, %q) = %v, want %v", tt.root, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitNameExt(t *testing.T) {
+	tests := []struct {
+		path string
+		name string
+		ext  string
+	}{
+		{"theme/index.html", "index", ".html"},
+		{"C:\\app\\model.gen.go", "model.gen", ".go"},
+		{"web/.env", ".env", ""},
+		{"bin/server", "server", ""},
+		{"web/", "web", ""},
+		{".", "", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		name, ext := SplitNameExt(tt.path)
+		if name != tt.name || ext != tt.ext {
+			t.Errorf("SplitNameExt(%q) = %q, %q; want %q, %q", tt.path, name, ext, tt.name, tt.ext)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package devwatch
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// HandlerBuilder builds a FilesEventHandlers without declaring a type:
+//
+//	_, err := dw.Handle(".css", ".js").Name("assets").OnEvent(build).Ignore("dist").Register()
+type HandlerBuilder struct {
+	dw         *DevWatch
+	extensions []string
+	main       string
+	name       string
+	onEvent    func(fileName, extension, filePath, event string) error
+	ignore     []string
+	sources    []string
+}
+
+// Handle starts building a handler for the given extensions eg: ".go", ".css"
+func (h *DevWatch) Handle(extensions ...string) *HandlerBuilder {
+	return &HandlerBuilder{dw: h, extensions: extensions}
+}
+
+// Main sets the main input file relative to AppRootDir eg: "cmd/api/main.go".
+// Required for ".go" handlers: it selects the files the handler owns.
+func (b *HandlerBuilder) Main(path string) *HandlerBuilder {
+	b.main = path
+	return b
+}
+
+// Name sets the handler name used by profiles, logs and traces
+func (b *HandlerBuilder) Name(name string) *HandlerBuilder {
+	b.name = name
+	return b
+}
+
+// OnEvent sets the function called for each file event
+func (b *HandlerBuilder) OnEvent(fn func(fileName, extension, filePath, event string) error) *HandlerBuilder {
+	b.onEvent = fn
+	return b
+}
+
+// Ignore adds files or folders the watcher must not observe eg: "dist", "main.wasm"
+func (b *HandlerBuilder) Ignore(paths ...string) *HandlerBuilder {
+	b.ignore = append(b.ignore, paths...)
+	return b
+}
+
+// Sources limits the handler to the events of these RootSources names eg: "api"
+func (b *HandlerBuilder) Sources(names ...string) *HandlerBuilder {
+	b.sources = append(b.sources, names...)
+	return b
+}
+
+// Register validates the builder and adds the handler to the watcher
+func (b *HandlerBuilder) Register() (FilesEventHandlers, error) {
+	var errs []error
+	if len(b.extensions) == 0 {
+		errs = append(errs, errors.New("Handle: no extensions"))
+	}
+	for _, ext := range b.extensions {
+		if !strings.HasPrefix(ext, ".") || len(ext) < 2 {
+			errs = append(errs, errors.New("Handle: invalid extension "+ext+` (expected eg: ".css")`))
+		}
+	}
+	if b.onEvent == nil {
+		errs = append(errs, errors.New("Handle: OnEvent function is required"))
+	}
+	if slices.Contains(b.extensions, ".go") && b.main == "" {
+		errs = append(errs, errors.New("Handle: .go handlers require Main"))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	handler := &builtHandler{
+		extensions: slices.Clone(b.extensions),
+		main:       b.main,
+		name:       b.name,
+		onEvent:    b.onEvent,
+		ignore:     slices.Clone(b.ignore),
+		sources:    slices.Clone(b.sources),
+	}
+	b.dw.AddFilesEventHandlers(handler)
+	return handler, nil
+}
+
+// builtHandler is the FilesEventHandlers produced by HandlerBuilder
+type builtHandler struct {
+	extensions []string
+	main       string
+	name       string
+	onEvent    func(fileName, extension, filePath, event string) error
+	ignore     []string
+	sources    []string
+}
+
+func (b *builtHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	return b.onEvent(fileName, extension, filePath, event)
+}
+
+func (b *builtHandler) SupportedExtensions() []string     { return b.extensions }
+func (b *builtHandler) MainInputFileRelativePath() string { return b.main }
+func (b *builtHandler) UnobservedFiles() []string         { return b.ignore }
+func (b *builtHandler) Name() string                      { return b.name }
+func (b *builtHandler) Sources() []string                 { return b.sources }
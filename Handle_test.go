@@ -0,0 +1,57 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHandle(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "style.css"), []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+
+	var got []string
+	handler, err := w.Handle(".css", ".js").
+		Name("assets").
+		OnEvent(func(fileName, extension, filePath, event string) error {
+			got = append(got, fileName+" "+event)
+			return nil
+		}).
+		Ignore("dist").
+		Register()
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if handlerName(handler) != "assets" || len(w.FilesEventHandlers) != 1 {
+		t.Fatalf("expected named handler registered, got %q (%d handlers)", handlerName(handler), len(w.FilesEventHandlers))
+	}
+	if !w.Contain(filepath.Join(root, "dist", "app.js")) {
+		t.Error("expected Ignore paths to be unobserved")
+	}
+	if err := w.Trigger("style.css", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != "style.css write" {
+		t.Errorf("unexpected events: %v", got)
+	}
+}
+
+func TestHandle_Validation(t *testing.T) {
+	w := New(&WatchConfig{AppRootDir: t.TempDir()})
+	_, err := w.Handle("css", ".go").Register()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"invalid extension css", "OnEvent function is required", ".go handlers require Main"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got: %v", want, err)
+		}
+	}
+	if len(w.FilesEventHandlers) != 0 {
+		t.Error("expected invalid handler not to be registered")
+	}
+}
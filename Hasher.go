@@ -0,0 +1,98 @@
+package devwatch
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Hasher identifies the current version of a file. Two calls return the same
+// string only if the file did not change (as far as the hasher can tell).
+// Used by the smart debounce and to dedupe shared handler work.
+type Hasher interface {
+	Hash(filePath string) (string, error)
+}
+
+// ContentHasher hashes the full content with SHA-256 (default, exact)
+type ContentHasher struct{}
+
+func (ContentHasher) Hash(filePath string) (string, error) {
+	return hashContent(filePath, sha256.New(), nil)
+}
+
+// FastContentHasher hashes the full content with 64-bit FNV-1a: exact enough
+// for change detection and cheaper than SHA-256 on large files
+type FastContentHasher struct{}
+
+func (FastContentHasher) Hash(filePath string) (string, error) {
+	return hashContent(filePath, fnv.New64a(), nil)
+}
+
+// ModTimeHasher uses modification time and size without reading the file.
+// Fastest for huge files; misses rewrites keeping size within the same mtime tick.
+type ModTimeHasher struct{}
+
+func (ModTimeHasher) Hash(filePath string) (string, error) {
+	info, err := statRetry(filePath)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(info.ModTime().UnixNano(), 36) + "-" + strconv.FormatInt(info.Size(), 36), nil
+}
+
+// GitBlobHasher returns the git blob id of the content (same as `git hash-object`),
+// so hashes can be compared with the index or HEAD
+type GitBlobHasher struct{}
+
+func (GitBlobHasher) Hash(filePath string) (string, error) {
+	info, err := statRetry(filePath)
+	if err != nil {
+		return "", err
+	}
+	return hashContent(filePath, sha1.New(), fmt.Appendf(nil, "blob %d\x00", info.Size()))
+}
+
+// copyBuffers pools the read buffers used to hash file content
+var copyBuffers = sync.Pool{New: func() any {
+	buf := make([]byte, 32*1024)
+	return &buf
+}}
+
+// hashContent writes prefix and the file content to hasher
+func hashContent(filePath string, hasher hash.Hash, prefix []byte) (string, error) {
+	file, err := openRetry(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := copyBuffers.Get().(*[]byte)
+	defer copyBuffers.Put(buf)
+
+	hasher.Write(prefix)
+	// hide WriterTo so the pooled buffer is used instead of a new one per call
+	if _, err := io.CopyBuffer(hasher, struct{ io.Reader }{file}, *buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// calculateFileHash returns the configured Hasher result for smart debouncing.
+// Returns "" if the file cannot be read.
+func (h *DevWatch) calculateFileHash(filePath string) string {
+	hasher := h.Hasher
+	if hasher == nil {
+		hasher = ContentHasher{}
+	}
+	sum, err := hasher.Hash(filePath)
+	if err != nil {
+		return "" // File doesn't exist or can't be read
+	}
+	return sum
+}
@@ -0,0 +1,59 @@
+package devwatch
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHashers(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+	write := func(content string, mtime time.Time) {
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t0 := time.Now().Add(-time.Minute)
+
+	for name, hasher := range map[string]Hasher{
+		"content":      ContentHasher{},
+		"fast content": FastContentHasher{},
+		"git blob":     GitBlobHasher{},
+		"mtime":        ModTimeHasher{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			write("package main", t0)
+			a, err := hasher.Hash(file)
+			if err != nil || a == "" {
+				t.Fatalf("Hash error: %v", err)
+			}
+			if b, _ := hasher.Hash(file); a != b {
+				t.Error("expected stable hash for unchanged file")
+			}
+			write("package main // edit", t0.Add(time.Second))
+			if c, _ := hasher.Hash(file); c == a {
+				t.Error("expected different hash after change")
+			}
+			if _, err := hasher.Hash(file + ".missing"); err == nil {
+				t.Error("expected error for missing file")
+			}
+		})
+	}
+
+	// git blob ids match git hash-object
+	if git, err := exec.LookPath("git"); err == nil {
+		out, err := exec.Command(git, "hash-object", file).Output()
+		if err == nil {
+			got, _ := GitBlobHasher{}.Hash(file)
+			if want := strings.TrimSpace(string(out)); got != want {
+				t.Errorf("GitBlobHasher = %s, git hash-object = %s", got, want)
+			}
+		}
+	}
+}
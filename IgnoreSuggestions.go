@@ -0,0 +1,59 @@
+package devwatch
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// noisyDirEvents is the number of events without any successful handler
+// work after which a directory is suggested for UnobservedFiles
+const noisyDirEvents = 50
+
+// artifactDirs are directory names that usually hold build or tool output
+var artifactDirs = []string{"dist", "build", "out", "tmp", "temp", "coverage", "node_modules", ".cache", "target"}
+
+// IgnoreSuggestion is a path that probably should be in UnobservedFiles
+type IgnoreSuggestion struct {
+	Path   string // relative to AppRootDir eg: "web/dist"
+	Reason string
+}
+
+// IgnoreSuggestions returns the paths suggested so far, in the order they were found.
+// Each suggestion is also logged once when found.
+func (h *DevWatch) IgnoreSuggestions() []IgnoreSuggestion {
+	h.activity.mu.Lock()
+	defer h.activity.mu.Unlock()
+	return slices.Clone(h.activity.suggestions)
+}
+
+// suggestIgnore checks dir after an event and logs a suggestion when it
+// looks like generated output: a known artifact folder, or many events
+// that never led to successful handler work
+func (h *DevWatch) suggestIgnore(dir string) {
+	rel := h.relativePath(dir)
+	if rel == "." {
+		return
+	}
+
+	var s IgnoreSuggestion
+	parts := strings.Split(rel, "/")
+	if i := slices.IndexFunc(parts, func(p string) bool { return slices.Contains(artifactDirs, p) }); i >= 0 {
+		s = IgnoreSuggestion{Path: path.Join(parts[:i+1]...), Reason: "matches build artifact folder " + parts[i]}
+	} else if events, handled := h.activity.counts(dir); events >= noisyDirEvents && handled == 0 {
+		s = IgnoreSuggestion{Path: rel, Reason: fmt.Sprintf("%d events and no handler work", events)}
+	} else {
+		return
+	}
+
+	h.activity.mu.Lock()
+	if slices.ContainsFunc(h.activity.suggestions, func(x IgnoreSuggestion) bool { return x.Path == s.Path }) {
+		h.activity.mu.Unlock()
+		return
+	}
+	h.activity.suggestions = append(h.activity.suggestions, s)
+	h.activity.mu.Unlock()
+
+	h.say("ignore-suggestion", s.Path, s.Reason)
+}
@@ -0,0 +1,45 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIgnoreSuggestions(t *testing.T) {
+	root := t.TempDir()
+	var logs int
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		Logger:     func(message ...any) { logs++ },
+	})
+
+	// known artifact folder, suggested at its top level and only once
+	distJS := filepath.Join(root, "web", "dist", "js")
+	w.activity.record(distJS, time.Now())
+	w.suggestIgnore(distJS)
+	w.suggestIgnore(distJS)
+
+	// noisy folder whose events never reach a handler
+	logsDir := filepath.Join(root, "logs")
+	for range noisyDirEvents {
+		w.activity.record(logsDir, time.Now())
+	}
+	w.suggestIgnore(logsDir)
+
+	// busy source folder with handler work is not suggested
+	srcDir := filepath.Join(root, "src")
+	for range noisyDirEvents {
+		w.activity.record(srcDir, time.Now())
+	}
+	w.activity.handled(srcDir)
+	w.suggestIgnore(srcDir)
+
+	got := w.IgnoreSuggestions()
+	if len(got) != 2 || got[0].Path != "web/dist" || got[1].Path != "logs" {
+		t.Fatalf("unexpected suggestions: %+v", got)
+	}
+	if logs != 2 {
+		t.Errorf("expected each suggestion to be logged once, got %d logs", logs)
+	}
+}
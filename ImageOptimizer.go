@@ -0,0 +1,110 @@
+package devwatch
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ImageOptimizer runs an optimizer or converter for each created or
+// modified image, writing the result into OutDir with the same relative
+// path (and OutExt when set). The command reads DEVWATCH_PATH and writes
+// DEVWATCH_OUTPUT:
+//
+//	watcher.AddFilesEventHandlers(&devwatch.ImageOptimizer{
+//		Dir:     appRoot,
+//		Source:  "web/img",
+//		OutDir:  "web/public/img",
+//		OutExt:  ".webp",
+//		Command: []string{"sh", "-c", `cwebp -quiet -q 80 "$DEVWATCH_PATH" -o "$DEVWATCH_OUTPUT"`},
+//	})
+//
+// OutDir is a generated path, so written images don't trigger handlers
+// again, and the browser reload reports the output instead of the source:
+// a LiveReload with InjectImages swaps the image without reloading the page.
+// Removing a source removes its output.
+type ImageOptimizer struct {
+	Dir        string    // project root, usually AppRootDir
+	Source     string    // folder holding the source images, relative to Dir (default: Dir)
+	OutDir     string    // relative to Dir eg: "web/public/img"
+	OutExt     string    // output extension eg: ".webp" (default: the source's)
+	Extensions []string  // default: .png .jpg .jpeg .svg
+	Command    []string  // eg: []string{"sh", "-c", `svgo "$DEVWATCH_PATH" -o "$DEVWATCH_OUTPUT"`}
+	Output     io.Writer // also receives the command stdout and stderr
+}
+
+// Name implements NamedHandler
+func (o *ImageOptimizer) Name() string { return "images" }
+
+func (o *ImageOptimizer) SupportedExtensions() []string {
+	if len(o.Extensions) > 0 {
+		return o.Extensions
+	}
+	return []string{".png", ".jpg", ".jpeg", ".svg"}
+}
+
+// Scope implements ScopedHandler: only images under Source
+func (o *ImageOptimizer) Scope() []string {
+	if o.Source == "" {
+		return nil
+	}
+	return []string{o.Source}
+}
+
+// GeneratedPaths implements CodegenHandler
+func (o *ImageOptimizer) GeneratedPaths() []string { return []string{o.OutDir} }
+
+// Outputs implements ArtifactHandler
+func (o *ImageOptimizer) Outputs(sourcePath string) []string {
+	if out := o.output(sourcePath); out != "" {
+		return []string{out}
+	}
+	return nil
+}
+
+// ReloadTargets implements ReloadTargetHandler: pages only need the new image
+func (o *ImageOptimizer) ReloadTargets(sourcePath string) []string { return o.Outputs(sourcePath) }
+
+// NewFileEvent writes the optimized image, or removes it with its source
+func (o *ImageOptimizer) NewFileEvent(fileName, extension, filePath, event string) error {
+	output := o.output(filePath)
+	if output == "" {
+		return errors.New("ImageOptimizer: " + filePath + " is outside Source")
+	}
+	output = filepath.Join(o.Dir, output)
+	if event == "remove" || event == "rename" {
+		if err := os.Remove(output); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if len(o.Command) == 0 {
+		return errors.New("ImageOptimizer: empty Command")
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return err
+	}
+	cmd := exec.Command(o.Command[0], o.Command[1:]...)
+	cmd.Dir = o.Dir
+	cmd.Env = append(os.Environ(), "DEVWATCH_PATH="+filePath, "DEVWATCH_OUTPUT="+output, "DEVWATCH_EVENT="+event)
+	_, err := runCommand(cmd, o.Name(), o.Output)
+	return err
+}
+
+// output returns the output of sourcePath relative to Dir, "" outside Source
+func (o *ImageOptimizer) output(sourcePath string) string {
+	if !filepath.IsAbs(sourcePath) {
+		sourcePath = filepath.Join(o.Dir, sourcePath)
+	}
+	rel, err := filepath.Rel(filepath.Join(o.Dir, o.Source), sourcePath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	if o.OutExt != "" {
+		rel = strings.TrimSuffix(rel, filepath.Ext(rel)) + o.OutExt
+	}
+	return filepath.ToSlash(filepath.Join(o.OutDir, rel))
+}
@@ -0,0 +1,63 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestImageOptimizer_WritesAndRemovesOutput(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"web/img/icons/logo.png": "png",
+		"web/other.png":          "png",
+	})
+	images := &ImageOptimizer{
+		Dir:     root,
+		Source:  "web/img",
+		OutDir:  "web/public/img",
+		OutExt:  ".webp",
+		Command: []string{"sh", "-c", `cp "$DEVWATCH_PATH" "$DEVWATCH_OUTPUT"`},
+	}
+	reloaded := make(chan []string, 1)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{images},
+		BrowserReloadPaths: func(paths []string) error { reloaded <- paths; return nil },
+		Logger:             func(message ...any) {},
+	})
+
+	if err := w.Trigger("web/img/icons/logo.png", "write"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case paths := <-reloaded:
+		if !slices.Equal(paths, []string{"web/public/img/icons/logo.webp"}) {
+			t.Errorf("reload paths = %v, want the output image", paths)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a reload")
+	}
+	output := filepath.Join(root, "web", "public", "img", "icons", "logo.webp")
+	if _, err := os.Stat(output); err != nil {
+		t.Fatalf("expected the output image: %v", err)
+	}
+	if !w.isGenerated(output) {
+		t.Error("output must be generated")
+	}
+	if matches := w.MatchHandlers(filepath.Join(root, "web", "other.png")); len(matches) != 1 || matches[0].Matched {
+		t.Errorf("images outside Source must not be handled: %+v", matches)
+	}
+
+	if err := os.Remove(filepath.Join(root, "web", "img", "icons", "logo.png")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger("web/img/icons/logo.png", "remove"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("expected the output to be removed with its source, got %v", err)
+	}
+}
@@ -1,26 +1,19 @@
 package devwatch
 
 import (
+	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"slices"
 )
 
 // addDirectoryToWatcher adds a directory to the watcher and handles folder events
 // This method is reused both in InitialRegistration and when new directories are created
-func (h *DevWatch) addDirectoryToWatcher(path string, reg map[string]struct{}) error {
-	if _, exists := reg[path]; exists {
-		return nil // Already registered
-	}
-
-	if err := h.watcher.Add(path); err != nil {
-		h.Logger("Failed to add directory to watcher:", path, err)
+func (h *DevWatch) addDirectoryToWatcher(path string) error {
+	if added, err := h.watchDirectory(path); err != nil || !added {
 		return err
 	}
 
-	reg[path] = struct{}{}
-	h.Logger("path added:", path)
-
 	// Get fileName once and reuse
 	fileName, err := GetFileName(path)
 	if err == nil {
@@ -28,23 +21,47 @@ func (h *DevWatch) addDirectoryToWatcher(path string, reg map[string]struct{}) e
 		if h.FolderEvents != nil {
 			err = h.FolderEvents.NewFolderEvent(fileName, path, "create")
 			if err != nil {
-				h.Logger("folder event error:", err)
+				h.say("folder-event-error", err)
 			}
 		}
 	}
 
 	if err != nil {
-		h.Logger("addDirectoryToWatcher:", err)
+		h.say("watch-dir-error", err)
 	}
 
 	return nil
 }
 
-func (h *DevWatch) InitialRegistration() {
-	h.Logger("Registration APP ROOT DIR: " + h.AppRootDir)
+// watchDirectory adds a directory to the watcher without folder events. It
+// reports false when the directory was registered already.
+func (h *DevWatch) watchDirectory(path string) (bool, error) {
+	if h.beyondDepth(path) {
+		return false, nil // see WatchConfig.MaxDepth
+	}
+	if h.overBudget(path) {
+		h.pollDirectory(path)
+		return false, nil
+	}
+	if !h.registry.claim(path) {
+		return false, nil // Already registered (possibly through another root or symlink)
+	}
+
+	if err := h.currentWatcher().Add(path); err != nil {
+		h.registry.release(path)
+		h.say("watch-dir-failed", path, err)
+		return false, err
+	}
 
-	// Initialize no_add_to_watch map and load unobserved files from all handlers
+	h.say("path-added", path)
+	return true, nil
+}
+
+// loadUnobservedFiles initializes the no_add_to_watch map and loads
+// unobserved files from WatchConfig and all handlers
+func (h *DevWatch) loadUnobservedFiles() {
 	h.noAddMu.Lock()
+	defer h.noAddMu.Unlock()
 	if h.no_add_to_watch == nil {
 		h.no_add_to_watch = make(map[string]bool)
 	}
@@ -58,60 +75,116 @@ func (h *DevWatch) InitialRegistration() {
 
 	// Load unobserved files from each FilesEventHandler
 	for _, handler := range h.FilesEventHandlers {
-		for _, file := range handler.UnobservedFiles() {
+		for _, file := range handlerUnobserved(handler) {
 			h.no_add_to_watch[file] = true
 		}
 	}
-	h.noAddMu.Unlock()
+}
+
+func (h *DevWatch) InitialRegistration() {
+	h.say("registration-root", h.AppRootDir)
 
-	reg := make(map[string]struct{})
+	h.loadUnobservedFiles()
+	if h.WatchBudget > 0 {
+		h.planWatchBudget()
+	}
+
+	roots := h.watchRoots()
+	h.census.reset(roots)
+	for _, root := range roots {
+		h.registerRoot(root)
+	}
+}
 
-	err := filepath.Walk(h.AppRootDir, func(path string, info os.FileInfo, err error) error {
+// registerRoot adds the directories under root to the watcher and sends the
+// existing files to their handlers. AppRootDir is walked through
+// WatchConfig.FS when set.
+func (h *DevWatch) registerRoot(root string) {
+	if err := h.walkRoot(root, h.registerEntry); err != nil {
+		h.say("walk-error", err)
+	}
+}
+
+// walkRoot calls fn for every entry under root, through WatchConfig.FS for
+// AppRootDir when set. Entries that can't be accessed are logged and skipped.
+func (h *DevWatch) walkRoot(root string, fn func(path string, isDir bool) error) error {
+	if h.FS != nil && h.samePath(root, h.AppRootDir) {
+		return fs.WalkDir(h.FS, ".", func(name string, d fs.DirEntry, err error) error {
+			path := filepath.Join(root, filepath.FromSlash(name))
+			if err != nil {
+				h.say("path-error", path, err)
+				return nil
+			}
+			return fn(path, d.IsDir())
+		})
+	}
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			h.Logger("accessing path error:", path, err)
+			h.say("path-error", path, err)
 			return nil
 		}
+		return fn(path, info.IsDir())
+	})
+}
 
-		if info.IsDir() && !h.Contain(path) {
-			h.addDirectoryToWatcher(path, reg)
-		} else if !info.IsDir() {
-			// Check if this file should be ignored before processing
-			if h.Contain(path) {
-				return nil // Skip ignored files
+// registerEntry watches a directory found by registerRoot or sends a file to its handlers
+func (h *DevWatch) registerEntry(path string, isDir bool) error {
+	if isDir && h.beyondDepth(path) {
+		return filepath.SkipDir
+	}
+	if isDir && !h.Contain(path) {
+		h.addDirectoryToWatcher(path)
+	} else if !isDir {
+		// Check if this file should be ignored before processing
+		if h.Contain(path) {
+			return nil // Skip ignored files
+		}
+		h.warnSensitive(path)
+		h.countExtension(path, h.anyHandlerSupports(path))
+
+		// Process existing files during initial registration
+		fileName, ferr := GetFileName(path)
+		if ferr == nil {
+			extension, source := filepath.Ext(path), h.sourceOf(path)
+			h.snapshotContent(path)
+			if extension == ".go" {
+				h.recordGoSignature(path)
+				if h.WarmOwnership {
+					h.ownership.recordImports(path)
+				}
+				if h.skipGeneratedGo(path, "create") {
+					return nil
+				}
 			}
 
-			// Process existing files during initial registration
-			fileName, ferr := GetFileName(path)
-			if ferr == nil {
-				extension := filepath.Ext(path)
-
-				for _, handler := range h.FilesEventHandlers {
-					if slices.Contains(handler.SupportedExtensions(), extension) {
-						var isMine = true
-						var herr error
-
-						if extension == ".go" {
-							isMine, herr = h.depFinder.ThisFileIsMine(handler.MainInputFileRelativePath(), path, "create")
-							if herr != nil {
-								//h.Logger("InitialRegistration go file error:", herr)
-								continue // Skip on error
-							}
+			for _, handler := range h.orderHandlers(h.FilesEventHandlers) {
+				matchedExt, supported := handlerSupports(handler, path, extension)
+				if supported && handlerInScope(handler, h.relativePath(path)) && handlerForSource(handler, source) {
+					var isMine = true
+					var herr error
+
+					if extension == ".go" {
+						isMine, herr = h.goFileIsMine(handler, path, "create")
+						if herr != nil {
+							//h.Logger("InitialRegistration go file error:", herr)
+							continue // Skip on error
 						}
+					}
 
-						if isMine {
-							err = handler.NewFileEvent(fileName, extension, path, "create")
-							if err != nil {
-								h.Logger("InitialRegistration file error:", err)
-							}
+					if isMine && h.auditing() {
+						h.auditCall(handler)
+					} else if isMine {
+						err := h.callHandler(context.Background(), handler, FileChange{FileName: fileName, Extension: matchedExt, FilePath: path, Event: "create", Source: source})
+						if err != nil {
+							h.say("registration-error", err)
+							h.initialBuildFailed()
+						} else if extension != ".go" {
+							h.fingerprint(path, "create")
 						}
 					}
 				}
 			}
 		}
-		return nil
-	})
-
-	if err != nil {
-		h.Logger("Walking directory:", err)
 	}
+	return nil
 }
@@ -0,0 +1,49 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type recordingHandler struct{ paths []string }
+
+func (r *recordingHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	r.paths = append(r.paths, filePath)
+	return nil
+}
+func (r *recordingHandler) SupportedExtensions() []string { return []string{".css", ".js"} }
+
+func TestInitialRegistration_FS(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "virtual", "app")
+	handler := &recordingHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		Logger:             func(message ...any) {},
+		FS: fstest.MapFS{
+			"app.js":                  {Data: []byte("main()")},
+			"web/style.css":           {Data: []byte("body{}")},
+			"web/node_modules/lib.js": {Data: []byte("lib()")},
+			".git/config":             {Data: []byte("[core]")},
+			"README.md":               {Data: []byte("# app")},
+		},
+	})
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	w.watcher = watcher
+
+	w.InitialRegistration()
+
+	slices.Sort(handler.paths)
+	want := []string{filepath.Join(root, "app.js"), filepath.Join(root, "web", "style.css")}
+	if !slices.Equal(handler.paths, want) {
+		t.Errorf("handler got %v, want %v", handler.paths, want)
+	}
+}
@@ -0,0 +1,75 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// instanceLockFile is created in AppRootDir while a devwatch instance watches it
+const instanceLockFile = ".devwatch.lock"
+
+// instanceOwner is the content of the lock file
+type instanceOwner struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	Started time.Time `json:"started"`
+}
+
+// LockInstance makes sure no other devwatch instance watches AppRootDir by
+// creating AppRootDir/.devwatch.lock. The error identifies the process
+// holding it. A lock left by a process that no longer exists is taken over.
+// FileWatcherStart calls it when WatchConfig.SingleInstance is set; the lock
+// is released when the watcher stops.
+func (h *DevWatch) LockInstance() error {
+	if h.lockPath != "" {
+		return nil // already held
+	}
+	path := filepath.Join(h.AppRootDir, instanceLockFile)
+	host, _ := os.Hostname()
+	owner, _ := json.Marshal(instanceOwner{PID: os.Getpid(), Host: host, Started: time.Now()})
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = f.Write(owner)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return err
+			}
+			h.lockPath = path
+			h.RegisterInternalPath(path)
+			return nil
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+
+		var other instanceOwner
+		data, _ := os.ReadFile(path)
+		if json.Unmarshal(data, &other) == nil && other.Host == host && !processAlive(other.PID) {
+			os.Remove(path) // stale lock of a crashed instance
+			continue
+		}
+		msg := "devwatch is already watching " + h.AppRootDir
+		if other.PID != 0 {
+			msg += " (pid " + strconv.Itoa(other.PID) + " on " + other.Host + " since " + other.Started.Format(time.DateTime) + ")"
+		}
+		return errors.New(msg + ", remove " + path + " if it is not running")
+	}
+	return errors.New("LockInstance: could not take over stale lock " + path)
+}
+
+// unlockInstance removes the lock file taken by LockInstance
+func (h *DevWatch) unlockInstance() {
+	if h.lockPath != "" {
+		os.Remove(h.lockPath)
+		h.lockPath = ""
+	}
+}
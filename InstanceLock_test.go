@@ -0,0 +1,71 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockInstance(t *testing.T) {
+	root := t.TempDir()
+	first := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+	second := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+
+	if err := first.LockInstance(); err != nil {
+		t.Fatal(err)
+	}
+	err := second.LockInstance()
+	if err == nil || !strings.Contains(err.Error(), "pid "+strconv.Itoa(os.Getpid())) {
+		t.Fatalf("expected error identifying the running instance, got %v", err)
+	}
+	if !first.Contain(filepath.Join(root, instanceLockFile)) {
+		t.Error("the lock file must not be observed")
+	}
+
+	first.unlockInstance()
+	if err := second.LockInstance(); err != nil {
+		t.Fatalf("expected lock after release, got %v", err)
+	}
+	second.unlockInstance()
+
+	// a lock left by a dead process is taken over
+	host, _ := os.Hostname()
+	stale, _ := json.Marshal(instanceOwner{PID: 1 << 30, Host: host, Started: time.Now()})
+	if err := os.WriteFile(filepath.Join(root, instanceLockFile), stale, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.LockInstance(); err != nil {
+		t.Fatalf("expected stale lock to be taken over, got %v", err)
+	}
+	first.unlockInstance()
+}
+
+func TestSingleInstance_FileWatcherStart(t *testing.T) {
+	root := t.TempDir()
+	holder := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+	if err := holder.LockInstance(); err != nil {
+		t.Fatal(err)
+	}
+	defer holder.unlockInstance()
+
+	var reported error
+	w := New(&WatchConfig{
+		AppRootDir:     root,
+		SingleInstance: true,
+		ExitChan:       make(chan bool),
+		OnError:        func(err error) { reported = err },
+		Logger:         func(message ...any) {},
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	wg.Wait()
+	if reported == nil || !strings.Contains(reported.Error(), "already watching") {
+		t.Errorf("expected FileWatcherStart to refuse, got %v", reported)
+	}
+}
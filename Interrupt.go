@@ -0,0 +1,122 @@
+package devwatch
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// runningCall is a ContextFileChangeHandler call a newer save can cancel
+type runningCall struct {
+	handler    FilesEventHandlers
+	path       string
+	cancel     context.CancelFunc
+	supersedBy string    // path of the newer event that cancelled it
+	supersedAt time.Time // when it was cancelled
+}
+
+// supersededCall is a cancelled call waiting for the newer event to reach its handler
+type supersededCall struct {
+	handler FilesEventHandlers
+	change  FileChange
+	by      string
+	at      time.Time
+}
+
+// interrupts tracks the handler calls InterruptStaleBuilds may cancel
+type interrupts struct {
+	mu         sync.Mutex
+	running    map[*runningCall]bool
+	superseded map[int]supersededCall // handler index in FilesEventHandlers => interrupted call
+}
+
+// sameTarget reports whether a newer event for path b makes a build started
+// for path a stale: the same file, or non-test Go files of one package
+func sameTarget(a, b string) bool {
+	if a == b {
+		return true
+	}
+	goSource := func(p string) bool { return filepath.Ext(p) == ".go" && !strings.HasSuffix(p, "_test.go") }
+	return goSource(a) && goSource(b) && filepath.Dir(a) == filepath.Dir(b)
+}
+
+// interruptStale cancels the running calls a newer event for path makes stale
+func (h *DevWatch) interruptStale(path string) {
+	if !h.InterruptStaleBuilds {
+		return
+	}
+	h.interrupts.mu.Lock()
+	defer h.interrupts.mu.Unlock()
+	for call := range h.interrupts.running {
+		if call.supersedBy == "" && sameTarget(call.path, path) {
+			call.supersedBy, call.supersedAt = path, time.Now()
+			call.cancel()
+		}
+	}
+}
+
+// interruptible returns the context for a call of handler (index id) on
+// path, and a finish func reporting whether a newer event cancelled the
+// call. Only ContextFileChangeHandlers are interrupted, other handlers get ctx.
+func (h *DevWatch) interruptible(ctx context.Context, id int, handler FilesEventHandlers, path string) (context.Context, func(FileChange) bool) {
+	if _, ok := handler.(ContextFileChangeHandler); !ok || !h.InterruptStaleBuilds {
+		return ctx, func(FileChange) bool { return false }
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	call := &runningCall{handler: handler, path: path, cancel: cancel}
+
+	h.interrupts.mu.Lock()
+	if h.interrupts.running == nil {
+		h.interrupts.running = make(map[*runningCall]bool)
+		h.interrupts.superseded = make(map[int]supersededCall)
+	}
+	// this call builds the newest code: an interrupted one needs no retry
+	delete(h.interrupts.superseded, id)
+	h.interrupts.running[call] = true
+	h.interrupts.mu.Unlock()
+
+	return ctx, func(change FileChange) bool {
+		cancel()
+		h.interrupts.mu.Lock()
+		defer h.interrupts.mu.Unlock()
+		delete(h.interrupts.running, call)
+		if call.supersedBy == "" {
+			return false
+		}
+		h.interrupts.superseded[id] = supersededCall{handler: handler, change: change, by: call.supersedBy, at: call.supersedAt}
+		return true
+	}
+}
+
+// retrySuperseded runs again the calls interrupted by the event for path
+// that this event didn't reach, eg: a comment-only save. Events handled
+// since started only; an earlier one is the interrupted event itself.
+func (h *DevWatch) retrySuperseded(path string, started time.Time) {
+	h.interrupts.mu.Lock()
+	var retries []supersededCall
+	for id, s := range h.interrupts.superseded {
+		if s.by == path && s.at.Before(started) {
+			retries = append(retries, s)
+			delete(h.interrupts.superseded, id)
+		}
+	}
+	h.interrupts.mu.Unlock()
+
+	for _, s := range retries {
+		handler, change := s.handler, s.change
+		rec := h.newEventRecord(change.FilePath, change.Event)
+		start := time.Now()
+		err := h.callHandler(context.Background(), handler, change)
+		rec.addResult(handler, start, err)
+		if err == nil {
+			rec.Reload = true
+			h.changed.add(h.relativePath(change.FilePath), change.Event, true)
+			h.scheduleReload()
+		} else if h.OnError != nil && DiagnosticsOf(err) != nil {
+			h.OnError(err)
+		}
+		h.publish(rec)
+	}
+}
@@ -0,0 +1,192 @@
+package devwatch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowBuild blocks its first call until cancelled (or 3s) and records every call
+type slowBuild struct {
+	mu       sync.Mutex
+	started  chan struct{}
+	blocked  atomic.Bool
+	calls    []string
+	canceled int
+}
+
+func (b *slowBuild) NewFileChangeContext(ctx context.Context, change FileChange) error {
+	b.mu.Lock()
+	b.calls = append(b.calls, filepath.Base(change.FilePath))
+	b.mu.Unlock()
+	if !b.blocked.CompareAndSwap(false, true) {
+		return nil
+	}
+	close(b.started)
+	select {
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.canceled++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-time.After(3 * time.Second):
+		return nil
+	}
+}
+
+func (b *slowBuild) NewFileEvent(fileName, extension, filePath, event string) error { return nil }
+func (b *slowBuild) SupportedExtensions() []string                                  { return []string{".css"} }
+
+func (b *slowBuild) result() ([]string, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...), b.canceled
+}
+
+func TestInterruptStaleBuilds(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+
+	build := &slowBuild{started: make(chan struct{})}
+	var reloads atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:           tempDir,
+		FilesEventHandlers:   []FilesEventHandlers{build},
+		InterruptStaleBuilds: true,
+		BrowserReload:        func() error { reloads.Add(1); return nil },
+		Logger:               func(message ...any) {},
+	})
+	stop := make(chan struct{})
+	workers := w.startWorkers(stop)
+	defer func() {
+		close(stop)
+		workers.Wait()
+	}()
+
+	os.WriteFile(cssFile, []byte("a {}"), 0644)
+	w.enqueueFileEvent("style.css", cssFile, "write", false)
+	<-build.started
+
+	start := time.Now()
+	os.WriteFile(cssFile, []byte("b {}"), 0644)
+	w.enqueueFileEvent("style.css", cssFile, "write", false)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for reloads.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if reloads.Load() == 0 {
+		t.Fatal("expected a reload after the newer build")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("newer build waited %v for the stale one", elapsed)
+	}
+	if calls, canceled := build.result(); len(calls) != 2 || canceled != 1 {
+		t.Errorf("calls %v with %d canceled, want the stale build canceled and one rebuild", calls, canceled)
+	}
+}
+
+func TestInterruptStaleBuilds_Retry(t *testing.T) {
+	build := &slowBuild{started: make(chan struct{})}
+	w := New(&WatchConfig{
+		AppRootDir:           t.TempDir(),
+		FilesEventHandlers:   []FilesEventHandlers{build},
+		InterruptStaleBuilds: true,
+		Logger:               func(message ...any) {},
+	})
+	cssFile := filepath.Join(w.AppRootDir, "style.css")
+	os.WriteFile(cssFile, []byte("a {}"), 0644)
+
+	done := make(chan struct{})
+	go func() {
+		w.handleFileEvent("style.css", cssFile, "write", false)
+		close(done)
+	}()
+	<-build.started
+	w.interruptStale(cssFile)
+	<-done
+
+	// the newer event never reaches the handler: the interrupted call runs again
+	w.retrySuperseded(cssFile, time.Now())
+	if calls, canceled := build.result(); len(calls) != 2 || canceled != 1 {
+		t.Errorf("calls %v with %d canceled, want the interrupted call retried", calls, canceled)
+	}
+}
+
+func TestInterruptStaleBuilds_RetrySameType(t *testing.T) {
+	first, second := &slowBuild{started: make(chan struct{})}, &slowBuild{started: make(chan struct{})}
+	w := New(&WatchConfig{
+		AppRootDir:           t.TempDir(),
+		FilesEventHandlers:   []FilesEventHandlers{first, second},
+		InterruptStaleBuilds: true,
+		Logger:               func(message ...any) {},
+	})
+	cssFile := filepath.Join(w.AppRootDir, "style.css")
+	os.WriteFile(cssFile, []byte("a {}"), 0644)
+
+	done := make(chan struct{})
+	go func() {
+		w.handleFileEvent("style.css", cssFile, "write", false)
+		close(done)
+	}()
+	<-first.started
+	w.interruptStale(cssFile)
+	<-second.started
+	w.interruptStale(cssFile)
+	<-done
+
+	// unnamed handlers of one type keep their own pending retry
+	w.retrySuperseded(cssFile, time.Now())
+	for i, build := range []*slowBuild{first, second} {
+		if calls, canceled := build.result(); len(calls) != 2 || canceled != 1 {
+			t.Errorf("handler %d: calls %v with %d canceled, want the interrupted call retried", i+1, calls, canceled)
+		}
+	}
+}
+
+// sliceBuild is a value type slowBuild that can't key a map
+type sliceBuild struct {
+	exts  []string
+	build *slowBuild
+}
+
+func (s sliceBuild) NewFileChangeContext(ctx context.Context, change FileChange) error {
+	return s.build.NewFileChangeContext(ctx, change)
+}
+
+func (s sliceBuild) NewFileEvent(fileName, extension, filePath, event string) error { return nil }
+func (s sliceBuild) SupportedExtensions() []string                                  { return s.exts }
+
+func TestInterruptStaleBuilds_ValueHandler(t *testing.T) {
+	build := &slowBuild{started: make(chan struct{})}
+	w := New(&WatchConfig{
+		AppRootDir:           t.TempDir(),
+		FilesEventHandlers:   []FilesEventHandlers{sliceBuild{exts: []string{".css"}, build: build}},
+		InterruptStaleBuilds: true,
+		Logger:               func(message ...any) {},
+	})
+	cssFile := filepath.Join(w.AppRootDir, "style.css")
+	os.WriteFile(cssFile, []byte("a {}"), 0644)
+
+	done := make(chan struct{})
+	go func() {
+		w.handleFileEvent("style.css", cssFile, "write", false)
+		close(done)
+	}()
+	select {
+	case <-build.started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the handler never ran")
+	}
+	w.interruptStale(cssFile)
+	<-done
+
+	w.retrySuperseded(cssFile, time.Now())
+	if calls, canceled := build.result(); len(calls) != 2 || canceled != 1 {
+		t.Errorf("calls %v with %d canceled, want the interrupted call retried", calls, canceled)
+	}
+}
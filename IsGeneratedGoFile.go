@@ -0,0 +1,48 @@
+package devwatch
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// generatedCodeHeader is the standard marker described in https://go.dev/s/generatedcode
+var generatedCodeHeader = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// IsGeneratedGoFile reports whether the Go file at filePath carries the
+// standard "// Code generated ... DO NOT EDIT." header before its package clause.
+func IsGeneratedGoFile(filePath string) bool {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if generatedCodeHeader.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// skipGeneratedGo reports whether a Go file event must not reach Go handlers
+// because the file is generated and SkipGeneratedGo is enabled. Handlers
+// claiming the file through FilenameHandler or FileMatcher still receive it.
+func (h *DevWatch) skipGeneratedGo(filePath, event string) bool {
+	return h.SkipGeneratedGo && event != "remove" && IsGeneratedGoFile(filePath)
+}
+
+// generatedClaimed reports whether a handler claims the generated filePath explicitly
+func (h *DevWatch) generatedClaimed(filePath string) bool {
+	return slices.ContainsFunc(h.FilesEventHandlers, func(handler FilesEventHandlers) bool {
+		return handlerClaims(handler, filePath)
+	})
+}
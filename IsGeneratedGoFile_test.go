@@ -0,0 +1,77 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsGeneratedGoFile(t *testing.T) {
+	tempDir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"protoc", "// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: api.proto\n\npackage api\n", true},
+		{"after build tag", "//go:build wasm\n\n// Code generated by tinygo. DO NOT EDIT.\n\npackage main\n", true},
+		{"crlf", "// Code generated by stringer. DO NOT EDIT.\r\npackage main\r\n", true},
+		{"hand written", "// Package api implements the server.\npackage api\n", false},
+		{"marker after package", "package api\n\n// Code generated by hand. DO NOT EDIT.\n", false},
+		{"missing period", "// Code generated by tool DO NOT EDIT\npackage api\n", false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(tempDir, tt.name+".go")
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if got := IsGeneratedGoFile(path); got != tt.want {
+			t.Errorf("%s: IsGeneratedGoFile = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	w := New(&WatchConfig{Logger: func(message ...any) {}})
+	generated := filepath.Join(tempDir, "protoc.go")
+	if w.skipGeneratedGo(generated, "write") {
+		t.Error("generated files are dispatched unless SkipGeneratedGo is set")
+	}
+	w.SkipGeneratedGo = true
+	if !w.skipGeneratedGo(generated, "write") {
+		t.Error("expected generated file to be skipped with SkipGeneratedGo")
+	}
+}
+
+// pbHandler claims generated protobuf Go files by matcher
+type pbHandler struct{ calls int }
+
+func (p *pbHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	p.calls++
+	return nil
+}
+func (p *pbHandler) SupportedExtensions() []string  { return []string{".proto"} }
+func (p *pbHandler) MatchFile(filePath string) bool { return strings.HasSuffix(filePath, ".pb.go") }
+
+func TestSkipGeneratedGo_Claimed(t *testing.T) {
+	root := t.TempDir()
+	generated := filepath.Join(root, "api.pb.go")
+	if err := os.WriteFile(generated, []byte("// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	goHandler, pb := &allGoHandler{}, &pbHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{goHandler, pb},
+		SkipGeneratedGo:    true,
+		Logger:             func(message ...any) {},
+	})
+	if err := w.Trigger(generated, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if pb.calls != 1 {
+		t.Errorf("expected the claiming handler to receive the generated file, got %d calls", pb.calls)
+	}
+	if got := goHandler.calls.Load(); got != 0 {
+		t.Errorf("expected Go handlers to skip the generated file, got %d calls", got)
+	}
+}
@@ -0,0 +1,181 @@
+package devwatch
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+const (
+	defaultJournalMaxBytes = 10 << 20
+	defaultJournalKeep     = 3
+)
+
+// eventJournal appends every published EventRecord to JournalPath
+type eventJournal struct {
+	mu       sync.Mutex
+	once     sync.Once
+	path     string // absolute JournalPath
+	file     *os.File
+	size     int64
+	writeErr bool // last write failed; logged once until it succeeds
+}
+
+// journal appends rec as a JSON line to JournalPath, rotating the file once
+// it exceeds JournalMaxBytes
+func (h *DevWatch) journal(rec *EventRecord) {
+	if h.JournalPath == "" {
+		return
+	}
+	h.eventLog.once.Do(func() {
+		path := h.JournalPath
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(h.AppRootDir, path)
+		}
+		h.eventLog.path = path
+		// never observe our own output, rotated files included
+		h.RegisterInternalPath(path)
+		for i := 1; i <= h.journalKeep(); i++ {
+			h.RegisterInternalPath(journalFile(path, i))
+		}
+	})
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	j := &h.eventLog
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file != nil && j.size+int64(len(line)) > h.journalMaxBytes() && j.size > 0 {
+		err = h.rotateJournal()
+	}
+	if err == nil && j.file == nil {
+		err = j.open()
+	}
+	if err == nil {
+		var n int
+		n, err = j.file.Write(line)
+		j.size += int64(n)
+	}
+	if err != nil {
+		if !j.writeErr {
+			h.say("journal-error", err)
+		}
+		j.writeErr = true
+		return
+	}
+	j.writeErr = false
+}
+
+// open opens the journal for appending. Callers must hold mu.
+func (j *eventJournal) open() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	j.file, j.size = f, info.Size()
+	// terminate a line cut short by a crash so the next record stays readable
+	last := make([]byte, 1)
+	if j.size > 0 {
+		if _, err := f.ReadAt(last, j.size-1); err == nil && last[0] != '\n' {
+			n, _ := f.Write([]byte{'\n'})
+			j.size += int64(n)
+		}
+	}
+	return nil
+}
+
+// rotateJournal shifts path.1 to path.2 ... dropping the oldest beyond
+// JournalKeep, and moves the current file to path.1. Callers must hold mu.
+func (h *DevWatch) rotateJournal() error {
+	j := &h.eventLog
+	j.file.Close()
+	j.file, j.size = nil, 0
+	keep := h.journalKeep()
+	os.Remove(journalFile(j.path, keep))
+	for i := keep - 1; i >= 1; i-- {
+		os.Rename(journalFile(j.path, i), journalFile(j.path, i+1))
+	}
+	return os.Rename(j.path, journalFile(j.path, 1))
+}
+
+// closeJournal closes the journal file when the watcher stops
+func (h *DevWatch) closeJournal() {
+	h.eventLog.mu.Lock()
+	defer h.eventLog.mu.Unlock()
+	if h.eventLog.file != nil {
+		h.eventLog.file.Close()
+		h.eventLog.file = nil
+	}
+}
+
+func (h *DevWatch) journalMaxBytes() int64 {
+	if h.JournalMaxBytes > 0 {
+		return h.JournalMaxBytes
+	}
+	return defaultJournalMaxBytes
+}
+
+func (h *DevWatch) journalKeep() int {
+	if h.JournalKeep > 0 {
+		return h.JournalKeep
+	}
+	return defaultJournalKeep
+}
+
+// journalFile returns the name of the i-th rotated journal eg: "events.log.1"
+func journalFile(path string, i int) string {
+	return path + "." + strconv.Itoa(i)
+}
+
+// ReadJournal returns the records written to the journal at path (see
+// WatchConfig.JournalPath), oldest first, rotated files included. Lines cut
+// short by a crash are skipped.
+func ReadJournal(path string) ([]EventRecord, error) {
+	files := []string{path}
+	for i := 1; ; i++ {
+		if _, err := os.Stat(journalFile(path, i)); err != nil {
+			break
+		}
+		files = append([]string{journalFile(path, i)}, files...)
+	}
+
+	var records []EventRecord
+	for _, name := range files {
+		f, err := os.Open(name)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return records, err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			var rec EventRecord
+			if json.Unmarshal(scanner.Bytes(), &rec) == nil {
+				records = append(records, rec)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return records, err
+		}
+	}
+	return records, nil
+}
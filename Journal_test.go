@@ -0,0 +1,79 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	root := t.TempDir()
+	css := filepath.Join(root, "style.css")
+	if err := os.WriteFile(css, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var called int32
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".css"},
+		}},
+		JournalPath:     ".devwatch/events.log",
+		JournalMaxBytes: 600, // a couple of records per file
+		JournalKeep:     2,
+		Logger:          func(message ...any) {},
+	})
+
+	for i := 0; i < 10; i++ {
+		if err := w.Trigger("style.css", "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.closeJournal()
+
+	path := filepath.Join(root, ".devwatch", "events.log")
+	if !w.Contain(path) || !w.Contain(path+".1") {
+		t.Error("journal files must not be observed")
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("expected rotation to keep only JournalKeep old files")
+	}
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		if info, err := os.Stat(name); err != nil || info.Size() > 600 {
+			t.Errorf("%s: expected a rotated journal file under JournalMaxBytes, got %v", name, err)
+		}
+	}
+
+	records, err := ReadJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) == 0 || len(records) >= 10 {
+		t.Fatalf("expected the newest records only, got %d", len(records))
+	}
+	for i, rec := range records {
+		if rec.Path != css || rec.Event != "write" || len(rec.Handlers) != 1 {
+			t.Errorf("unexpected record %+v", rec)
+		}
+		if i > 0 && rec.ID != records[i-1].ID+1 {
+			t.Errorf("records out of order: %d after %d", rec.ID, records[i-1].ID)
+		}
+	}
+	if records[len(records)-1].ID != 10 {
+		t.Errorf("expected the last record to be the newest, got %d", records[len(records)-1].ID)
+	}
+
+	// a line cut short by a crash is skipped, the journal keeps appending
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	f.WriteString(`{"id":11,"pa`)
+	f.Close()
+	if err := w.Trigger("style.css", "write"); err != nil {
+		t.Fatal(err)
+	}
+	w.closeJournal()
+	after, err := ReadJournal(path)
+	if err != nil || len(after) != len(records)+1 || after[len(after)-1].ID != 11 {
+		t.Errorf("expected the partial line to be skipped and the next record kept, got %d records, %v", len(after), err)
+	}
+}
@@ -0,0 +1,128 @@
+package devwatch
+
+import (
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultLintDebounce is used when Linter.Debounce is not set: longer than
+// the reload debounce so linting never competes with the build
+const defaultLintDebounce = time.Second
+
+// Linter is a Go handler running "go vet" (or golangci-lint) on the
+// packages changed during its own debounce window. It is a BackgroundHandler
+// in the "lint" pipeline stage: findings are warnings that never block nor
+// trigger the browser reload.
+//
+//	watcher.AddFilesEventHandlers(&devwatch.Linter{
+//		Dir:           appRoot,
+//		Command:       []string{"golangci-lint", "run"},
+//		After:         []string{"compile"},
+//		OnDiagnostics: func(d []devwatch.Diagnostic) { ... },
+//	})
+type Linter struct {
+	Command  []string      // default: []string{"go", "vet"}; the changed packages are appended eg: "./internal/api"
+	Dir      string        // module root, usually AppRootDir
+	Debounce time.Duration // default: 1s
+	After    []string      // stages the lint stage depends on eg: []string{"compile"}
+	Output   io.Writer     // also receives the linter output
+	// OnDiagnostics receives the findings of each run (empty once clean)
+	OnDiagnostics func([]Diagnostic)
+
+	running     sync.Mutex // one lint run at a time
+	mu          sync.Mutex
+	timer       *time.Timer
+	pending     map[string]bool // package arguments waiting for the next run
+	diagnostics []Diagnostic
+}
+
+func (l *Linter) SupportedExtensions() []string { return []string{".go"} }
+
+// OwnsAllGoFiles implements AllGoFilesHandler
+func (l *Linter) OwnsAllGoFiles() bool { return true }
+
+// Background implements BackgroundHandler: lint warnings never block reloads
+func (l *Linter) Background() bool { return true }
+
+// Stage implements StagedHandler
+func (l *Linter) Stage() string { return "lint" }
+
+// DependsOn implements StagedHandler
+func (l *Linter) DependsOn() []string { return l.After }
+
+// Name implements NamedHandler
+func (l *Linter) Name() string { return "lint" }
+
+// NewFileEvent queues the package of filePath for the next debounced run
+func (l *Linter) NewFileEvent(fileName, extension, filePath, event string) error {
+	rel, err := filepath.Rel(l.Dir, filepath.Dir(filePath))
+	if err != nil || !hasGoFiles(filepath.Dir(filePath)) {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pending == nil {
+		l.pending = make(map[string]bool)
+	}
+	l.pending["./"+filepath.ToSlash(rel)] = true
+
+	debounce := l.Debounce
+	if debounce <= 0 {
+		debounce = defaultLintDebounce
+	}
+	if l.timer == nil {
+		l.timer = time.AfterFunc(debounce, l.run)
+	} else {
+		l.timer.Reset(debounce)
+	}
+	return nil
+}
+
+// Diagnostics returns the findings of the last run
+func (l *Linter) Diagnostics() []Diagnostic {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.diagnostics
+}
+
+// run lints the pending packages
+func (l *Linter) run() {
+	l.running.Lock()
+	defer l.running.Unlock()
+
+	l.mu.Lock()
+	pkgs := make([]string, 0, len(l.pending))
+	for pkg := range l.pending {
+		pkgs = append(pkgs, pkg)
+	}
+	l.pending = nil
+	l.mu.Unlock()
+	if len(pkgs) == 0 {
+		return
+	}
+	sort.Strings(pkgs)
+
+	command := l.Command
+	if len(command) == 0 {
+		command = []string{"go", "vet"}
+	}
+	cmd := exec.Command(command[0], append(command[1:], pkgs...)...)
+	cmd.Dir = l.Dir
+	output, _ := runCommand(cmd, l.Name(), l.Output)
+
+	diags := ParseDiagnostics(output)
+	for i := range diags {
+		diags[i].Severity = "warning"
+	}
+	l.mu.Lock()
+	l.diagnostics = diags
+	l.mu.Unlock()
+	if l.OnDiagnostics != nil {
+		l.OnDiagnostics(diags)
+	}
+}
@@ -0,0 +1,68 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLinter(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"api", "db"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, dir+".go"), []byte("package "+dir+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs := make(chan []Diagnostic, 4)
+	linter := &Linter{
+		// prints one finding per package argument
+		Command:       []string{"sh", "-c", `for p in "$@"; do echo "$p/x.go:1:2: unused"; done; exit 1`, "lint"},
+		Dir:           root,
+		Debounce:      20 * time.Millisecond,
+		OnDiagnostics: func(d []Diagnostic) { runs <- d },
+	}
+
+	var reloads atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{linter},
+		BrowserReload:      func() error { reloads.Add(1); return nil },
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+	for _, file := range []string{"api/api.go", "db/db.go", "api/api.go"} {
+		if err := w.Trigger(filepath.Join(root, file), "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	select {
+	case diags := <-runs:
+		want := []Diagnostic{
+			{File: "./api/x.go", Line: 1, Col: 2, Severity: "warning", Message: "unused"},
+			{File: "./db/x.go", Line: 1, Col: 2, Severity: "warning", Message: "unused"},
+		}
+		if !reflect.DeepEqual(diags, want) {
+			t.Errorf("got %+v, want %+v", diags, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("linter did not run")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if len(runs) != 0 {
+		t.Error("expected a single debounced run")
+	}
+	if reloads.Load() != 0 {
+		t.Errorf("lint must not trigger reloads, got %d", reloads.Load())
+	}
+	if len(linter.Diagnostics()) != 2 {
+		t.Errorf("expected last diagnostics to be kept, got %+v", linter.Diagnostics())
+	}
+}
@@ -0,0 +1,358 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// LiveReload is a built-in browser reload server. Pages load its script,
+// which keeps an EventSource open and reloads when Reload is called:
+//
+//	lr := &devwatch.LiveReload{}
+//	mux.Handle(devwatch.LiveReloadPrefix, lr)
+//	cfg.BrowserReload = lr.Reload
+//	// page: <script src="/devwatch/livereload.js"></script>
+//
+// Hidden tabs (Page Visibility API) don't reload: they get exactly one
+// reload when they become visible again. With Route and
+// WatchConfig.BrowserReloadPaths = lr.ReloadPaths only the pages showing
+// affected routes reload.
+//
+// With InjectCSS, a change of stylesheets only (eg: reported by Tailwind
+// through ReloadPaths) swaps the matching <link rel="stylesheet"> of the
+// pages instead of reloading them; InjectImages does the same for <img>
+// sources (eg: written by ImageOptimizer). Pages showing none of the
+// changed files reload.
+//
+// With HotModules, a change of JavaScript outputs (and their ".js.map"
+// source maps) sends the pages a "modules" event listing each output with
+// its source map instead of reloading them. Module scripts marked
+// <script type="module" data-devwatch-hot> are imported again with a
+// cache-busting query and a "devwatch:module" event is dispatched on window
+// with the new module, for the page to swap its state; pages loading none
+// of the changed modules as hot reload.
+//
+// With Focus set (StaticServer sets its watcher), GET LiveReloadPrefix+"focus"
+// reports focus mode as {"focus":true} and POST ...+"focus?on=1" (or on=0)
+// turns it on or off, eg: from a devtools snippet or an editor task.
+type LiveReload struct {
+	InjectCSS    bool
+	InjectImages bool
+	HotModules   bool
+	Focus        FocusController
+
+	mu      sync.Mutex
+	clients map[string]*liveClient
+	routes  []liveRoute
+}
+
+// FocusController is the focus mode LiveReload exposes, eg: a *DevWatch
+type FocusController interface {
+	SetFocusMode(on bool)
+	FocusMode() bool
+}
+
+// imageExtensions are the images InjectImages swaps
+var imageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".avif"}
+
+// HotModule is a changed JavaScript output in a LiveReload "modules" event
+type HotModule struct {
+	Path      string `json:"path"`                // relative to AppRootDir eg: "web/public/main.js"
+	SourceMap string `json:"sourceMap,omitempty"` // its source map when it changed too eg: "web/public/main.js.map"
+}
+
+// LiveReloadPrefix is the path LiveReload must be mounted on
+const LiveReloadPrefix = "/devwatch/"
+
+// LiveReloadScript is the script tag pages include to connect
+const LiveReloadScript = `<script src="` + LiveReloadPrefix + `livereload.js"></script>`
+
+// liveClient is a connected page
+type liveClient struct {
+	page    string        // location.pathname eg: "/admin/users"
+	send    chan struct{} // a reload is due
+	visible bool
+	pending bool     // a reload arrived while hidden
+	full    bool     // the due reload reloads the page
+	swap    []string // stylesheets, images and modules to swap when the due reload is not full
+}
+
+// liveRoute maps files under scope to the page routes they affect
+type liveRoute struct {
+	scope  string
+	routes []string
+}
+
+// liveReloadJS connects to the events stream and reports visibility changes
+const liveReloadJS = `(function () {
+  var id = Math.random().toString(36).slice(2);
+  var state = function () {
+    return "id=" + id + "&visible=" + (document.hidden ? 0 : 1) + "&page=" + encodeURIComponent(location.pathname);
+  };
+  var es = new EventSource("` + LiveReloadPrefix + `events?" + state());
+  es.addEventListener("reload", function () { es.close(); location.reload(); });
+  es.addEventListener("swap", function (e) {
+    var files = JSON.parse(e.data), swapped = false;
+    var swap = function (el, attr) {
+      var url = (el[attr] || "").split("?")[0];
+      if (url && files.some(function (f) { return url.endsWith("/" + f.split("/").pop()); })) {
+        el[attr] = url + "?devwatch=" + Date.now();
+        swapped = true;
+      }
+    };
+    document.querySelectorAll('link[rel="stylesheet"]').forEach(function (link) { swap(link, "href"); });
+    document.querySelectorAll("img").forEach(function (img) { swap(img, "src"); });
+    if (!swapped) { es.close(); location.reload(); }
+  });
+  es.addEventListener("modules", function (e) {
+    var modules = JSON.parse(e.data), stamp = Date.now();
+    var hot = Array.prototype.slice.call(document.querySelectorAll('script[type="module"][data-devwatch-hot]'));
+    Promise.all(modules.map(function (m) {
+      var name = "/" + m.path.split("/").pop();
+      var script = hot.find(function (s) { return s.src.split("?")[0].endsWith(name); });
+      if (!script) { return Promise.reject(new Error("not hot: " + m.path)); }
+      var url = script.src.split("?")[0] + "?devwatch=" + stamp;
+      return import(url).then(function (module) {
+        window.dispatchEvent(new CustomEvent("devwatch:module", {
+          detail: { path: m.path, sourceMap: m.sourceMap, url: url, module: module }
+        }));
+      });
+    })).catch(function () { es.close(); location.reload(); });
+  });
+  document.addEventListener("visibilitychange", function () {
+    fetch("` + LiveReloadPrefix + `visibility?" + state(), { method: "POST", keepalive: true });
+  });
+})();
+`
+
+// ServeHTTP serves the script, the events stream and visibility reports
+func (lr *LiveReload) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch strings.TrimPrefix(r.URL.Path, LiveReloadPrefix) {
+	case "livereload.js":
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		fmt.Fprint(w, liveReloadJS)
+	case "events":
+		lr.serveEvents(w, r)
+	case "visibility":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		lr.setVisible(r.URL.Query().Get("id"), r.URL.Query().Get("visible") != "0")
+		w.WriteHeader(http.StatusNoContent)
+	case "focus":
+		lr.serveFocus(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveFocus reports or sets the focus mode of Focus
+func (lr *LiveReload) serveFocus(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case lr.Focus == nil:
+		http.NotFound(w, r)
+		return
+	case r.Method == http.MethodPost:
+		if !originAllowed(r, nil) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		lr.Focus.SetFocusMode(r.URL.Query().Get("on") != "0")
+	case r.Method != http.MethodGet:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"focus":%t}`, lr.Focus.FocusMode())
+}
+
+// serveEvents streams "reload", "swap" and "modules" events to a page until it disconnects
+func (lr *LiveReload) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	id := r.URL.Query().Get("id")
+	if !ok || id == "" {
+		http.Error(w, "streaming unsupported or missing id", http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	client := &liveClient{page: query.Get("page"), send: make(chan struct{}, 1), visible: query.Get("visible") != "0"}
+
+	lr.mu.Lock()
+	if lr.clients == nil {
+		lr.clients = make(map[string]*liveClient)
+	}
+	lr.clients[id] = client
+	lr.mu.Unlock()
+	defer func() {
+		lr.mu.Lock()
+		if lr.clients[id] == client {
+			delete(lr.clients, id)
+		}
+		lr.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client.send:
+			lr.mu.Lock()
+			full, swap := client.full || len(client.swap) == 0, client.swap
+			client.full, client.swap = false, nil
+			lr.mu.Unlock()
+			if full {
+				fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			} else {
+				assets, modules := splitSwap(swap)
+				if len(assets) > 0 {
+					data, _ := json.Marshal(assets)
+					fmt.Fprintf(w, "event: swap\ndata: %s\n\n", data)
+				}
+				if len(modules) > 0 {
+					data, _ := json.Marshal(modules)
+					fmt.Fprintf(w, "event: modules\ndata: %s\n\n", data)
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// setVisible records a visibility change; a tab becoming visible gets the reload it missed
+func (lr *LiveReload) setVisible(id string, visible bool) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	client, ok := lr.clients[id]
+	if !ok {
+		return
+	}
+	client.visible = visible
+	if visible && client.pending {
+		client.pending = false
+		client.full = true
+		client.notify()
+	}
+}
+
+// Route limits the reloads caused by files under scope (relative to
+// AppRootDir, eg: "web/admin") to pages at or below routes (eg: "/admin").
+// Files outside every routed scope reload all pages.
+func (lr *LiveReload) Route(scope string, routes ...string) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	lr.routes = append(lr.routes, liveRoute{scope: scope, routes: routes})
+}
+
+// Reload tells every visible page to reload; hidden pages reload once when shown.
+// Use it as WatchConfig.BrowserReload.
+func (lr *LiveReload) Reload() error {
+	return lr.ReloadPaths(nil)
+}
+
+// ReloadPaths reloads the pages affected by the changed paths according to
+// Route (all pages when paths is empty). Use it as WatchConfig.BrowserReloadPaths.
+func (lr *LiveReload) ReloadPaths(paths []string) error {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	swappable := len(paths) > 0 && !slices.ContainsFunc(paths, func(p string) bool {
+		ext := strings.ToLower(path.Ext(p))
+		return !(lr.InjectCSS && ext == ".css" || lr.InjectImages && slices.Contains(imageExtensions, ext) ||
+			lr.HotModules && (ext == ".js" || strings.HasSuffix(strings.ToLower(p), ".js.map")))
+	})
+	for _, client := range lr.clients {
+		if !lr.affects(client.page, paths) {
+			continue
+		}
+		if client.visible {
+			if swappable {
+				for _, p := range paths {
+					if !slices.Contains(client.swap, p) {
+						client.swap = append(client.swap, p)
+					}
+				}
+			} else {
+				client.full = true
+			}
+			client.notify()
+		} else {
+			client.pending = true
+		}
+	}
+	return nil
+}
+
+// splitSwap separates the stylesheets and images of a swap from its
+// modules, each with its source map when the map changed too. A source map
+// changing alone is neither.
+func splitSwap(swap []string) (assets []string, modules []HotModule) {
+	for _, p := range swap {
+		switch lower := strings.ToLower(p); {
+		case strings.HasSuffix(lower, ".js.map"):
+		case path.Ext(lower) == ".js":
+			module := HotModule{Path: p}
+			if slices.Contains(swap, p+".map") {
+				module.SourceMap = p + ".map"
+			}
+			modules = append(modules, module)
+		default:
+			assets = append(assets, p)
+		}
+	}
+	return assets, modules
+}
+
+// affects reports whether a change of paths reloads page. Callers must hold lr.mu.
+func (lr *LiveReload) affects(page string, paths []string) bool {
+	if len(paths) == 0 {
+		return true
+	}
+	for _, path := range paths {
+		routed := false
+		for _, route := range lr.routes {
+			if !pathInScopes(path, []string{route.scope}) {
+				continue
+			}
+			routed = true
+			if slices.ContainsFunc(route.routes, func(r string) bool { return pageInRoute(page, r) }) {
+				return true
+			}
+		}
+		if !routed {
+			return true
+		}
+	}
+	return false
+}
+
+// pageInRoute reports whether page is route or below it eg: "/admin/users" in "/admin"
+func pageInRoute(page, route string) bool {
+	route = strings.TrimSuffix(route, "/")
+	return route == "" || page == route || strings.HasPrefix(page, route+"/")
+}
+
+// Clients returns the number of connected pages
+func (lr *LiveReload) Clients() int {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	return len(lr.clients)
+}
+
+// notify queues a reload; a reload already queued covers this one
+func (c *liveClient) notify() {
+	select {
+	case c.send <- struct{}{}:
+	default:
+	}
+}
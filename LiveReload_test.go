@@ -0,0 +1,188 @@
+package devwatch
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// liveReloadClient connects to srv as page id and returns the received event names
+func liveReloadClient(t *testing.T, srv *httptest.Server, query string) <-chan string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+LiveReloadPrefix+"events?"+query, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := make(chan string, 8)
+	go func() {
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if name, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				events <- name
+			}
+		}
+	}()
+	return events
+}
+
+func waitClients(t *testing.T, lr *LiveReload, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for lr.Clients() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d clients, got %d", n, lr.Clients())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// receivedEvents returns the events received within wait
+func receivedEvents(events <-chan string, wait time.Duration) []string {
+	var got []string
+	timeout := time.After(wait)
+	for {
+		select {
+		case e := <-events:
+			got = append(got, e)
+		case <-timeout:
+			return got
+		}
+	}
+}
+
+func TestLiveReload(t *testing.T) {
+	lr := &LiveReload{}
+	srv := httptest.NewServer(lr)
+	t.Cleanup(srv.Close)
+
+	visible := liveReloadClient(t, srv, "id=a&visible=1")
+	hidden := liveReloadClient(t, srv, "id=b&visible=0")
+	waitClients(t, lr, 2)
+
+	lr.Reload()
+	lr.Reload()
+	if got := receivedEvents(visible, 100*time.Millisecond); len(got) == 0 || got[0] != "reload" {
+		t.Errorf("expected visible tab to reload, got %v", got)
+	}
+	if got := receivedEvents(hidden, 50*time.Millisecond); len(got) != 0 {
+		t.Fatalf("expected hidden tab not to reload, got %v", got)
+	}
+
+	resp, err := http.Post(srv.URL+LiveReloadPrefix+"visibility?id=b&visible=1", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := receivedEvents(hidden, 100*time.Millisecond); len(got) != 1 {
+		t.Errorf("expected exactly one reload when the tab became visible, got %v", got)
+	}
+}
+
+func TestLiveReload_Script(t *testing.T) {
+	srv := httptest.NewServer(&LiveReload{})
+	t.Cleanup(srv.Close)
+	resp, err := http.Get(srv.URL + LiveReloadPrefix + "livereload.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "visibilitychange") || !strings.Contains(LiveReloadScript, "livereload.js") {
+		t.Errorf("unexpected script: %s", body)
+	}
+}
+
+func TestLiveReload_Routes(t *testing.T) {
+	lr := &LiveReload{}
+	lr.Route("web/admin", "/admin")
+	srv := httptest.NewServer(lr)
+	t.Cleanup(srv.Close)
+
+	admin := liveReloadClient(t, srv, "id=a&page=%2Fadmin%2Fusers")
+	home := liveReloadClient(t, srv, "id=h&page=%2F")
+	waitClients(t, lr, 2)
+
+	lr.ReloadPaths([]string{"web/admin/admin.css"})
+	if got := receivedEvents(admin, 100*time.Millisecond); len(got) != 1 {
+		t.Errorf("expected admin page to reload, got %v", got)
+	}
+	if got := receivedEvents(home, 50*time.Millisecond); len(got) != 0 {
+		t.Errorf("expected home page not to reload for admin assets, got %v", got)
+	}
+
+	lr.ReloadPaths([]string{"web/admin/admin.css", "web/style.css"}) // unrouted file: every page
+	if got := receivedEvents(home, 100*time.Millisecond); len(got) != 1 {
+		t.Errorf("expected unrouted change to reload every page, got %v", got)
+	}
+}
+
+func TestLiveReload_InjectCSS(t *testing.T) {
+	lr := &LiveReload{InjectCSS: true}
+	srv := httptest.NewServer(lr)
+	t.Cleanup(srv.Close)
+	page := liveReloadClient(t, srv, "id=a&page=%2F")
+	waitClients(t, lr, 1)
+
+	lr.ReloadPaths([]string{"web/public/style.css"})
+	if got := receivedEvents(page, 100*time.Millisecond); !slices.Equal(got, []string{"swap"}) {
+		t.Errorf("stylesheet change: got %v, want a css swap", got)
+	}
+	lr.ReloadPaths([]string{"web/public/style.css", "web/index.html"})
+	if got := receivedEvents(page, 100*time.Millisecond); !slices.Equal(got, []string{"reload"}) {
+		t.Errorf("mixed change: got %v, want a reload", got)
+	}
+}
+
+func TestLiveReload_HotModules(t *testing.T) {
+	lr := &LiveReload{InjectCSS: true, HotModules: true}
+	srv := httptest.NewServer(lr)
+	t.Cleanup(srv.Close)
+	page := liveReloadClient(t, srv, "id=a&page=%2F")
+	waitClients(t, lr, 1)
+
+	lr.ReloadPaths([]string{"web/public/main.js", "web/public/main.js.map"})
+	if got := receivedEvents(page, 100*time.Millisecond); !slices.Equal(got, []string{"modules"}) {
+		t.Errorf("module change: got %v, want a modules event", got)
+	}
+	lr.ReloadPaths([]string{"web/public/style.css", "web/public/main.js"})
+	if got := receivedEvents(page, 100*time.Millisecond); !slices.Equal(got, []string{"swap", "modules"}) {
+		t.Errorf("stylesheet and module change: got %v, want a swap then a modules event", got)
+	}
+	lr.ReloadPaths([]string{"web/public/main.js.map"})
+	if got := receivedEvents(page, 100*time.Millisecond); len(got) != 0 {
+		t.Errorf("source map change alone: got %v, want no event", got)
+	}
+	lr.ReloadPaths([]string{"web/public/main.js", "web/index.html"})
+	if got := receivedEvents(page, 100*time.Millisecond); !slices.Equal(got, []string{"reload"}) {
+		t.Errorf("mixed change: got %v, want a reload", got)
+	}
+
+	assets, modules := splitSwap([]string{"web/public/style.css", "web/public/main.js.map", "web/public/main.js", "web/public/worker.js"})
+	want := []HotModule{{Path: "web/public/main.js", SourceMap: "web/public/main.js.map"}, {Path: "web/public/worker.js"}}
+	if !slices.Equal(assets, []string{"web/public/style.css"}) || !slices.Equal(modules, want) {
+		t.Errorf("splitSwap: got %v %v, want [web/public/style.css] %v", assets, modules, want)
+	}
+}
+
+func TestLiveReload_HotModulesOff(t *testing.T) {
+	lr := &LiveReload{InjectCSS: true}
+	srv := httptest.NewServer(lr)
+	t.Cleanup(srv.Close)
+	page := liveReloadClient(t, srv, "id=a&page=%2F")
+	waitClients(t, lr, 1)
+
+	lr.ReloadPaths([]string{"web/public/main.js"})
+	if got := receivedEvents(page, 100*time.Millisecond); !slices.Equal(got, []string{"reload"}) {
+		t.Errorf("module change without HotModules: got %v, want a reload", got)
+	}
+}
@@ -0,0 +1,155 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LogLine is one line written to Logger when LogFormat is "json" (NDJSON),
+// for godev and log processors parsing devwatch output
+type LogLine struct {
+	TS      time.Time `json:"ts"`
+	Level   string    `json:"level"`  // debug, info, warn or error
+	Module  string    `json:"module"` // always "devwatch"
+	Event   string    `json:"event"`  // message ID (see DefaultMessages), "handler-run" or "file-event"
+	Path    string    `json:"path,omitempty"`
+	Handler string    `json:"handler,omitempty"`
+	Dur     float64   `json:"dur,omitempty"` // milliseconds
+	Err     string    `json:"err,omitempty"`
+	Msg     string    `json:"msg,omitempty"` // the text message, see Messages
+}
+
+// messageFields names the LogLine field each argument of a message fills,
+// "" for arguments only found in Msg
+var messageFields = map[string][]string{
+	"async-running":          {"dur"},
+	"async-timeout":          {"path"},
+	"build-growth":           {"path"},
+	"cleanup-error":          {"err"},
+	"cleanup-handler-error":  {"handler", "err"},
+	"comment-only":           {"path"},
+	"config-error":           {"err"},
+	"daemon-invalid-request": {"err"},
+	"deps-reset":             {"path"},
+	"detect-handler":         {"handler"},
+	"detect-none":            {"path"},
+	"dir-moved":              {"", "path"},
+	"errors-closed":          {"err"},
+	"event-chain":            {"err", "path"},
+	"folder-event-error":     {"err"},
+	"format-error":           {"err"},
+	"formatted":              {"path"},
+	"generated-skipped":      {"path"},
+	"ignore-suggestion":      {"path"},
+	"instance-locked":        {"err"},
+	"journal-error":          {"err"},
+	"main-found":             {"path"},
+	"main-waiting":           {"path"},
+	"manifest-error":         {"err"},
+	"new-watcher-error":      {"err"},
+	"open-browser-error":     {"", "err"},
+	"overload":               {"", "path"},
+	"ownership-report-error": {"err"},
+	"path-added":             {"path"},
+	"path-error":             {"path", "err"},
+	"path-polled":            {"path"},
+	"queue-full":             {"", "path"},
+	"ready-failed":           {"err"},
+	"rebuild-loop":           {"err", "handler", "path"},
+	"registration-error":     {"err"},
+	"registration-root":      {"path"},
+	"reload-transport-error": {"", "err"},
+	"rescan-error":           {"path", "err"},
+	"restarted":              {"path"},
+	"sensitive-file":         {"path"},
+	"shutdown-reload":        {"err"},
+	"sighup":                 {"path"},
+	"sighup-error":           {"err"},
+	"skip-event":             {"path", "err"},
+	"slow-handler":           {"handler", "dur", "path"},
+	"stage-deferred":         {"handler"},
+	"static-server":          {"path"},
+	"static-server-error":    {"err"},
+	"task-error":             {"", "err"},
+	"verify-failed":          {"err"},
+	"walk-error":             {"err"},
+	"walk-new-dir-error":     {"path", "err"},
+	"wasm-stale":             {"path"},
+	"watch-dir-error":        {"err"},
+	"watch-dir-failed":       {"path", "err"},
+	"watcher-error":          {"err"},
+}
+
+// warnMessages are logged with level "warn"
+var warnMessages = map[string]bool{
+	"async-running": true, "async-timeout": true, "build-growth": true, "ignore-suggestion": true,
+	"overflow": true, "overload": true, "queue-full": true, "reload-transport-error": true,
+	"sensitive-file": true, "slow-handler": true, "stage-cycle": true, "stage-deferred": true,
+	"wasm-stale": true,
+}
+
+// logJSON writes the message id as a LogLine
+func (h *DevWatch) logJSON(id string, args ...any) {
+	line := LogLine{TS: time.Now(), Level: "info", Module: "devwatch", Event: id, Msg: h.message(id, args...)}
+	switch {
+	case id == "dependency-miss":
+		line.Level = "debug"
+	case warnMessages[id]:
+		line.Level = "warn"
+	}
+	for i, field := range messageFields[id] {
+		if i >= len(args) || args[i] == nil {
+			continue
+		}
+		switch field {
+		case "path":
+			line.Path = fmt.Sprint(args[i])
+		case "handler":
+			line.Handler = fmt.Sprint(args[i])
+		case "dur":
+			if d, ok := args[i].(time.Duration); ok {
+				line.Dur = durationMillis(d)
+			}
+		case "err":
+			line.Err = fmt.Sprint(args[i])
+			line.Level = "error"
+		}
+	}
+	h.writeLogLine(line)
+}
+
+// logRecord writes the handler runs and the outcome of a processed event
+// as LogLines, in json LogFormat only
+func (h *DevWatch) logRecord(rec *EventRecord) {
+	if h.LogFormat != "json" {
+		return
+	}
+	for _, result := range rec.Handlers {
+		line := LogLine{TS: rec.Time, Level: "info", Module: "devwatch", Event: "handler-run",
+			Path: rec.Path, Handler: result.Handler, Dur: durationMillis(result.Duration), Err: result.Error}
+		if result.Error != "" {
+			line.Level = "error"
+		}
+		h.writeLogLine(line)
+	}
+	msg := rec.Event
+	if rec.Skipped != "" {
+		msg += " skipped: " + rec.Skipped
+	} else if rec.Reload {
+		msg += ", reload"
+	}
+	h.writeLogLine(LogLine{TS: rec.Time, Level: "info", Module: "devwatch", Event: "file-event", Path: rec.Path, Msg: msg})
+}
+
+func (h *DevWatch) writeLogLine(line LogLine) {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return
+	}
+	h.Logger(string(data))
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
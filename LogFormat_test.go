@@ -0,0 +1,61 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLogFormat_JSON(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var lines []LogLine
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&flakyHandler{fail: true}},
+		LogFormat:          "json",
+		Logger: func(message ...any) {
+			if len(message) != 1 {
+				t.Errorf("expected one NDJSON line per call, got %q", message)
+				return
+			}
+			var line LogLine
+			if err := json.Unmarshal([]byte(message[0].(string)), &line); err != nil {
+				t.Errorf("not a JSON line: %v %q", err, message[0])
+				return
+			}
+			mu.Lock()
+			lines = append(lines, line)
+			mu.Unlock()
+		},
+	})
+
+	w.say("slow-handler", "css", 1500*time.Microsecond, "style.css")
+	w.Trigger(cssFile, "write")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lines) != 3 {
+		t.Fatalf("lines = %+v, want the message, a handler run and the event", lines)
+	}
+	slow := lines[0]
+	if slow.Event != "slow-handler" || slow.Level != "warn" || slow.Module != "devwatch" ||
+		slow.Handler != "css" || slow.Dur != 1.5 || slow.Path != "style.css" || slow.Msg == "" || slow.TS.IsZero() {
+		t.Errorf("slow handler line = %+v", slow)
+	}
+	run := lines[1]
+	if run.Event != "handler-run" || run.Level != "error" || run.Handler != "flaky" || run.Err != "build failed" || run.Path != cssFile {
+		t.Errorf("handler run line = %+v", run)
+	}
+	if lines[2].Event != "file-event" || lines[2].Path != cssFile {
+		t.Errorf("event line = %+v", lines[2])
+	}
+}
@@ -0,0 +1,16 @@
+package devwatch
+
+// MainInputHandler is an optional capability for handlers built from a main
+// input file. Go handlers need it (or MultiMainHandler / AllGoFilesHandler)
+// to receive the .go files their main input depends on.
+type MainInputHandler interface {
+	MainInputFileRelativePath() string // eg: go => "app/server/main.go" | js =>"app/pwa/public/main.js"
+}
+
+// handlerMain returns the main input file declared by handler, "" when none
+func handlerMain(handler FilesEventHandlers) string {
+	if mh, ok := handler.(MainInputHandler); ok {
+		return mh.MainInputFileRelativePath()
+	}
+	return ""
+}
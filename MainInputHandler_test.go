@@ -0,0 +1,40 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// minimalHandler implements only the core FilesEventHandlers methods
+type minimalHandler struct{ events []string }
+
+func (m *minimalHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	m.events = append(m.events, fileName)
+	return nil
+}
+func (m *minimalHandler) SupportedExtensions() []string { return []string{".css"} }
+
+func TestMinimalHandler(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "app.css"), []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handler := &minimalHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		Logger:             func(message ...any) {},
+	})
+	w.loadUnobservedFiles()
+
+	if handlerMain(handler) != "" || handlerUnobserved(handler) != nil {
+		t.Error("expected no main input nor unobserved files")
+	}
+	if err := w.Trigger("app.css", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if len(handler.events) != 1 || handler.events[0] != "app.css" {
+		t.Errorf("expected minimal handler to receive the event, got %v", handler.events)
+	}
+}
@@ -0,0 +1,111 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// HandlerMatch tells whether a handler would receive an event for a path and why
+type HandlerMatch struct {
+	Handler string `json:"handler"`
+	Matched bool   `json:"matched"`
+	// Reason eg: "extension .css", "outside scope web", "not imported by cmd/app/main.go"
+	Reason string `json:"reason"`
+}
+
+// MatchHandlers explains which handlers would receive a write event for path
+// right now, in dispatch order, without calling them: the ignore rules, the
+// active profile, extensions, scopes and Go dependency ownership are checked
+// like a real event. Handlers that don't match carry the first rule that
+// excluded them. Relative paths are resolved against AppRootDir.
+func (h *DevWatch) MatchHandlers(path string) []HandlerMatch {
+	if path != "" && !filepath.IsAbs(path) && h.AppRootDir != "" {
+		path = filepath.Join(h.AppRootDir, path)
+	}
+	relPath := h.relativePath(path)
+	extension := filepath.Ext(path)
+	profile := h.currentProfile()
+
+	// rules excluding the path for every handler
+	excluded := ""
+	switch {
+	case h.isInternalPath(path):
+		excluded = "devwatch internal file"
+	case h.Contain(path):
+		excluded = "path is unobserved"
+	case h.isGenerated(path):
+		excluded = "CodegenHandler output, dispatched after the generator runs"
+	case !profile.allowsPath(relPath):
+		excluded = "outside the paths of profile " + h.ActiveProfile()
+	case extension == ".go" && h.skipGeneratedGo(path, "write"):
+		excluded = "generated Go file (SkipGeneratedGo)"
+	}
+
+	handlers := h.orderHandlers(h.FilesEventHandlers)
+	matches := make([]HandlerMatch, 0, len(handlers))
+	for _, handler := range handlers {
+		m := HandlerMatch{Handler: handlerName(handler)}
+		if excluded != "" {
+			m.Reason = excluded
+		} else {
+			m.Matched, m.Reason = h.matchHandler(handler, path, relPath, extension, profile)
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// matchHandler checks the per handler rules of MatchHandlers
+func (h *DevWatch) matchHandler(handler FilesEventHandlers, path, relPath, extension string, profile *WatchProfile) (bool, string) {
+	matchedExt, supported := handlerSupports(handler, path, extension)
+	if !supported {
+		if extension == "" {
+			return false, "file name " + filepath.Base(path) + " not supported"
+		}
+		return false, "extension " + extension + " not supported"
+	}
+	if !profile.allowsHandler(handler) {
+		return false, "disabled in profile " + h.ActiveProfile()
+	}
+	if !handlerInScope(handler, relPath) {
+		return false, "outside scope " + strings.Join(handler.(ScopedHandler).Scope(), ", ")
+	}
+	if source := h.sourceOf(path); !handlerForSource(handler, source) {
+		return false, "source " + source + " not in " + strings.Join(handler.(SourceHandler).Sources(), ", ")
+	}
+
+	reason := "extension " + matchedExt
+	if _, ok := matchExtension(path, handler.SupportedExtensions()); !ok {
+		reason = "file name " + filepath.Base(path)
+		if fh, ok := handler.(FilenameHandler); !ok || !slices.Contains(fh.SupportedFilenames(), filepath.Base(path)) {
+			reason = "MatchFile"
+			if fm, ok := handler.(FileMatcher); !ok || !fm.MatchFile(path) {
+				pattern, _ := matchBuildDependency(handler, path)
+				reason = "build dependency " + pattern
+			}
+		}
+	}
+	if extension != ".go" {
+		return true, reason
+	}
+
+	if isVendored(relPath) && !h.VendorChanges {
+		return false, "vendored package (VendorChanges disabled)"
+	}
+	if ownsAllGoFiles(handler) {
+		return true, "owns all Go files"
+	}
+	mains := strings.Join(handlerMains(handler), ", ")
+	isMine, err := h.goFileIsMine(handler, path, "write")
+	if err != nil {
+		return false, "dependency analysis failed: " + err.Error()
+	}
+	if !isMine {
+		if mains == "" {
+			return false, "no main input file"
+		}
+		return false, "not imported by " + mains
+	}
+	return true, "imported by " + mains
+}
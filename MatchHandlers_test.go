@@ -0,0 +1,76 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatchHandlers(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":             "module a\n\ngo 1.20\n",
+		"cmd/app/main.go":    "package main\n\nimport _ \"a/api\"\n\nfunc main() {}\n",
+		"api/api.go":         "package api\n",
+		"tools/tool.go":      "package main\n\nfunc main() {}\n",
+		"web/style.css":      "body {}",
+		"admin/style.css":    "body {}",
+		"node_modules/x.css": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+	called := func(fileName, extension, filePath, event string) error {
+		t.Errorf("MatchHandlers must not call handlers, got %s", filePath)
+		return nil
+	}
+	if _, err := w.Handle(".go").Name("server").Main("cmd/app/main.go").OnEvent(called).Register(); err != nil {
+		t.Fatal(err)
+	}
+	assets := &scopedCSSHandler{namedCSSHandler: namedCSSHandler{name: "assets"}, scope: []string{"web"}}
+	w.AddFilesEventHandlers(assets)
+	w.loadUnobservedFiles()
+
+	for _, tt := range []struct {
+		path string
+		want []HandlerMatch
+	}{
+		{"api/api.go", []HandlerMatch{
+			{"server", true, "imported by cmd/app/main.go"},
+			{"assets", false, "extension .go not supported"},
+		}},
+		{"tools/tool.go", []HandlerMatch{
+			{"server", false, "not imported by cmd/app/main.go"},
+			{"assets", false, "extension .go not supported"},
+		}},
+		{"web/style.css", []HandlerMatch{
+			{"server", false, "extension .css not supported"},
+			{"assets", true, "extension .css"},
+		}},
+		{"admin/style.css", []HandlerMatch{
+			{"server", false, "extension .css not supported"},
+			{"assets", false, "outside scope web"},
+		}},
+		{"node_modules/x.css", []HandlerMatch{
+			{"server", false, "path is unobserved"},
+			{"assets", false, "path is unobserved"},
+		}},
+	} {
+		if got := w.MatchHandlers(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("MatchHandlers(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+	if assets.calls != 0 {
+		t.Error("MatchHandlers must not call handlers")
+	}
+}
@@ -0,0 +1,66 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestMaxDepth_Registration(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		max    int
+		limits map[string]int
+		want   []string // handled css files, relative to the root
+	}{
+		{"unlimited", 0, nil, []string{"a.css", "web/b.css", "web/dist/c.css", "web/dist/js/d.css", "web/dist/js/min/e.css"}},
+		{"max depth", 2, nil, []string{"a.css", "web/b.css", "web/dist/c.css"}},
+		{"subtree limit", 0, map[string]int{"web/dist": 1}, []string{"a.css", "web/b.css", "web/dist/c.css", "web/dist/js/d.css"}},
+		{"subtree lifts max depth", 1, map[string]int{"web/dist": -1}, []string{"a.css", "web/b.css", "web/dist/c.css", "web/dist/js/d.css", "web/dist/js/min/e.css"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, file := range []string{"a.css", "web/b.css", "web/dist/c.css", "web/dist/js/d.css", "web/dist/js/min/e.css"} {
+				path := filepath.Join(root, filepath.FromSlash(file))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("a{}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			handler := &recordingHandler{}
+			w := New(&WatchConfig{
+				AppRootDir:         root,
+				FilesEventHandlers: []FilesEventHandlers{handler},
+				MaxDepth:           tt.max,
+				DepthLimits:        tt.limits,
+				Logger:             func(message ...any) {},
+			})
+			watcher, err := fsnotify.NewWatcher()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer watcher.Close()
+			w.watcher = watcher
+
+			w.InitialRegistration()
+
+			var got []string
+			for _, path := range handler.paths {
+				got = append(got, w.relativePath(path))
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("handled %v, want %v", got, tt.want)
+			}
+			if watched := len(watcher.WatchList()); watched != len(tt.want) {
+				t.Errorf("watched %d directories, want %d", watched, len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package devwatch
+
+import (
+	"fmt"
+	"maps"
+)
+
+// MessageProvider supplies the text of user-facing messages (log lines), eg:
+// to translate or brand the output of a tool embedding devwatch. Format
+// returns the fmt format of the message with that ID, or "" for the default
+// (see DefaultMessages). Arguments keep their order; a translation may
+// reorder or drop them with explicit indexes eg: "%[2]v tardó %[1]v".
+type MessageProvider interface {
+	Format(id string) string
+}
+
+// Messages is a MessageProvider overriding some formats by ID:
+//
+//	cfg.Messages = devwatch.Messages{
+//		"listening":    "Escuchando cambios ...",
+//		"slow-handler": "manejador lento: %v tardó %v (%v)",
+//	}
+type Messages map[string]string
+
+func (m Messages) Format(id string) string { return m[id] }
+
+// defaultMessages are the built-in formats by message ID
+var defaultMessages = map[string]string{
+	"async-running":          "reload: async handlers still running after %v",
+	"async-timeout":          "async handler timeout: %v",
+	"audit-report":           "%v",
+	"build-growth":           "build size: %v grew %v%% to %v bytes",
+	"cleanup-error":          "source removed cleanup: %v",
+	"cleanup-handler-error":  "source removed cleanup: %v %v",
+	"comment-only":           "comment-only change skipped: %v",
+	"config-error":           "config: %v",
+	"daemon-invalid-request": "daemon: invalid request: %v",
+	"dependency-miss":        "DEBUG %v",
+	"deps-reset":             "%v changed, dependency cache reset",
+	"detect-handler":         "auto detect: handler %v",
+	"detect-none":            "auto detect: no known project layout in %v",
+	"dir-moved":              "directory moved: %v -> %v",
+	"errors-closed":          "h.watcher.Errors: %v",
+	"events-closed":          "Error h.watcher.Events",
+	"event-chain":            "%[1]v",
+	"every-invalid":          "Every: invalid task %v",
+	"focus-off":              "focus mode off",
+	"focus-on":               "focus mode on: browser reloads paused",
+	"folder-event-error":     "folder event error: %v",
+	"format-error":           "format: %v",
+	"formatted":              "formatted: %v",
+	"generated-skipped":      "generated go file skipped: %v",
+	"ignore-suggestion":      "suggestion: add %q to UnobservedFiles: %v",
+	"instance-locked":        "%v",
+	"journal-error":          "event journal: %v",
+	"listening":              "Listening for File Changes ...",
+	"main-found":             "main input file found, rebinding: %v",
+	"main-waiting":           "waiting for main input file: %v",
+	"manifest-error":         "asset manifest: %v",
+	"new-watcher-error":      "Error New Watcher: %v",
+	"open-browser":           "browser opened at %v",
+	"open-browser-error":     "open browser at %v: %v",
+	"overflow":               "WARNING: file events were dropped by the OS (event queue overflow); rescanning %v recently active directories. Add build output folders to UnobservedFiles or raise the limit (linux: sysctl fs.inotify.max_queued_events) to avoid it",
+	"overload":               "overload: %v events/s, busiest path: %v",
+	"ownership-report-error": "OwnershipReport: %v",
+	"ownership-warmed":       "ownership cache warmed: %v results for %v go files",
+	"path-added":             "path added: %v",
+	"path-error":             "accessing path error: %v %v",
+	"path-polled":            "path polled: %v",
+	"queue-full":             "event queue full, dropped: %v %v",
+	"ready-failed":           "reload skipped: %v",
+	"rebuild-loop":           "%[1]v",
+	"registration-error":     "InitialRegistration file error: %v",
+	"registration-root":      "Registration APP ROOT DIR: %v",
+	"reload-transport-error": "reload transport %T: %v",
+	"rescan-error":           "rescan: %v %v",
+	"restarted":              "Watcher restarted: %v",
+	"sensitive-file":         "warning: sensitive file inside the watched tree: %v",
+	"shutdown":               "Shutting down, draining handlers ...",
+	"shutdown-reload":        "Shutdown: pending reload cancelled: %v",
+	"sighup":                 "SIGHUP: rescanning %v",
+	"sighup-error":           "SIGHUP: %v",
+	"signal":                 "Signal received: %v",
+	"skip-event":             "skip event: %v %v",
+	"slow-handler":           "slow handler: %v %v %v",
+	"stage-cycle":            "pipeline stages: dependency cycle detected, using registration order",
+	"stage-deferred":         "pipeline stages: handler %v rejected, a stage other handlers depend on can't have an async, debounced or batched result",
+	"static-server":          "Serving %v on %v",
+	"static-server-error":    "static server: %v",
+	"task-error":             "scheduled task %v error: %v",
+	"verify-failed":          "reload skipped, artifact verification failed: %v",
+	"walk-error":             "Walking directory: %v",
+	"walk-new-dir-error":     "Watch: Error walking new directory: %v %v",
+	"wasm-stale":             "reload skipped: wasm output not rebuilt: %v",
+	"watch-budget":           "watch budget: watching %v of %v directories, polling the rest every %v",
+	"watch-dir-error":        "addDirectoryToWatcher: %v",
+	"watch-dir-failed":       "Failed to add directory to watcher: %v %v",
+	"watch-profile":          "watch profile: %v",
+	"watcher-error":          "watcher error: %v",
+}
+
+// DefaultMessages returns the built-in message formats by ID, the keys a
+// MessageProvider can override
+func DefaultMessages() map[string]string {
+	return maps.Clone(defaultMessages)
+}
+
+// message formats the message id with args through Messages
+func (h *DevWatch) message(id string, args ...any) string {
+	format := ""
+	if h.Messages != nil {
+		format = h.Messages.Format(id)
+	}
+	if format == "" {
+		format = defaultMessages[id]
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// say logs the message id with args, as a LogLine in json LogFormat
+func (h *DevWatch) say(id string, args ...any) {
+	if h.LogFormat == "json" {
+		h.logJSON(id, args...)
+		return
+	}
+	h.Logger(h.message(id, args...))
+}
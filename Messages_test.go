@@ -0,0 +1,34 @@
+package devwatch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMessages_Override(t *testing.T) {
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir: t.TempDir(),
+		Messages: Messages{
+			"focus-on":     "modo foco activado",
+			"slow-handler": "%[3]v: %[1]v tardó %[2]v",
+		},
+		Logger: func(message ...any) { logs = append(logs, fmt.Sprint(message...)) },
+	})
+
+	w.SetFocusMode(true)
+	w.SetFocusMode(false) // not overridden: default text
+	if len(logs) != 2 || logs[0] != "modo foco activado" || logs[1] != "focus mode off" {
+		t.Errorf("logs = %q, want the override then the default", logs)
+	}
+
+	if got := w.message("slow-handler", "css", "2s", "style.css"); got != "style.css: css tardó 2s" {
+		t.Errorf("reordered message = %q", got)
+	}
+	if got := w.message("overload", 120, "web"); got != "overload: 120 events/s, busiest path: web" {
+		t.Errorf("default message = %q", got)
+	}
+	if _, ok := DefaultMessages()["listening"]; !ok {
+		t.Error("DefaultMessages should list the built-in IDs")
+	}
+}
@@ -0,0 +1,40 @@
+package devwatch
+
+// Migration runs a migration command (eg: "migrate up", "goose up") when
+// .sql files change inside its directories. It is the "migrate" pipeline
+// stage: handlers restarting the backend declare DependsOn "migrate" so they
+// only run once the migrations applied, eg:
+//
+//	&devwatch.Migration{
+//		ExecHandler: devwatch.ExecHandler{Command: []string{"goose", "up"}, Dir: appRoot},
+//		Dirs:        []string{"migrations"},
+//	}
+type Migration struct {
+	ExecHandler          // Extensions default to .sql
+	Dirs        []string // relative to AppRootDir eg: []string{"migrations"} (default: whole project)
+}
+
+func (m *Migration) SupportedExtensions() []string {
+	if len(m.Extensions) == 0 {
+		return []string{".sql"}
+	}
+	return m.Extensions
+}
+
+// Scope implements ScopedHandler
+func (m *Migration) Scope() []string { return m.Dirs }
+
+// Stage implements StagedHandler
+func (m *Migration) Stage() string { return "migrate" }
+
+// DependsOn implements StagedHandler
+func (m *Migration) DependsOn() []string { return nil }
+
+// NewFileEvent runs the migration command; removing a migration file
+// doesn't migrate anything
+func (m *Migration) NewFileEvent(fileName, extension, filePath, event string) error {
+	if event == "remove" {
+		return nil
+	}
+	return m.ExecHandler.NewFileEvent(fileName, extension, filePath, event)
+}
@@ -0,0 +1,71 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// restartHandler stands for a backend restart gated on migrations
+type restartHandler struct{ restarts int }
+
+func (r *restartHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	r.restarts++
+	return nil
+}
+func (r *restartHandler) SupportedExtensions() []string { return []string{".sql"} }
+func (r *restartHandler) Stage() string                 { return "restart" }
+func (r *restartHandler) DependsOn() []string           { return []string{"migrate"} }
+
+func TestMigration(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{"migrations/001_users.sql", "queries/users.sql"} {
+		path := filepath.Join(root, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("select 1;"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migration := &Migration{
+		ExecHandler: ExecHandler{Command: []string{"sh", "-c", "echo migrated >> log.txt"}, Dir: root},
+		Dirs:        []string{"migrations"},
+	}
+	restart := &restartHandler{}
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		// registered before the migration on purpose
+		FilesEventHandlers: []FilesEventHandlers{restart, migration},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+	migrations := func() string {
+		log, _ := os.ReadFile(filepath.Join(root, "log.txt"))
+		return string(log)
+	}
+
+	if err := w.Trigger("migrations/001_users.sql", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if migrations() != "migrated\n" || restart.restarts != 1 {
+		t.Errorf("expected migration then restart, got %q and %d restarts", migrations(), restart.restarts)
+	}
+
+	migration.Command = []string{"sh", "-c", "exit 1"}
+	if err := w.Trigger("migrations/001_users.sql", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if restart.restarts != 1 {
+		t.Error("a failed migration must gate the restart")
+	}
+
+	// .sql files outside the migration dirs don't migrate
+	migration.Command = []string{"sh", "-c", "echo migrated >> log.txt"}
+	if err := w.Trigger("queries/users.sql", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if migrations() != "migrated\n" {
+		t.Errorf("queries must not run migrations, got %q", migrations())
+	}
+}
@@ -0,0 +1,17 @@
+package devwatch
+
+import "reflect"
+
+// NamedHandler is an optional capability giving a handler a stable name used
+// by profiles, logs and diagnostics. Without it the handler's type name is used.
+type NamedHandler interface {
+	Name() string // eg: "wasm", "server", "assets"
+}
+
+// handlerName returns the name of handler for profiles, logs and diagnostics
+func handlerName(handler FilesEventHandlers) string {
+	if nh, ok := handler.(NamedHandler); ok && nh.Name() != "" {
+		return nh.Name()
+	}
+	return reflect.TypeOf(handler).String() // same as %T, without allocating
+}
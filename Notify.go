@@ -0,0 +1,144 @@
+package devwatch
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultNotifyInterval is how often an open notification is updated
+const defaultNotifyInterval = 5 * time.Second
+
+// Notification is the failure state of one handler, for desktop
+// notifications or a browser overlay. Consecutive failures of a handler
+// update the same notification (same ID) instead of raising one per save,
+// and its next success resolves it. See WatchConfig.OnNotify.
+type Notification struct {
+	ID          string       `json:"id"` // stable while the handler keeps failing
+	Handler     string       `json:"handler"`
+	Failures    int          `json:"failures"` // consecutive failed runs
+	Since       time.Time    `json:"since"`    // first failure
+	Error       string       `json:"error,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+	Resolved    bool         `json:"resolved,omitempty"` // the handler succeeded again: clear the notification
+}
+
+// openNotification is a failing handler and when its notification was last sent
+type openNotification struct {
+	Notification
+	sent time.Time
+}
+
+// notifier aggregates handler failures per handler
+type notifier struct {
+	mu   sync.Mutex
+	open map[string]*openNotification // handler name => failure state
+	held []Notification               // latest per handler, not sent during focus mode
+}
+
+// notify aggregates the handler results of a file event into notifications:
+// a first failure is sent at once, repeated ones at most once per
+// NotifyInterval unless the error changes, a recovery always. Focus mode
+// holds them back until it ends.
+func (h *DevWatch) notify(rec *EventRecord) {
+	if h.OnNotify == nil || rec.Event == "reload" {
+		return
+	}
+	interval := h.NotifyInterval
+	if interval == 0 {
+		interval = defaultNotifyInterval
+	}
+
+	var send []Notification
+	h.notes.mu.Lock()
+	if h.notes.open == nil {
+		h.notes.open = make(map[string]*openNotification)
+	}
+	for _, result := range rec.Handlers {
+		if result.Superseded {
+			continue
+		}
+		n := h.notes.open[result.Handler]
+		if result.Error == "" {
+			if n != nil {
+				delete(h.notes.open, result.Handler)
+				resolved := n.Notification
+				resolved.Resolved = true
+				send = append(send, resolved)
+			}
+			continue
+		}
+		if n == nil {
+			n = &openNotification{Notification: Notification{
+				ID:      result.Handler + "@" + rec.Time.Format(time.RFC3339Nano),
+				Handler: result.Handler,
+				Since:   rec.Time,
+			}}
+			h.notes.open[result.Handler] = n
+		}
+		changed := n.Error != result.Error
+		n.Failures++
+		n.Error = result.Error
+		n.Diagnostics = result.Diagnostics
+		if n.sent.IsZero() || changed || time.Since(n.sent) >= interval {
+			n.sent = time.Now()
+			send = append(send, n.Notification)
+		}
+	}
+	if h.focus.Load() {
+		for _, n := range send {
+			h.notes.hold(n)
+		}
+		send = nil
+	}
+	h.notes.mu.Unlock()
+
+	for _, n := range send {
+		h.OnNotify(n)
+	}
+}
+
+// hold keeps n as the latest notification of its handler. Callers must hold mu.
+func (s *notifier) hold(n Notification) {
+	if i := slices.IndexFunc(s.held, func(held Notification) bool { return held.Handler == n.Handler }); i >= 0 {
+		s.held = slices.Delete(s.held, i, i+1)
+	}
+	s.held = append(s.held, n)
+}
+
+// flushNotifications sends the notifications held during focus mode, with
+// the current state of the handlers still failing
+func (h *DevWatch) flushNotifications() {
+	h.notes.mu.Lock()
+	held := h.notes.held
+	h.notes.held = nil
+	for i, n := range held {
+		if open := h.notes.open[n.Handler]; open != nil && !n.Resolved {
+			open.sent = time.Now()
+			held[i] = open.Notification
+		}
+	}
+	h.notes.mu.Unlock()
+	for _, n := range held {
+		h.OnNotify(n)
+	}
+}
+
+// Notifications returns the open notifications, oldest first, eg: for a
+// browser overlay connecting after the failures started
+func (h *DevWatch) Notifications() []Notification {
+	h.notes.mu.Lock()
+	defer h.notes.mu.Unlock()
+	open := make([]Notification, 0, len(h.notes.open))
+	for _, n := range h.notes.open {
+		open = append(open, n.Notification)
+	}
+	slices.SortFunc(open, func(a, b Notification) int {
+		if c := a.Since.Compare(b.Since); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Handler, b.Handler)
+	})
+	return open
+}
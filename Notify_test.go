@@ -0,0 +1,78 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// flakyHandler fails while fail is set
+type flakyHandler struct {
+	mu   sync.Mutex
+	fail bool
+}
+
+func (f *flakyHandler) setFail(fail bool) {
+	f.mu.Lock()
+	f.fail = fail
+	f.mu.Unlock()
+}
+
+func (f *flakyHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return errors.New("build failed")
+	}
+	return nil
+}
+
+func (f *flakyHandler) SupportedExtensions() []string { return []string{".css"} }
+func (f *flakyHandler) Name() string                  { return "flaky" }
+
+func TestNotify_AggregatesFailures(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &flakyHandler{fail: true}
+	var notes []Notification
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		OnNotify:           func(n Notification) { notes = append(notes, n) },
+		LoopLimit:          -1, // the same content is triggered repeatedly
+		Logger:             func(message ...any) {},
+	})
+
+	for range 20 {
+		w.Trigger(cssFile, "write")
+	}
+	if len(notes) != 1 || notes[0].Handler != "flaky" || notes[0].Failures != 1 || notes[0].Resolved {
+		t.Fatalf("20 failures sent %+v, want a single notification", notes)
+	}
+	open := w.Notifications()
+	if len(open) != 1 || open[0].Failures != 20 || open[0].ID != notes[0].ID {
+		t.Fatalf("Notifications() = %+v, want the same notification with 20 failures", open)
+	}
+
+	handler.setFail(false)
+	w.Trigger(cssFile, "write")
+	if len(notes) != 2 || !notes[1].Resolved || notes[1].ID != notes[0].ID || notes[1].Failures != 20 {
+		t.Fatalf("recovery sent %+v, want the notification resolved", notes)
+	}
+	if open := w.Notifications(); len(open) != 0 {
+		t.Errorf("Notifications() = %+v after recovery, want none", open)
+	}
+
+	// a new failure opens a new notification
+	handler.setFail(true)
+	w.Trigger(cssFile, "write")
+	if len(notes) != 3 || notes[2].ID == notes[0].ID || notes[2].Failures != 1 {
+		t.Errorf("new failure sent %+v, want a new notification", notes)
+	}
+}
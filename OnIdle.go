@@ -0,0 +1,50 @@
+package devwatch
+
+import "time"
+
+// defaultIdleTimeout is used when WatchConfig.IdleTimeout is not set
+const defaultIdleTimeout = 5 * time.Second
+
+// idleDetector fires WatchConfig.OnIdle once after no events arrived for
+// IdleTimeout. It re-arms on the next event.
+type idleDetector struct {
+	timer        *time.Timer
+	C            <-chan time.Time // nil when OnIdle is not configured
+	lastActivity time.Time
+}
+
+func (h *DevWatch) newIdleDetector() *idleDetector {
+	d := &idleDetector{lastActivity: time.Now()}
+	if h.OnIdle != nil {
+		d.timer = time.NewTimer(h.idleTimeout())
+		d.C = d.timer.C
+	}
+	return d
+}
+
+func (h *DevWatch) idleTimeout() time.Duration {
+	if h.IdleTimeout > 0 {
+		return h.IdleTimeout
+	}
+	return defaultIdleTimeout
+}
+
+// activity records an incoming event and restarts the idle countdown
+func (d *idleDetector) activity(timeout time.Duration) {
+	d.lastActivity = time.Now()
+	if d.timer != nil {
+		d.timer.Reset(timeout)
+	}
+}
+
+func (d *idleDetector) stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
+
+// notifyIdle runs OnIdle in the background so expensive tasks never block the watch loop
+func (h *DevWatch) notifyIdle(d *idleDetector) {
+	idleFor := time.Since(d.lastActivity)
+	go h.OnIdle(idleFor)
+}
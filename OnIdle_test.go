@@ -0,0 +1,71 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestOnIdle(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var idleCount int32
+	idleCalled := make(chan time.Duration, 4)
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{SupportedExtensions_: []string{".css"}}},
+		OnIdle: func(idleFor time.Duration) {
+			atomic.AddInt32(&idleCount, 1)
+			idleCalled <- idleFor
+		},
+		IdleTimeout: 80 * time.Millisecond,
+		Logger:      func(message ...any) { t.Log(message...) },
+		ExitChan:    make(chan bool, 1),
+	})
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.watcher = watcher
+
+	done := make(chan struct{})
+	go func() {
+		w.watchEvents()
+		close(done)
+	}()
+
+	// events keep postponing the idle callback
+	for range 3 {
+		watcher.Events <- fsnotify.Event{Name: cssFile, Op: fsnotify.Write}
+		time.Sleep(30 * time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&idleCount); got != 0 {
+		t.Fatalf("OnIdle called while events were arriving: %d", got)
+	}
+
+	select {
+	case idleFor := <-idleCalled:
+		if idleFor < 80*time.Millisecond {
+			t.Errorf("idleFor = %v, want >= 80ms", idleFor)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected OnIdle to be called")
+	}
+
+	// fires only once per idle period
+	time.Sleep(200 * time.Millisecond)
+	if got := atomic.LoadInt32(&idleCount); got != 1 {
+		t.Errorf("expected OnIdle once per idle period, got %d", got)
+	}
+
+	w.ExitChan <- true
+	<-done
+}
@@ -0,0 +1,82 @@
+package devwatch
+
+import (
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+// browserOpener opens WatchConfig.OpenBrowserURL once
+type browserOpener struct {
+	mu     sync.Mutex
+	failed bool // an initial build failed: wait for a successful one
+	opened bool
+}
+
+// initialBuildFailed defers OpenBrowserURL to the first successful build
+func (h *DevWatch) initialBuildFailed() {
+	h.opener.mu.Lock()
+	h.opener.failed = true
+	h.opener.mu.Unlock()
+}
+
+// openBrowserOnStart opens OpenBrowserURL after InitialRegistration unless
+// an initial build failed
+func (h *DevWatch) openBrowserOnStart() {
+	h.opener.mu.Lock()
+	failed := h.opener.failed
+	h.opener.mu.Unlock()
+	if !failed {
+		go h.openBrowser()
+	}
+}
+
+// openBrowser opens OpenBrowserURL the first time it's called, once the
+// server accepts connections (see ReadyProbe). Reloads after a successful
+// build call it too, for a failed initial build.
+func (h *DevWatch) openBrowser() {
+	if h.OpenBrowserURL == "" || h.auditing() {
+		return
+	}
+	h.opener.mu.Lock()
+	if h.opener.opened {
+		h.opener.mu.Unlock()
+		return
+	}
+	h.opener.opened = true
+	h.opener.mu.Unlock()
+
+	if !h.serverReady() {
+		h.opener.mu.Lock()
+		h.opener.opened = false // next reload
+		h.opener.mu.Unlock()
+		return
+	}
+	open := h.OpenBrowser
+	if open == nil {
+		open = openSystemBrowser
+	}
+	if err := open(h.OpenBrowserURL); err != nil {
+		h.say("open-browser-error", h.OpenBrowserURL, err)
+		return
+	}
+	h.say("open-browser", h.OpenBrowserURL)
+}
+
+// openSystemBrowser opens url with the default browser of the platform
+func openSystemBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait() // the opener may exit at once or live as long as the browser
+	return nil
+}
@@ -0,0 +1,84 @@
+package devwatch
+
+import (
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// failingBuild fails its first calls, then succeeds
+type failingBuild struct{ failures int32 }
+
+func (f *failingBuild) NewFileEvent(fileName, extension, filePath, event string) error {
+	if atomic.AddInt32(&f.failures, -1) >= 0 {
+		return errors.New("build failed")
+	}
+	return nil
+}
+func (f *failingBuild) SupportedExtensions() []string { return []string{".css"} }
+
+// startForBrowser runs the InitialRegistration of FileWatcherStart with
+// handler and returns the channel receiving the opened URLs
+func startForBrowser(t *testing.T, handler FilesEventHandlers) (*DevWatch, chan string) {
+	t.Helper()
+	opened := make(chan string, 4)
+	w := New(&WatchConfig{
+		AppRootDir:         filepath.Join(string(filepath.Separator), "virtual", "app"),
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		FS:                 fstest.MapFS{"web/style.css": {Data: []byte("body{}")}},
+		OpenBrowserURL:     "http://localhost:8080",
+		OpenBrowser:        func(url string) error { opened <- url; return nil },
+		Logger:             func(message ...any) {},
+	})
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { watcher.Close() })
+	w.watcher = watcher
+
+	w.InitialRegistration()
+	w.openBrowserOnStart()
+	return w, opened
+}
+
+func TestOpenBrowserURL(t *testing.T) {
+	w, opened := startForBrowser(t, &recordingHandler{})
+	select {
+	case url := <-opened:
+		if url != "http://localhost:8080" {
+			t.Errorf("opened %q", url)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the browser to open after the initial build")
+	}
+
+	w.triggerBrowserReload()
+	select {
+	case <-opened:
+		t.Error("expected the browser to open once")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestOpenBrowserURL_AfterFailedBuild(t *testing.T) {
+	w, opened := startForBrowser(t, &failingBuild{failures: 1})
+	select {
+	case <-opened:
+		t.Fatal("expected no browser while the initial build fails")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the fixed build reloads: the browser opens instead
+	w.triggerBrowserReload()
+	select {
+	case <-opened:
+	case <-time.After(time.Second):
+		t.Fatal("expected the browser to open after the first successful build")
+	}
+}
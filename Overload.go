@@ -0,0 +1,145 @@
+package devwatch
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// overloadSeconds is how many consecutive seconds the event rate must stay
+// above OverloadEventsPerSecond before OnOverload is invoked
+const overloadSeconds = 3
+
+// overloadTop bounds the paths and handlers listed in an OverloadReport
+const overloadTop = 10
+
+// OverloadReport is a snapshot taken when the raw event rate stayed above
+// OverloadEventsPerSecond, usually a feedback loop between a handler
+// writing files and the watcher (eg: build output inside a watched folder)
+type OverloadReport struct {
+	Rate     float64        // events per second over the last seconds
+	TopPaths []PathCount    // paths with most events, busiest first
+	Handlers []HandlerCount // recent handler calls, busiest first
+}
+
+// PathCount is the number of events of a path
+type PathCount struct {
+	Path   string
+	Events int
+}
+
+// HandlerCount is the number of recent calls of a handler
+type HandlerCount struct {
+	Handler string
+	Calls   int
+}
+
+type rateSample struct {
+	at   time.Time
+	path string
+}
+
+type secondCount struct {
+	sec int64 // unix second
+	n   int
+}
+
+// rateGuard tracks the raw event rate of the last seconds
+type rateGuard struct {
+	mu      sync.Mutex
+	events  []rateSample // within overloadSeconds
+	paths   map[string]int
+	seconds [overloadSeconds + 1]secondCount // ring indexed by unix second
+	tripped bool                             // OnOverload already invoked for the current overload
+}
+
+// observe records an event and reports whether it completes an overload:
+// every one of the last overloadSeconds full seconds had at least
+// threshold events. It fires once per overload.
+func (g *rateGuard) observe(path string, now time.Time, threshold float64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paths == nil {
+		g.paths = make(map[string]int)
+	}
+	g.events = append(g.events, rateSample{at: now, path: path})
+	g.paths[path]++
+	for len(g.events) > 0 && now.Sub(g.events[0].at) > overloadSeconds*time.Second {
+		old := g.events[0].path
+		if g.paths[old]--; g.paths[old] == 0 {
+			delete(g.paths, old)
+		}
+		g.events = g.events[1:]
+	}
+
+	sec := now.Unix()
+	slot := &g.seconds[sec%int64(len(g.seconds))]
+	if slot.sec != sec {
+		*slot = secondCount{sec: sec}
+	}
+	slot.n++
+
+	for i := int64(1); i <= overloadSeconds; i++ {
+		past := g.seconds[(sec-i)%int64(len(g.seconds))]
+		if past.sec != sec-i || float64(past.n) < threshold {
+			g.tripped = false
+			return false
+		}
+	}
+	if g.tripped {
+		return false
+	}
+	g.tripped = true
+	return true
+}
+
+// topPaths returns the busiest paths of the last seconds
+func (g *rateGuard) topPaths() ([]PathCount, float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	top := make([]PathCount, 0, len(g.paths))
+	for path, n := range g.paths {
+		top = append(top, PathCount{Path: path, Events: n})
+	}
+	slices.SortFunc(top, func(a, b PathCount) int {
+		return cmp.Or(cmp.Compare(b.Events, a.Events), cmp.Compare(a.Path, b.Path))
+	})
+	return top[:min(len(top), overloadTop)], float64(len(g.events)) / overloadSeconds
+}
+
+// observeRate feeds the overload watchdog with a raw watcher event
+func (h *DevWatch) observeRate(path string, now time.Time) {
+	if h.OverloadEventsPerSecond <= 0 || !h.rate.observe(h.relativePath(path), now, h.OverloadEventsPerSecond) {
+		return
+	}
+	report := h.overloadReport(now)
+	h.say("overload", int(report.Rate), report.TopPaths[0].Path)
+	if h.OnOverload != nil {
+		go h.OnOverload(report) // never block the watch loop
+	}
+}
+
+// overloadReport snapshots the busiest paths and the handlers called in
+// the last seconds
+func (h *DevWatch) overloadReport(now time.Time) OverloadReport {
+	var report OverloadReport
+	report.TopPaths, report.Rate = h.rate.topPaths()
+
+	counts := make(map[string]int)
+	h.calls.mu.Lock()
+	for _, call := range h.calls.recent {
+		if now.Sub(call.At) <= overloadSeconds*time.Second {
+			counts[call.Handler]++
+		}
+	}
+	h.calls.mu.Unlock()
+	for handler, n := range counts {
+		report.Handlers = append(report.Handlers, HandlerCount{Handler: handler, Calls: n})
+	}
+	slices.SortFunc(report.Handlers, func(a, b HandlerCount) int {
+		return cmp.Or(cmp.Compare(b.Calls, a.Calls), cmp.Compare(a.Handler, b.Handler))
+	})
+	report.Handlers = report.Handlers[:min(len(report.Handlers), overloadTop)]
+	return report
+}
@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOverload(t *testing.T) {
+	reports := make(chan OverloadReport, 2)
+	w := New(&WatchConfig{
+		AppRootDir:              "/app",
+		OverloadEventsPerSecond: 10,
+		OnOverload:              func(r OverloadReport) { reports <- r },
+		Logger:                  func(message ...any) { t.Log(message...) },
+	})
+	start := time.Unix(time.Now().Unix(), 0)
+	w.calls.add(HandlerInvocation{Handler: "build", At: start.Add(4 * time.Second)})
+
+	// a short burst followed by a quiet second is not an overload
+	for i := range 50 {
+		w.observeRate("/app/dist/out.js", start.Add(time.Duration(i)*time.Millisecond))
+	}
+	select {
+	case r := <-reports:
+		t.Fatalf("unexpected overload %+v", r)
+	default:
+	}
+	// 20 events per second for 4 seconds, mostly one path: fires at start+5s
+	for i := range 80 {
+		path := "/app/dist/out.js"
+		if i%4 == 0 {
+			path = "/app/main.go"
+		}
+		w.observeRate(path, start.Add(2*time.Second+time.Duration(i)*50*time.Millisecond))
+	}
+
+	select {
+	case r := <-reports:
+		if len(r.TopPaths) != 2 || r.TopPaths[0].Path != "dist/out.js" || r.TopPaths[1].Path != "main.go" {
+			t.Errorf("unexpected top paths %+v", r.TopPaths)
+		}
+		if r.Rate < 10 {
+			t.Errorf("expected the sustained rate, got %v", r.Rate)
+		}
+		if len(r.Handlers) != 1 || r.Handlers[0].Handler != "build" {
+			t.Errorf("expected recent handler calls, got %+v", r.Handlers)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnOverload not invoked")
+	}
+	select {
+	case r := <-reports:
+		t.Errorf("OnOverload must fire once per overload, got %+v", r)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,96 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+)
+
+// OwnershipReport lists which handlers would receive each file of the
+// watched tree, without watching nor calling any handler. Useful in CI to
+// check that every source file is owned by some build target and that the
+// ignore rules skip what they should:
+//
+//	report := watcher.OwnershipReport()
+//	report.WriteJSON(os.Stdout)
+//	if len(report.Unowned) > 0 {
+//		os.Exit(1)
+//	}
+type OwnershipReport struct {
+	Files []FileOwnership `json:"files"`
+	// Unowned are the files no handler would receive
+	Unowned []string `json:"unowned"`
+	// Ignored are the unobserved files and folders (folder contents are not listed)
+	Ignored []string `json:"ignored"`
+}
+
+// FileOwnership is a file of the watched tree and the handlers receiving it
+type FileOwnership struct {
+	Path     string   `json:"path"` // relative to AppRootDir, slash separated
+	Handlers []string `json:"handlers"`
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *OwnershipReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// OwnershipReport walks the watch roots once, like InitialRegistration,
+// and reports the handlers owning each file. Go files are resolved with
+// the same dependency analysis used for events.
+func (h *DevWatch) OwnershipReport() *OwnershipReport {
+	h.loadUnobservedFiles()
+
+	report := &OwnershipReport{Files: []FileOwnership{}, Unowned: []string{}, Ignored: []string{}}
+	for _, root := range h.watchRoots() {
+		err := h.walkRoot(root, func(path string, isDir bool) error {
+			rel := h.relativePath(path)
+			if h.Contain(path) {
+				report.Ignored = append(report.Ignored, rel)
+				if isDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isDir {
+				return nil
+			}
+
+			owners := h.owners(path)
+			report.Files = append(report.Files, FileOwnership{Path: rel, Handlers: owners})
+			if len(owners) == 0 {
+				report.Unowned = append(report.Unowned, rel)
+			}
+			return nil
+		})
+		if err != nil {
+			h.say("ownership-report-error", err)
+		}
+	}
+	return report
+}
+
+// owners returns the names of the handlers that would receive a create
+// event for path
+func (h *DevWatch) owners(path string) []string {
+	extension := filepath.Ext(path)
+	if extension == ".go" && h.skipGeneratedGo(path, "create") {
+		return []string{}
+	}
+	owners := []string{}
+	source := h.sourceOf(path)
+	for _, handler := range h.orderHandlers(h.FilesEventHandlers) {
+		if _, supported := handlerSupports(handler, path, extension); !supported || !handlerInScope(handler, h.relativePath(path)) || !handlerForSource(handler, source) {
+			continue
+		}
+		if extension == ".go" {
+			if isMine, err := h.goFileIsMine(handler, path, "create"); err != nil || !isMine {
+				continue
+			}
+		}
+		owners = append(owners, handlerName(handler))
+	}
+	return owners
+}
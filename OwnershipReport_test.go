@@ -0,0 +1,76 @@
+package devwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOwnershipReport(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":              "module a\n\ngo 1.20\n",
+		"cmd/app/main.go":     "package main\n\nimport _ \"a/api\"\n\nfunc main() {}\n",
+		"api/api.go":          "package api\n",
+		"tools/tool.go":       "package main\n\nfunc main() {}\n",
+		"web/style.css":       "body {}",
+		".idea/workspace.xml": "<project/>",
+		"node_modules/x/x.js": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+	called := func(fileName, extension, filePath, event string) error {
+		t.Errorf("the report must not call handlers, got %s", filePath)
+		return nil
+	}
+	if _, err := w.Handle(".go").Name("server").Main("cmd/app/main.go").OnEvent(called).Register(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Handle(".css").Name("assets").OnEvent(called).Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := w.OwnershipReport()
+	owners := make(map[string][]string)
+	for _, f := range report.Files {
+		owners[f.Path] = f.Handlers
+	}
+	want := map[string][]string{
+		"go.mod":          {},
+		"cmd/app/main.go": {"server"},
+		"api/api.go":      {"server"},
+		"tools/tool.go":   {},
+		"web/style.css":   {"assets"},
+	}
+	if !reflect.DeepEqual(owners, want) {
+		t.Errorf("got owners %v, want %v", owners, want)
+	}
+	if !reflect.DeepEqual(report.Unowned, []string{"go.mod", "tools/tool.go"}) {
+		t.Errorf("unexpected unowned files %v", report.Unowned)
+	}
+	if !reflect.DeepEqual(report.Ignored, []string{".idea", "node_modules"}) {
+		t.Errorf("unexpected ignored paths %v", report.Ignored)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded OwnershipReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded.Files) != len(report.Files) {
+		t.Errorf("invalid JSON report: %v\n%s", err, buf.String())
+	}
+}
@@ -0,0 +1,55 @@
+package devwatch
+
+import (
+	"errors"
+	"time"
+)
+
+// ArtifactVerifier is an optional capability for handlers producing build
+// outputs (server binary, wasm). VerifyArtifacts runs right before each
+// browser reload; an error (eg: missing binary, zero-byte wasm) skips the
+// reload so the page is never reloaded into a broken state.
+type ArtifactVerifier interface {
+	VerifyArtifacts() error
+}
+
+// verifyArtifacts runs WatchConfig.PreReloadCheck and every handler
+// ArtifactVerifier. On failure it publishes a "reload" EventRecord skipped
+// with "verify", logs, calls OnError and returns the joined errors.
+func (h *DevWatch) verifyArtifacts() error {
+	var results EventRecord // handler results, published only on failure
+	var errs []error
+
+	if h.PreReloadCheck != nil {
+		start := time.Now()
+		if err := h.PreReloadCheck(); err != nil {
+			results.Handlers = append(results.Handlers, HandlerResult{Handler: "PreReloadCheck", Duration: time.Since(start), Error: err.Error()})
+			errs = append(errs, err)
+		}
+	}
+	for _, handler := range h.FilesEventHandlers {
+		v, ok := handler.(ArtifactVerifier)
+		if !ok {
+			continue
+		}
+		start := time.Now()
+		if err := v.VerifyArtifacts(); err != nil {
+			results.addResult(handler, start, err)
+			errs = append(errs, errors.New(handlerName(handler)+": "+err.Error()))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	err := errors.Join(errs...)
+	rec := h.newEventRecord("", "reload")
+	rec.Handlers = results.Handlers
+	rec.Skipped = "verify"
+	h.publish(rec)
+	h.say("verify-failed", err)
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package devwatch
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// verifyingHandler reports its artifacts as broken while broken is set
+type verifyingHandler struct {
+	FakeFilesEventHandler
+	broken bool
+}
+
+func (v *verifyingHandler) VerifyArtifacts() error {
+	if v.broken {
+		return errors.New("main.wasm is empty")
+	}
+	return nil
+}
+
+func TestPreReloadCheck(t *testing.T) {
+	var reloads int32
+	var reported error
+	handler := &verifyingHandler{broken: true}
+	checkErr := errors.New("server binary missing")
+	w := New(&WatchConfig{
+		AppRootDir:         t.TempDir(),
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		BrowserReload: func() error {
+			atomic.AddInt32(&reloads, 1)
+			return nil
+		},
+		PreReloadCheck: func() error { return checkErr },
+		OnError:        func(err error) { reported = err },
+		Logger:         func(message ...any) {},
+	})
+	events, cancel := w.Subscribe()
+	defer cancel()
+
+	w.triggerBrowserReload()
+	if atomic.LoadInt32(&reloads) != 0 {
+		t.Fatal("expected reload to be skipped when verification fails")
+	}
+	if !errors.Is(reported, checkErr) {
+		t.Errorf("expected OnError with PreReloadCheck error, got %v", reported)
+	}
+	rec := <-events
+	if rec.Event != "reload" || rec.Skipped != "verify" || len(rec.Handlers) != 2 {
+		t.Errorf("unexpected error event: %+v", rec)
+	}
+
+	if err := w.ForceReload(); err == nil {
+		t.Error("expected ForceReload to return the verification error")
+	}
+
+	handler.broken = false
+	w.PreReloadCheck = nil
+	w.triggerBrowserReload()
+	if atomic.LoadInt32(&reloads) != 1 {
+		t.Errorf("expected reload once artifacts verify, got %d", reloads)
+	}
+
+	// a successful verification uses no event ID
+	before := w.newEventRecord("", "write").ID
+	w.triggerBrowserReload()
+	if after := w.newEventRecord("", "write").ID; after != before+1 {
+		t.Errorf("expected consecutive event IDs around a verified reload, got %d then %d", before, after)
+	}
+}
@@ -0,0 +1,139 @@
+package devwatch
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ExtensionCount is the number of files with one extension found under a
+// top level folder by InitialRegistration
+type ExtensionCount struct {
+	Dir       string // top level folder relative to AppRootDir ("." for files in the root); other roots keep their path
+	Extension string // eg: ".ts", empty for files without extension
+	Files     int
+	Handled   int // files some handler supports
+}
+
+// ProjectProfile is the extension census of the watched tree, most files first
+type ProjectProfile []ExtensionCount
+
+// String formats the profile as a table, eg: for a log line or a control endpoint
+func (p ProjectProfile) String() string {
+	var b strings.Builder
+	for _, c := range p {
+		fmt.Fprintf(&b, "%8d  %-8s %s (handled %d)\n", c.Files, c.Extension, c.Dir, c.Handled)
+	}
+	return b.String()
+}
+
+// Unhandled returns per extension the files no handler supports, across
+// folders, most files first
+func (p ProjectProfile) Unhandled() []ExtensionCount {
+	totals := make(map[string]int)
+	for _, c := range p {
+		if c.Files > c.Handled && c.Extension != "" {
+			totals[c.Extension] += c.Files - c.Handled
+		}
+	}
+	unhandled := make([]ExtensionCount, 0, len(totals))
+	for ext, files := range totals {
+		unhandled = append(unhandled, ExtensionCount{Extension: ext, Files: files})
+	}
+	sortCounts(unhandled)
+	return unhandled
+}
+
+// Suggestions describes the extensions no handler supports, eg: "found 143
+// .ts files but no handler supports .ts", for tools proposing handlers
+func (p ProjectProfile) Suggestions() []string {
+	var suggestions []string
+	for _, c := range p.Unhandled() {
+		suggestions = append(suggestions, fmt.Sprintf("found %d %s files but no handler supports %s", c.Files, c.Extension, c.Extension))
+	}
+	return suggestions
+}
+
+// ProjectProfile returns the files per extension and top level folder found
+// by InitialRegistration (unobserved files excluded)
+func (h *DevWatch) ProjectProfile() ProjectProfile {
+	h.census.mu.Lock()
+	defer h.census.mu.Unlock()
+	profile := make(ProjectProfile, 0, len(h.census.counts))
+	for _, c := range h.census.counts {
+		profile = append(profile, *c)
+	}
+	sortCounts(profile)
+	return profile
+}
+
+// sortCounts orders counts by files, then folder and extension
+func sortCounts(counts []ExtensionCount) {
+	slices.SortFunc(counts, func(a, b ExtensionCount) int {
+		if a.Files != b.Files {
+			return b.Files - a.Files
+		}
+		if a.Dir != b.Dir {
+			return strings.Compare(a.Dir, b.Dir)
+		}
+		return strings.Compare(a.Extension, b.Extension)
+	})
+}
+
+// extensionCensus counts the files found by InitialRegistration
+type extensionCensus struct {
+	mu     sync.Mutex
+	roots  []string                      // absolute slash watch roots, AppRootDir first
+	counts map[[2]string]*ExtensionCount // {dir, extension} => count
+}
+
+// reset drops the previous census; roots are the watch roots
+func (c *extensionCensus) reset(roots []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roots = c.roots[:0]
+	for _, root := range roots {
+		c.roots = append(c.roots, absSlashPath(root))
+	}
+	c.counts = make(map[[2]string]*ExtensionCount)
+}
+
+// countExtension counts a file found during registration
+func (h *DevWatch) countExtension(path string, handled bool) {
+	abs := absSlashPath(path)
+	ext := filepath.Ext(path)
+
+	h.census.mu.Lock()
+	defer h.census.mu.Unlock()
+	dir := ""
+	for i, root := range h.census.roots {
+		if !withinDir(abs, root) {
+			continue
+		}
+		dir = "."
+		rest := strings.TrimPrefix(strings.TrimPrefix(abs, root), "/")
+		if top, _, nested := strings.Cut(rest, "/"); nested {
+			dir = top
+		}
+		if i > 0 { // extra roots keep their path so folders don't mix with AppRootDir's
+			dir = strings.TrimSuffix(root+"/"+dir, "/.")
+		}
+		break
+	}
+	if dir == "" || h.census.counts == nil {
+		return
+	}
+
+	key := [2]string{dir, ext}
+	c := h.census.counts[key]
+	if c == nil {
+		c = &ExtensionCount{Dir: dir, Extension: ext}
+		h.census.counts[key] = c
+	}
+	c.Files++
+	if handled {
+		c.Handled++
+	}
+}
@@ -0,0 +1,51 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestProjectProfile_Census(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "virtual", "app")
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{&recordingHandler{}},
+		Logger:             func(message ...any) {},
+		FS: fstest.MapFS{
+			"README.md":               {Data: []byte("# app")},
+			"web/app.js":              {Data: []byte("main()")},
+			"web/style.css":           {Data: []byte("body{}")},
+			"web/ts/a.ts":             {Data: []byte("")},
+			"web/ts/b.ts":             {Data: []byte("")},
+			"api/c.ts":                {Data: []byte("")},
+			"web/node_modules/lib.js": {Data: []byte("lib()")},
+		},
+	})
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	w.watcher = watcher
+
+	w.InitialRegistration()
+
+	want := ProjectProfile{
+		{Dir: "web", Extension: ".ts", Files: 2},
+		{Dir: ".", Extension: ".md", Files: 1},
+		{Dir: "api", Extension: ".ts", Files: 1},
+		{Dir: "web", Extension: ".css", Files: 1, Handled: 1},
+		{Dir: "web", Extension: ".js", Files: 1, Handled: 1},
+	}
+	if got := w.ProjectProfile(); !slices.Equal(got, want) {
+		t.Errorf("profile =\n%v\nwant\n%v", got, want)
+	}
+	suggestions := w.ProjectProfile().Suggestions()
+	if want := []string{"found 3 .ts files but no handler supports .ts", "found 1 .md files but no handler supports .md"}; !slices.Equal(suggestions, want) {
+		t.Errorf("suggestions = %q, want %q", suggestions, want)
+	}
+}
@@ -0,0 +1,95 @@
+package devwatch
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ReadyProbe checks that the restarted server accepts requests before the
+// browser reloads, so the page doesn't reload into "connection refused".
+// Set Addr for a TCP dial or URL for an HTTP GET (any status below 500 counts
+// as ready).
+type ReadyProbe struct {
+	Addr    string        // eg: "localhost:8080"
+	URL     string        // eg: "http://localhost:8080/health"
+	Timeout time.Duration // total time to wait for the server (default 10s)
+}
+
+// readyProbe backoff between attempts: 50ms doubling up to 1s
+const (
+	readyProbeTimeout    = 10 * time.Second
+	readyProbeBackoff    = 50 * time.Millisecond
+	readyProbeMaxBackoff = time.Second
+)
+
+// wait retries the probe with backoff until it succeeds or the timeout expires
+func (p *ReadyProbe) wait() error {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = readyProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	backoff := readyProbeBackoff
+	for {
+		err := p.check(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("server not ready after " + timeout.String() + ": " + err.Error())
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, readyProbeMaxBackoff)
+	}
+}
+
+// check runs a single attempt
+func (p *ReadyProbe) check(ctx context.Context) error {
+	attempt, cancel := context.WithTimeout(ctx, readyProbeMaxBackoff)
+	defer cancel()
+
+	if p.URL != "" {
+		req, err := http.NewRequestWithContext(attempt, http.MethodGet, p.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			return errors.New("status " + resp.Status)
+		}
+		return nil
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(attempt, "tcp", p.Addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// serverReady runs the configured ReadyProbe; on failure the reload is
+// skipped and the error reported through the log and OnError
+func (h *DevWatch) serverReady() bool {
+	if h.ReadyProbe == nil || (h.ReadyProbe.Addr == "" && h.ReadyProbe.URL == "") {
+		return true
+	}
+	if err := h.ReadyProbe.wait(); err != nil {
+		h.say("ready-failed", err)
+		if h.OnError != nil {
+			h.OnError(err)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package devwatch
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadyProbe(t *testing.T) {
+	// reserve a port, then start the server on it only after a delay
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		if l, err := net.Listen("tcp", addr); err == nil {
+			t.Cleanup(func() { l.Close() })
+			go http.Serve(l, http.NotFoundHandler())
+		}
+	}()
+
+	start := time.Now()
+	if err := (&ReadyProbe{Addr: addr, Timeout: 2 * time.Second}).wait(); err != nil {
+		t.Fatalf("expected server to become ready: %v", err)
+	}
+	if time.Since(start) < 150*time.Millisecond {
+		t.Error("expected probe to wait for the server to boot")
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	if err := (&ReadyProbe{URL: srv.URL, Timeout: 200 * time.Millisecond}).wait(); err == nil {
+		t.Error("expected probe to fail while the server answers 503")
+	}
+}
+
+func TestServerReadySkipsReload(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close() // nothing listening
+
+	reloaded := false
+	var reported error
+	w := New(&WatchConfig{
+		AppRootDir:    t.TempDir(),
+		BrowserReload: func() error { reloaded = true; return nil },
+		ReadyProbe:    &ReadyProbe{Addr: addr, Timeout: 100 * time.Millisecond},
+		OnError:       func(err error) { reported = err },
+		Logger:        func(message ...any) {},
+	})
+	w.triggerBrowserReload()
+	if reloaded || reported == nil {
+		t.Errorf("expected reload skipped and error reported, reloaded=%v err=%v", reloaded, reported)
+	}
+}
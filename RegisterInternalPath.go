@@ -0,0 +1,47 @@
+package devwatch
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// internalPaths are files or folders written by devwatch itself or its
+// subsystems (journal, snapshots, logs), stored as absolute slash paths
+type internalPaths struct {
+	mu    sync.RWMutex
+	paths []string
+}
+
+// RegisterInternalPath marks a file or folder written by devwatch or one of
+// its subsystems (eg: a journal or snapshot file inside AppRootDir). It is
+// treated as unobserved and its events never reach handlers nor reload the
+// browser. Relative paths are resolved against AppRootDir.
+func (h *DevWatch) RegisterInternalPath(path string) {
+	if path == "" {
+		return
+	}
+	if !isAbsSlash(slashPath(path)) && h.AppRootDir != "" {
+		path = h.AppRootDir + "/" + path
+	}
+	abs := absSlashPath(path)
+
+	h.internal.mu.Lock()
+	defer h.internal.mu.Unlock()
+	if !slices.Contains(h.internal.paths, abs) {
+		h.internal.paths = append(h.internal.paths, abs)
+	}
+}
+
+// isInternalPath reports whether path is, or is inside, a registered internal path
+func (h *DevWatch) isInternalPath(path string) bool {
+	h.internal.mu.RLock()
+	defer h.internal.mu.RUnlock()
+	if len(h.internal.paths) == 0 {
+		return false
+	}
+	abs := absSlashPath(path)
+	return slices.ContainsFunc(h.internal.paths, func(p string) bool {
+		return abs == p || strings.HasPrefix(abs, p+"/")
+	})
+}
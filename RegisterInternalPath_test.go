@@ -0,0 +1,45 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRegisterInternalPath(t *testing.T) {
+	root := t.TempDir()
+	stateDir := filepath.Join(root, "state")
+	if err := os.Mkdir(stateDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(stateDir, "journal.css")
+	if err := os.WriteFile(journal, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var called int32
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".css"},
+		}},
+		BrowserReload: func() error { return nil },
+		Logger:        func(message ...any) {},
+	})
+	w.RegisterInternalPath("state")
+
+	if !w.Contain(journal) || !w.Contain(stateDir) {
+		t.Error("expected internal path and its content to be unobserved")
+	}
+	if w.Contain(filepath.Join(root, "statement.css")) {
+		t.Error("expected sibling with the same prefix to stay observed")
+	}
+
+	// removals skip Contain in the watch loop: they must not reach handlers either
+	w.handleFileEvent("journal.css", journal, "remove", true)
+	if atomic.LoadInt32(&called) != 0 {
+		t.Error("expected internal file events to never reach handlers")
+	}
+}
@@ -0,0 +1,107 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// eventRecorder records the events it receives and fails when err is set
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []string
+	err    error
+}
+
+func (e *eventRecorder) NewFileEvent(fileName, extension, filePath, event string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, fileName+":"+event)
+	return e.err
+}
+func (e *eventRecorder) SupportedExtensions() []string { return []string{".css"} }
+
+func (e *eventRecorder) received() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.events...)
+}
+
+func TestReloadOnDelete_Policy(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		policy   bool
+		path     string
+		err      error
+		reloaded bool
+	}{
+		{"unhandled asset without policy", false, "logo.png", nil, false},
+		{"unhandled asset", true, "logo.png", nil, true},
+		{"handled asset", true, "app.css", nil, true},
+		{"failing handler", true, "app.css", errors.New("boom"), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var reloads atomic.Int32
+			w := New(&WatchConfig{
+				AppRootDir:         t.TempDir(),
+				FilesEventHandlers: []FilesEventHandlers{&eventRecorder{err: tt.err}},
+				BrowserReload:      func() error { reloads.Add(1); return nil },
+				ReloadOnDelete:     tt.policy,
+				Logger:             func(message ...any) {},
+			})
+			if err := w.Trigger(tt.path, "remove"); err != nil {
+				t.Fatal(err)
+			}
+			time.Sleep(100 * time.Millisecond)
+			if got := reloads.Load() == 1; got != tt.reloaded {
+				t.Errorf("reloaded = %v, want %v", got, tt.reloaded)
+			}
+		})
+	}
+}
+
+func TestReloadOnDelete_MovedAway(t *testing.T) {
+	root := t.TempDir()
+	trash := t.TempDir()
+	css := filepath.Join(root, "app.css")
+	if err := os.WriteFile(css, []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &eventRecorder{}
+	reloaded := make(chan struct{}, 1)
+	exit := make(chan bool)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		BrowserReload:      func() error { reloaded <- struct{}{}; return nil },
+		ReloadOnDelete:     true,
+		Logger:             func(message ...any) {},
+		ExitChan:           exit,
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	defer func() {
+		close(exit)
+		wg.Wait()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	if err := os.Rename(css, filepath.Join(trash, "app.css")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a reload after the asset was moved away")
+	}
+	events := handler.received()
+	if len(events) == 0 || events[len(events)-1] != "app.css:remove" {
+		t.Errorf("expected the move to reach handlers as a removal, got %v", events)
+	}
+}
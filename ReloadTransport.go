@@ -0,0 +1,116 @@
+package devwatch
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReloadMessage describes a browser reload to the ReloadTransports, so
+// clients can choose between a full reload, swapping stylesheets or doing
+// nothing, eg: when only server templates or sources of a failed build changed
+type ReloadMessage struct {
+	Paths   []string       `json:"paths,omitempty"`   // handled since the last reload, relative to AppRootDir; empty: reload everything
+	Changes []ReloadChange `json:"changes,omitempty"` // the same files with their type and event
+	Rebuild bool           `json:"rebuild,omitempty"` // Go code was rebuilt (server binary or wasm): only a full reload applies it
+	Forced  bool           `json:"forced,omitempty"`  // sent by ForceReload
+	Time    time.Time      `json:"time"`
+}
+
+// ReloadChange is a file of a ReloadMessage
+type ReloadChange struct {
+	Path    string `json:"path"`              // relative to AppRootDir
+	Type    string `json:"type"`              // "css", "js", "image", "html", "wasm", "go" or "other"
+	Event   string `json:"event"`             // last event: create, write, remove or rename; "write" for outputs of ReloadTargetHandlers
+	Rebuilt bool   `json:"rebuilt,omitempty"` // handlers processed it; false for removals reloading through ReloadOnDelete
+}
+
+// reloadType classifies relPath by extension for ReloadChange.Type
+func reloadType(relPath string) string {
+	switch ext := strings.ToLower(filepath.Ext(relPath)); {
+	case ext == ".css":
+		return "css"
+	case ext == ".js" || ext == ".mjs":
+		return "js"
+	case slices.Contains(imageExtensions, ext):
+		return "image"
+	case ext == ".html" || ext == ".htm":
+		return "html"
+	case ext == ".wasm":
+		return "wasm"
+	case ext == ".go":
+		return "go"
+	}
+	return "other"
+}
+
+// ReloadTransport delivers browser reloads, eg: LiveReload (EventSource),
+// WebSocketReload, ExecReload or CDPReload. Every transport of
+// WatchConfig.ReloadTransports is notified on each reload, after
+// BrowserReload or BrowserReloadPaths.
+type ReloadTransport interface {
+	NotifyReload(msg ReloadMessage) error
+}
+
+// ReloadFunc adapts a callback to a ReloadTransport
+type ReloadFunc func(msg ReloadMessage) error
+
+// NotifyReload calls f
+func (f ReloadFunc) NotifyReload(msg ReloadMessage) error { return f(msg) }
+
+// NotifyReload reloads the pages affected by msg.Paths, see ReloadPaths
+func (lr *LiveReload) NotifyReload(msg ReloadMessage) error { return lr.ReloadPaths(msg.Paths) }
+
+// ExecReload is a ReloadTransport running a command on each reload, eg: a
+// browser CLI or an editor preview refresh. The changed paths are passed
+// newline separated in DEVWATCH_PATHS.
+type ExecReload struct {
+	Command []string  // eg: []string{"osascript", "-e", `tell application "Safari" to do JavaScript "location.reload()" in front document`}
+	Dir     string    // working directory, usually AppRootDir
+	Env     []string  // extra environment
+	Output  io.Writer // also receives the command stdout and stderr (always reported in errors)
+}
+
+// NotifyReload runs Command
+func (e *ExecReload) NotifyReload(msg ReloadMessage) error {
+	if len(e.Command) == 0 {
+		return errors.New("ExecReload: empty Command")
+	}
+	cmd := exec.Command(e.Command[0], e.Command[1:]...)
+	cmd.Dir = e.Dir
+	cmd.Env = append(append(os.Environ(), e.Env...), "DEVWATCH_PATHS="+strings.Join(msg.Paths, "\n"))
+	_, err := runCommand(cmd, "ExecReload", e.Output)
+	return err
+}
+
+// reloadConfigured reports whether a reload reaches anything
+func (h *DevWatch) reloadConfigured() bool {
+	return h.BrowserReload != nil || h.BrowserReloadPaths != nil || len(h.ReloadTransports) > 0
+}
+
+// notifyTransports sends the reload to every ReloadTransport; a failing
+// transport doesn't keep the others from reloading
+func (h *DevWatch) notifyTransports(changes []ReloadChange, paths []string, forced bool) error {
+	if len(h.ReloadTransports) == 0 {
+		return nil
+	}
+	msg := ReloadMessage{Paths: paths, Changes: changes, Forced: forced, Time: time.Now()}
+	for _, change := range changes {
+		if change.Rebuilt && (change.Type == "go" || change.Type == "wasm") {
+			msg.Rebuild = true
+		}
+	}
+	var errs []error
+	for _, t := range h.ReloadTransports {
+		if err := t.NotifyReload(msg); err != nil {
+			h.say("reload-transport-error", t, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,125 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReloadTransports(t *testing.T) {
+	var mu sync.Mutex
+	var got []ReloadMessage
+	record := ReloadFunc(func(msg ReloadMessage) error {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, msg)
+		return nil
+	})
+	var callbacks int
+	w := New(&WatchConfig{
+		BrowserReload: func() error { callbacks++; return nil },
+		ReloadTransports: []ReloadTransport{
+			ReloadFunc(func(ReloadMessage) error { return errors.New("browser gone") }),
+			record,
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	w.changed.add("web/public/main.js", "write", true)
+	w.triggerBrowserReload()
+	if err := w.ForceReload(); err == nil || !strings.Contains(err.Error(), "browser gone") {
+		t.Errorf("expected ForceReload to return the transport error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if callbacks != 2 {
+		t.Errorf("expected BrowserReload to run with the transports, got %d calls", callbacks)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected the failing transport not to stop the others, got %d messages", len(got))
+	}
+	if !slices.Equal(got[0].Paths, []string{"web/public/main.js"}) || got[0].Forced || got[0].Time.IsZero() {
+		t.Errorf("unexpected reload message: %+v", got[0])
+	}
+	if !got[1].Forced {
+		t.Errorf("expected ForceReload message to be forced: %+v", got[1])
+	}
+}
+
+func TestReloadTransports_Only(t *testing.T) {
+	var calls int
+	w := New(&WatchConfig{
+		ReloadTransports: []ReloadTransport{ReloadFunc(func(ReloadMessage) error { calls++; return nil })},
+		Logger:           func(message ...any) { t.Log(message...) },
+	})
+	w.triggerBrowserReload()
+	if calls != 1 {
+		t.Errorf("expected a transport without BrowserReload to reload, got %d calls", calls)
+	}
+}
+
+func TestExecReload(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	out := filepath.Join(t.TempDir(), "paths")
+	e := &ExecReload{Command: []string{"sh", "-c", `printf '%s' "$DEVWATCH_PATHS" > "$OUT"`}, Env: []string{"OUT=" + out}}
+	if err := e.NotifyReload(ReloadMessage{Paths: []string{"a.css", "b.js"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(out)
+	if string(data) != "a.css\nb.js" {
+		t.Errorf("DEVWATCH_PATHS = %q", data)
+	}
+
+	e = &ExecReload{Command: []string{"sh", "-c", "echo no browser; exit 1"}}
+	var execErr *ExecError
+	if err := e.NotifyReload(ReloadMessage{}); !errors.As(err, &execErr) || execErr.Output != "no browser" {
+		t.Errorf("expected an ExecError with the command output, got %v", err)
+	}
+}
+
+func TestReloadMessage_Changes(t *testing.T) {
+	messages := make(chan ReloadMessage, 4)
+	w := New(&WatchConfig{
+		AppRootDir:         t.TempDir(),
+		FilesEventHandlers: []FilesEventHandlers{&recordingHandler{}},
+		ReloadTransports:   []ReloadTransport{ReloadFunc(func(msg ReloadMessage) error { messages <- msg; return nil })},
+		Logger:             func(message ...any) {},
+	})
+	css := filepath.Join(w.AppRootDir, "web", "app.css")
+	os.MkdirAll(filepath.Dir(css), 0755)
+	os.WriteFile(css, []byte("a {}"), 0644)
+
+	w.handleFileEvent("app.css", css, "write", false)
+	select {
+	case msg := <-messages:
+		want := []ReloadChange{{Path: "web/app.css", Type: "css", Event: "write", Rebuilt: true}}
+		if !slices.Equal(msg.Changes, want) || msg.Rebuild {
+			t.Errorf("stylesheet change: got %+v rebuild=%v, want %+v", msg.Changes, msg.Rebuild, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a reload message")
+	}
+
+	// a file changing again keeps its last event
+	w.changed.add("web/logo.png", "create", true)
+	w.changed.add("web/logo.png", "write", false)
+	w.changed.add("cmd/server/main.go", "write", true)
+	w.triggerBrowserReload()
+	msg := <-messages
+	want := []ReloadChange{
+		{Path: "web/logo.png", Type: "image", Event: "write", Rebuilt: true},
+		{Path: "cmd/server/main.go", Type: "go", Event: "write", Rebuilt: true},
+	}
+	if !slices.Equal(msg.Changes, want) || !msg.Rebuild || !slices.Equal(msg.Paths, []string{"web/logo.png", "cmd/server/main.go"}) {
+		t.Errorf("got %+v rebuild=%v, want %+v and a rebuild", msg.Changes, msg.Rebuild, want)
+	}
+}
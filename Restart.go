@@ -0,0 +1,104 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchRun is a single execution of the watch loop bound to one fsnotify watcher
+type watchRun struct {
+	watcher *fsnotify.Watcher
+	stop    chan struct{} // closed by Restart to end this run
+	done    chan struct{} // closed when the loop returned
+}
+
+// Restart tears down the fsnotify watcher, creates a new one and registers
+// the directories under AppRootDir again (eg: after the project root changed
+// or the watcher reported a fatal error). Handlers, scheduled tasks, profiles,
+// stats and subscribers are preserved and files are not re-sent to handlers.
+func (h *DevWatch) Restart() error {
+	if h.closing.Load() {
+		return errShuttingDown
+	}
+	h.restartMu.Lock()
+	defer h.restartMu.Unlock()
+
+	// stop the current loop; it finishes the event being handled first
+	h.watcherMu.Lock()
+	old := h.run
+	h.run = nil
+	h.watcherMu.Unlock()
+	if old != nil {
+		close(old.stop)
+		<-old.done
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.New("Restart new watcher: " + err.Error())
+	}
+
+	h.watcherMu.Lock()
+	if h.watcher != nil {
+		h.watcher.Close()
+	}
+	h.watcher = watcher
+	h.watcherMu.Unlock()
+	h.registry.reset()
+
+	// root may have changed: start with a fresh dependency cache
+	h.mainMu.Lock()
+	h.resetDeps()
+	h.mainMu.Unlock()
+
+	h.loadUnobservedFiles()
+	h.registerDirectories()
+
+	go h.runLoop(h.beginRun())
+	h.say("restarted", h.AppRootDir)
+	return nil
+}
+
+// beginRun creates the run for the current watcher
+func (h *DevWatch) beginRun() *watchRun {
+	h.watcherMu.Lock()
+	defer h.watcherMu.Unlock()
+	h.run = &watchRun{
+		watcher: h.watcher,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	return h.run
+}
+
+// currentWatcher returns the fsnotify watcher in use
+func (h *DevWatch) currentWatcher() *fsnotify.Watcher {
+	h.watcherMu.Lock()
+	defer h.watcherMu.Unlock()
+	return h.watcher
+}
+
+// registerDirectories adds every observed directory under the watch roots to the watcher
+func (h *DevWatch) registerDirectories() {
+	for _, root := range h.watchRoots() {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				h.say("path-error", path, err)
+				return nil
+			}
+			if info.IsDir() && h.beyondDepth(path) {
+				return filepath.SkipDir
+			}
+			if info.IsDir() && !h.Contain(path) {
+				h.addDirectoryToWatcher(path)
+			}
+			return nil
+		})
+		if err != nil {
+			h.say("walk-error", err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRestart(t *testing.T) {
+	firstRoot := t.TempDir()
+	secondRoot := t.TempDir()
+
+	var called int32
+	exit := make(chan bool)
+	w := New(&WatchConfig{
+		AppRootDir: firstRoot,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".css"},
+		}},
+		BrowserReload: func() error { return nil },
+		Logger:        func(message ...any) { t.Log(message...) },
+		ExitChan:      exit,
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	time.Sleep(100 * time.Millisecond)
+	before := w.currentWatcher()
+
+	// project root changed
+	w.AppRootDir = secondRoot
+	if err := w.Restart(); err != nil {
+		t.Fatalf("Restart returned error: %v", err)
+	}
+	if w.currentWatcher() == before {
+		t.Fatal("expected a new fsnotify watcher after Restart")
+	}
+
+	if err := os.WriteFile(filepath.Join(secondRoot, "style.css"), []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&called) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&called) == 0 {
+		t.Fatal("expected handler to receive events from the new root after Restart")
+	}
+
+	close(exit)
+	wg.Wait()
+}
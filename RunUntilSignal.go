@@ -0,0 +1,52 @@
+package devwatch
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// shutdownTimeout bounds how long RunUntilSignal waits for handlers on exit
+const shutdownTimeout = 5 * time.Second
+
+// RunUntilSignal starts the watcher and blocks until SIGINT or SIGTERM,
+// then shuts down gracefully. SIGHUP restarts the watcher so the directory
+// tree under AppRootDir and the unobserved files are scanned again.
+// It returns the Shutdown error, if any.
+func (h *DevWatch) RunUntilSignal() error {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	return h.runUntil(sigs)
+}
+
+func (h *DevWatch) runUntil(sigs <-chan os.Signal) error {
+	if h.ExitChan == nil {
+		h.ExitChan = make(chan bool)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go h.FileWatcherStart(&wg)
+
+	for sig := range sigs {
+		if sig == syscall.SIGHUP {
+			h.say("sighup", h.AppRootDir)
+			if err := h.Restart(); err != nil {
+				h.say("sighup-error", err)
+			}
+			continue
+		}
+
+		h.say("signal", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		err := h.Shutdown(ctx)
+		cancel()
+		wg.Wait()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package devwatch
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunUntilSignal(t *testing.T) {
+	w := New(&WatchConfig{
+		AppRootDir:    t.TempDir(),
+		BrowserReload: func() error { return nil },
+		Logger:        func(message ...any) { t.Log(message...) },
+	})
+
+	sigs := make(chan os.Signal, 1)
+	done := make(chan error, 1)
+	go func() { done <- w.runUntil(sigs) }()
+	time.Sleep(100 * time.Millisecond)
+
+	before := w.currentWatcher()
+	sigs <- syscall.SIGHUP
+	deadline := time.Now().Add(time.Second)
+	for w.currentWatcher() == before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if w.currentWatcher() == before {
+		t.Fatal("expected SIGHUP to restart the watcher")
+	}
+
+	sigs <- syscall.SIGTERM
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected shutdown error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("RunUntilSignal did not return after SIGTERM")
+	}
+}
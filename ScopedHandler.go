@@ -0,0 +1,36 @@
+package devwatch
+
+import (
+	"strings"
+)
+
+// ScopedHandler is an optional capability binding a handler to directory
+// scopes relative to AppRootDir, eg: ["web"] so a web assets handler never
+// receives events from "api/". An empty scope list means the whole project.
+type ScopedHandler interface {
+	Scope() []string
+}
+
+// handlerInScope reports whether relPath falls inside the handler's scopes
+func handlerInScope(handler FilesEventHandlers, relPath string) bool {
+	sh, ok := handler.(ScopedHandler)
+	if !ok {
+		return true
+	}
+	return pathInScopes(relPath, sh.Scope())
+}
+
+// pathInScopes reports whether relPath is one of scopes or inside one of them.
+// An empty scope list matches every path.
+func pathInScopes(relPath string, scopes []string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		scope = slashPath(scope)
+		if scope == "" || scope == "." || relPath == scope || strings.HasPrefix(relPath, scope+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// scopedCSSHandler only wants events from its scopes
+type scopedCSSHandler struct {
+	namedCSSHandler
+	scope []string
+}
+
+func (s *scopedCSSHandler) Scope() []string { return s.scope }
+
+func TestScopedHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"web/css", "api"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tempDir, dir, "style.css"), []byte("body {}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	web := &scopedCSSHandler{namedCSSHandler: namedCSSHandler{name: "web"}, scope: []string{"web/"}}
+	global := &namedCSSHandler{name: "global"}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{web, global},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	w.Trigger("web/css/style.css", "write")
+	w.Trigger("api/style.css", "write")
+
+	if web.calls != 1 {
+		t.Errorf("scoped handler calls = %d, want 1", web.calls)
+	}
+	if global.calls != 2 {
+		t.Errorf("unscoped handler calls = %d, want 2", global.calls)
+	}
+}
+
+func TestPathInScopes(t *testing.T) {
+	tests := []struct {
+		path   string
+		scopes []string
+		want   bool
+	}{
+		{"web/app.js", nil, true},
+		{"web/app.js", []string{"web"}, true},
+		{"web", []string{"web"}, true},
+		{"website/app.js", []string{"web"}, false},
+		{"api/main.go", []string{"web", "api"}, true},
+		{"api/main.go", []string{"."}, true},
+	}
+	for _, tt := range tests {
+		if got := pathInScopes(tt.path, tt.scopes); got != tt.want {
+			t.Errorf("pathInScopes(%q, %v) = %v, want %v", tt.path, tt.scopes, got, tt.want)
+		}
+	}
+}
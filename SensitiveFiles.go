@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+// sensitivePatterns are base name patterns (path.Match syntax) of files
+// holding secrets. devwatch never reads their content into diffs or
+// snapshots; handlers still receive their events. WatchConfig.SensitivePatterns
+// adds to them.
+var sensitivePatterns = []string{
+	"*.pem", "*.key", "*.p12", "*.pfx", "*.jks", "*.keystore",
+	"id_rsa", "id_dsa", "id_ecdsa", "id_ed25519",
+	".env", ".env.*",
+}
+
+// IsSensitiveFile reports whether the base name of filePath matches the
+// built-in secret patterns (*.pem, *.key, id_rsa, .env, ...)
+func IsSensitiveFile(filePath string) bool {
+	return matchBase(sensitivePatterns, filePath)
+}
+
+// isSensitive reports whether filePath matches the built-in patterns or
+// WatchConfig.SensitivePatterns
+func (h *DevWatch) isSensitive(filePath string) bool {
+	return IsSensitiveFile(filePath) || matchBase(h.SensitivePatterns, filePath)
+}
+
+// matchBase reports whether the base name of filePath matches one of patterns
+func matchBase(patterns []string, filePath string) bool {
+	base := filepath.Base(filePath)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveWarnings remembers the sensitive files already reported
+type sensitiveWarnings struct {
+	once sync.Map // path -> struct{}
+}
+
+// warnSensitive logs once per path when WarnSensitiveFiles is set and an
+// observed file looks like a secret
+func (h *DevWatch) warnSensitive(filePath string) {
+	if !h.WarnSensitiveFiles || !h.isSensitive(filePath) {
+		return
+	}
+	if _, seen := h.sensitive.once.LoadOrStore(filePath, struct{}{}); !seen {
+		h.say("sensitive-file", h.relativePath(filePath))
+	}
+}
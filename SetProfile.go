@@ -0,0 +1,65 @@
+package devwatch
+
+import (
+	"errors"
+	"slices"
+)
+
+// WatchProfile selects a subset of handlers and paths, eg: a "frontend"
+// profile that only runs asset handlers for files under "web/".
+type WatchProfile struct {
+	Handlers []string // handler names (see NamedHandler) enabled in this profile, empty = all
+	Paths    []string // directories relative to AppRootDir events must be under, empty = all
+}
+
+// SetProfile switches the active watch profile at runtime. An empty name
+// restores the full profile (all handlers, all paths).
+func (h *DevWatch) SetProfile(name string) error {
+	if name != "" {
+		if _, ok := h.Profiles[name]; !ok {
+			return errors.New("SetProfile unknown profile: " + name)
+		}
+	}
+	h.profileMu.Lock()
+	h.activeProfile = name
+	h.profileMu.Unlock()
+	h.say("watch-profile", h.ActiveProfile())
+	return nil
+}
+
+// ActiveProfile returns the name of the active profile ("full" when none is set)
+func (h *DevWatch) ActiveProfile() string {
+	h.profileMu.RLock()
+	defer h.profileMu.RUnlock()
+	if h.activeProfile == "" {
+		return "full"
+	}
+	return h.activeProfile
+}
+
+// currentProfile returns the active profile or nil for the full profile
+func (h *DevWatch) currentProfile() *WatchProfile {
+	h.profileMu.RLock()
+	defer h.profileMu.RUnlock()
+	if h.activeProfile == "" {
+		return nil
+	}
+	p := h.Profiles[h.activeProfile]
+	return &p
+}
+
+// allowsPath reports whether filePath is inside the profile path scopes
+func (p *WatchProfile) allowsPath(relPath string) bool {
+	if p == nil {
+		return true
+	}
+	return pathInScopes(relPath, p.Paths)
+}
+
+// allowsHandler reports whether handler is enabled in the profile
+func (p *WatchProfile) allowsHandler(handler FilesEventHandlers) bool {
+	if p == nil || len(p.Handlers) == 0 {
+		return true
+	}
+	return slices.Contains(p.Handlers, handlerName(handler))
+}
@@ -0,0 +1,72 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// namedCSSHandler counts events and exposes a name for profiles
+type namedCSSHandler struct {
+	name  string
+	calls int32
+}
+
+func (n *namedCSSHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	atomic.AddInt32(&n.calls, 1)
+	return nil
+}
+
+func (n *namedCSSHandler) Name() string                      { return n.name }
+func (n *namedCSSHandler) SupportedExtensions() []string     { return []string{".css"} }
+func (n *namedCSSHandler) MainInputFileRelativePath() string { return "" }
+func (n *namedCSSHandler) UnobservedFiles() []string         { return nil }
+
+func TestSetProfile(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"web", "api"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tempDir, dir, "style.css"), []byte("body {}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assets := &namedCSSHandler{name: "assets"}
+	docs := &namedCSSHandler{name: "docs"}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{assets, docs},
+		Profiles: map[string]WatchProfile{
+			"frontend": {Handlers: []string{"assets"}, Paths: []string{"web"}},
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	if got := w.ActiveProfile(); got != "full" {
+		t.Errorf("ActiveProfile = %q, want full", got)
+	}
+	if err := w.SetProfile("backend"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+	if err := w.SetProfile("frontend"); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Trigger("web/style.css", "write")
+	w.Trigger("api/style.css", "write")
+	if assets.calls != 1 || docs.calls != 0 {
+		t.Errorf("frontend profile: assets=%d docs=%d, want 1 and 0", assets.calls, docs.calls)
+	}
+
+	// back to full profile
+	if err := w.SetProfile(""); err != nil {
+		t.Fatal(err)
+	}
+	w.Trigger("api/style.css", "write")
+	if assets.calls != 2 || docs.calls != 1 {
+		t.Errorf("full profile: assets=%d docs=%d, want 2 and 1", assets.calls, docs.calls)
+	}
+}
@@ -0,0 +1,48 @@
+package devwatch
+
+// SharedWorkHandler is an optional capability for handlers that perform the
+// same underlying action as other handlers (eg: two handlers building the same
+// main input). Handlers returning the same non-empty WorkKey for the same main
+// input run only once per save of a given file content; the others reuse the
+// outcome of the first one.
+type SharedWorkHandler interface {
+	WorkKey() string // eg: "go-build"
+}
+
+// sharedWork deduplicates handler work within a single event dispatch
+type sharedWork struct {
+	hash    string
+	results map[string]error
+}
+
+// key returns the dedup key for handler, or "" when the handler does not share work
+func (s *sharedWork) key(h *DevWatch, handler FilesEventHandlers, filePath string) string {
+	sw, ok := handler.(SharedWorkHandler)
+	if !ok || sw.WorkKey() == "" {
+		return ""
+	}
+	if s.hash == "" {
+		s.hash = h.calculateFileHash(filePath)
+	}
+	return sw.WorkKey() + "|" + handlerMain(handler) + "|" + s.hash
+}
+
+// lookup returns the recorded outcome for key, if the work already ran
+func (s *sharedWork) lookup(key string) (err error, done bool) {
+	if key == "" || s.results == nil {
+		return nil, false
+	}
+	err, done = s.results[key]
+	return err, done
+}
+
+// record stores the outcome of the work identified by key
+func (s *sharedWork) record(key string, err error) {
+	if key == "" {
+		return
+	}
+	if s.results == nil {
+		s.results = make(map[string]error)
+	}
+	s.results[key] = err
+}
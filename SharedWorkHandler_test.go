@@ -0,0 +1,60 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// sharedBuildHandler counts builds and shares them by work key
+type sharedBuildHandler struct {
+	key    string
+	main   string
+	builds *int32
+}
+
+func (s *sharedBuildHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	atomic.AddInt32(s.builds, 1)
+	return nil
+}
+
+func (s *sharedBuildHandler) WorkKey() string                   { return s.key }
+func (s *sharedBuildHandler) SupportedExtensions() []string     { return []string{".js"} }
+func (s *sharedBuildHandler) MainInputFileRelativePath() string { return s.main }
+func (s *sharedBuildHandler) UnobservedFiles() []string         { return nil }
+
+func TestSharedWorkHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	jsFile := filepath.Join(tempDir, "main.js")
+	if err := os.WriteFile(jsFile, []byte("console.log(1)"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var builds int32
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		FilesEventHandlers: []FilesEventHandlers{
+			&sharedBuildHandler{key: "bundle", main: "main.js", builds: &builds},
+			&sharedBuildHandler{key: "bundle", main: "main.js", builds: &builds},
+			&sharedBuildHandler{key: "bundle", main: "other.js", builds: &builds}, // different main input
+			&sharedBuildHandler{key: "", main: "main.js", builds: &builds},        // does not share work
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	if err := w.Trigger(jsFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&builds); got != 3 {
+		t.Errorf("expected 3 builds for one save, got %d", got)
+	}
+
+	// the next save runs the shared work again
+	if err := w.Trigger(jsFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&builds); got != 6 {
+		t.Errorf("expected 6 builds after second save, got %d", got)
+	}
+}
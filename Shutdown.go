@@ -0,0 +1,80 @@
+package devwatch
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errShuttingDown is returned by Trigger once Shutdown started
+var errShuttingDown = errors.New("devwatch is shutting down")
+
+// shutdownPoll is how often Shutdown checks for in-flight handlers
+const shutdownPoll = 10 * time.Millisecond
+
+// Shutdown stops the watcher gracefully: new events are ignored, in-flight
+// handlers (including AsyncFileEventHandler work) are drained until ctx is
+// done, a pending browser reload is flushed when the drain completed or
+// cancelled when it didn't, and finally the fsnotify watcher is closed and
+// FileWatcherStart returns. It returns ctx.Err() when the deadline expired
+// before handlers finished.
+func (h *DevWatch) Shutdown(ctx context.Context) error {
+	if !h.closing.CompareAndSwap(false, true) {
+		return errShuttingDown
+	}
+	h.say("shutdown")
+
+	// events still grouped by SaveAllWindow, handled under the drain deadline
+	h.async.add()
+	go func() {
+		defer h.async.done()
+		h.flushSaves(-1)
+	}()
+	drainErr := h.drain(ctx)
+
+	// explicit pending reload policy: flush only if everything completed
+	h.reloadMutex.Lock()
+	pending := h.reloadTimer != nil && h.reloadTimer.Stop()
+	h.reloadsClosed = true
+	h.reloadMutex.Unlock()
+	if pending {
+		if drainErr == nil {
+			h.triggerBrowserReload()
+		} else {
+			h.say("shutdown-reload", drainErr)
+		}
+	}
+
+	h.stopOnce.Do(func() { close(h.stopChan()) })
+	if watcher := h.currentWatcher(); watcher != nil {
+		watcher.Close()
+	}
+	h.stopScheduler()
+	return drainErr
+}
+
+// drain waits until no handler dispatch or async work is in flight
+func (h *DevWatch) drain(ctx context.Context) error {
+	ticker := time.NewTicker(shutdownPoll)
+	defer ticker.Stop()
+	for {
+		if h.dispatching.Load() == 0 && h.queue.len() == 0 && h.async.wait(0) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopChan returns the channel closed by Shutdown
+func (h *DevWatch) stopChan() chan struct{} {
+	h.stopMu.Lock()
+	defer h.stopMu.Unlock()
+	if h.stop == nil {
+		h.stop = make(chan struct{})
+	}
+	return h.stop
+}
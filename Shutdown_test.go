@@ -0,0 +1,133 @@
+package devwatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var reloads int32
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&slowCSSHandler{delay: 100 * time.Millisecond}},
+		BrowserReload: func() error {
+			atomic.AddInt32(&reloads, 1)
+			return nil
+		},
+		Logger:   func(message ...any) { t.Log(message...) },
+		ExitChan: make(chan bool),
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	time.Sleep(100 * time.Millisecond)
+
+	// handler in flight while Shutdown starts
+	go w.Trigger("style.css", "write")
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := w.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if atomic.LoadInt32(&reloads) != 1 {
+		t.Errorf("expected pending reload to be flushed once, got %d", reloads)
+	}
+
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("FileWatcherStart did not return after Shutdown")
+	}
+
+	if err := w.Trigger("style.css", "write"); !errors.Is(err, errShuttingDown) {
+		t.Errorf("expected Trigger to be rejected after Shutdown, got %v", err)
+	}
+}
+
+func TestShutdown_DeadlineCancelsReload(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var reloads int32
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&slowCSSHandler{delay: 300 * time.Millisecond}},
+		BrowserReload: func() error {
+			atomic.AddInt32(&reloads, 1)
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	// handler still running when the deadline expires
+	go w.Trigger("style.css", "write")
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if err := w.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	// handler completes after Shutdown returned and must not reload
+	time.Sleep(400 * time.Millisecond)
+	if atomic.LoadInt32(&reloads) != 0 {
+		t.Errorf("expected pending reload to be cancelled, got %d reloads", reloads)
+	}
+}
+
+// slowBatch is a BatchHandler taking delay per transaction
+type slowBatch struct {
+	batchRecorder
+	delay time.Duration
+}
+
+func (b *slowBatch) NewFileChanges(changes []FileChange) error {
+	time.Sleep(b.delay)
+	return b.batchRecorder.NewFileChanges(changes)
+}
+
+func TestShutdown_DeadlineBoundsSaveAll(t *testing.T) {
+	tempDir := t.TempDir()
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&slowBatch{delay: 500 * time.Millisecond}},
+		SaveAllWindow:      time.Minute,
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+	for _, name := range []string{"a.css", "b.css"} {
+		path := filepath.Join(tempDir, name)
+		os.WriteFile(path, []byte(name), 0644)
+		w.queueSave(name, path, "write", false)
+	}
+
+	// the grouped saves are still building when the deadline expires
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := w.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("expected Shutdown to return at the deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,70 @@
+package devwatch
+
+import (
+	"cmp"
+	"context"
+	"runtime/pprof"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxInvocationSamples bounds the handler invocations kept for SlowestHandlers
+const maxInvocationSamples = 256
+
+// HandlerInvocation is a handler call and how long it took
+type HandlerInvocation struct {
+	Handler  string
+	Path     string
+	Duration time.Duration
+	At       time.Time // call start
+}
+
+// invocations keeps the most recent handler calls
+type invocations struct {
+	mu     sync.Mutex
+	recent []HandlerInvocation
+}
+
+func (i *invocations) add(inv HandlerInvocation) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.recent) == maxInvocationSamples {
+		i.recent = i.recent[1:]
+	}
+	i.recent = append(i.recent, inv)
+}
+
+// SlowestHandlers returns the n slowest of the recent handler invocations,
+// slowest first
+func (h *DevWatch) SlowestHandlers(n int) []HandlerInvocation {
+	h.calls.mu.Lock()
+	slowest := slices.Clone(h.calls.recent)
+	h.calls.mu.Unlock()
+
+	slices.SortStableFunc(slowest, func(a, b HandlerInvocation) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+	return slowest[:min(max(n, 0), len(slowest))]
+}
+
+// profileHandler runs call with pprof labels naming the handler and path
+// when ProfileLabels is set, then records its duration and warns when it
+// exceeded SlowHandlerThreshold
+func (h *DevWatch) profileHandler(ctx context.Context, name, path string, call func() error) (err error) {
+	start := time.Now()
+	if h.ProfileLabels {
+		pprof.Do(ctx, pprof.Labels("devwatch.handler", name, "devwatch.path", h.relativePath(path)), func(context.Context) {
+			err = call()
+		})
+	} else {
+		err = call()
+	}
+
+	d := time.Since(start)
+	h.calls.add(HandlerInvocation{Handler: name, Path: path, Duration: d, At: start})
+	if h.SlowHandlerThreshold > 0 && d > h.SlowHandlerThreshold {
+		h.say("slow-handler", name, d.Round(time.Millisecond), path)
+	}
+	return err
+}
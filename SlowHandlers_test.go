@@ -0,0 +1,60 @@
+package devwatch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlowHandlers(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.css", "b.css"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("body {}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir:           root,
+		ProfileLabels:        true,
+		SlowHandlerThreshold: 20 * time.Millisecond,
+		Logger:               func(message ...any) { logs = append(logs, fmt.Sprint(message...)) },
+	})
+	_, err := w.Handle(".css").Name("assets").OnEvent(func(fileName, extension, filePath, event string) error {
+		if fileName == "b.css" {
+			time.Sleep(30 * time.Millisecond)
+		}
+		return nil
+	}).Register()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.css", "b.css", "a.css"} {
+		if err := w.Trigger(name, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	slowest := w.SlowestHandlers(1)
+	if len(slowest) != 1 || slowest[0].Handler != "assets" || filepath.Base(slowest[0].Path) != "b.css" || slowest[0].Duration < 30*time.Millisecond {
+		t.Errorf("unexpected slowest handler %+v", slowest)
+	}
+	if got := len(w.SlowestHandlers(100)); got != 3 {
+		t.Errorf("expected 3 recorded invocations, got %d", got)
+	}
+
+	warnings := 0
+	for _, l := range logs {
+		if strings.Contains(l, "slow handler") {
+			warnings++
+		}
+	}
+	if warnings != 1 {
+		t.Errorf("expected one slow handler warning, got %d in %v", warnings, logs)
+	}
+}
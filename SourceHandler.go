@@ -0,0 +1,42 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SourceHandler is an optional capability binding a handler to the event
+// sources named by WatchConfig.RootSources, eg: ["api"] so a Go build of
+// the backend never receives events from the "frontend" root. An empty
+// list means every source.
+type SourceHandler interface {
+	Sources() []string
+}
+
+// sourceOf returns the RootSources name of path: the one of the deepest
+// directory containing it, "" when none does
+func (h *DevWatch) sourceOf(path string) string {
+	if len(h.RootSources) == 0 || path == "" {
+		return ""
+	}
+	// the file may be removed already: resolve its directory only
+	canonical := canonicalPath(filepath.Dir(path)) + "/" + filepath.Base(path)
+	source, depth := "", -1
+	for dir, name := range h.RootSources {
+		key := canonicalPath(h.absPath(dir))
+		if (canonical == key || strings.HasPrefix(canonical, strings.TrimSuffix(key, "/")+"/")) && len(key) > depth {
+			source, depth = name, len(key)
+		}
+	}
+	return source
+}
+
+// handlerForSource reports whether handler receives the events of source
+func handlerForSource(handler FilesEventHandlers, source string) bool {
+	sh, ok := handler.(SourceHandler)
+	if !ok || len(sh.Sources()) == 0 {
+		return true
+	}
+	return slices.Contains(sh.Sources(), source)
+}
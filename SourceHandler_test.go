@@ -0,0 +1,70 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sourceRecorder captures the FileChanges of its sources
+type sourceRecorder struct {
+	changeRecorder
+	sources []string
+}
+
+func (s *sourceRecorder) Sources() []string { return s.sources }
+
+func TestRootSources(t *testing.T) {
+	tmp := t.TempDir()
+	app, api := filepath.Join(tmp, "app"), filepath.Join(tmp, "api")
+	files := map[string]string{
+		"app":      filepath.Join(app, "index.html"),
+		"frontend": filepath.Join(app, "web", "index.html"),
+		"api":      filepath.Join(api, "docs.html"),
+	}
+	for _, file := range files {
+		os.MkdirAll(filepath.Dir(file), 0755)
+		os.WriteFile(file, []byte("<html></html>"), 0644)
+	}
+
+	all, apiOnly := &changeRecorder{}, &sourceRecorder{sources: []string{"api"}}
+	w := New(&WatchConfig{
+		AppRootDir:         app,
+		ExtraRootDirs:      []string{api},
+		RootSources:        map[string]string{".": "app", "web": "frontend", api: "api"},
+		FilesEventHandlers: []FilesEventHandlers{all, apiOnly},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+	records, unsubscribe := w.Subscribe()
+	defer unsubscribe()
+
+	for _, source := range []string{"app", "frontend", "api"} {
+		if err := w.Trigger(files[source], "write"); err != nil {
+			t.Fatal(err)
+		}
+		if rec := <-records; rec.Source != source {
+			t.Errorf("record of %s: got source %q, want %q", files[source], rec.Source, source)
+		}
+	}
+
+	var got []string
+	for _, change := range all.changes {
+		got = append(got, change.Source)
+	}
+	if !slices.Equal(got, []string{"app", "frontend", "api"}) {
+		t.Errorf("expected the deepest RootSources entry to name each file, got %v", got)
+	}
+	if len(apiOnly.changes) != 1 || apiOnly.changes[0].FilePath != files["api"] {
+		t.Errorf("expected the api handler to receive only the api file, got %v", apiOnly.changes)
+	}
+	if matches := w.MatchHandlers(files["frontend"]); len(matches) != 2 || matches[1].Matched || !strings.Contains(matches[1].Reason, "source frontend") {
+		t.Errorf("expected MatchHandlers to explain the source mismatch, got %+v", matches)
+	}
+
+	apiOnly.sources = []string{"backend"}
+	if errs := w.Validate(); !slices.ContainsFunc(errs, func(err error) bool { return strings.Contains(err.Error(), "source backend") }) {
+		t.Errorf("expected Validate to report the unknown source, got %v", errs)
+	}
+}
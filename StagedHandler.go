@@ -0,0 +1,164 @@
+package devwatch
+
+import "slices"
+
+// StagedHandler is an optional capability that places a FilesEventHandler in a
+// named pipeline stage (eg: "codegen", "compile", "bundle") and declares which
+// stages must run before it. For each event, handlers are executed in
+// dependency order and a handler is skipped when any stage it depends on
+// failed for that event. Handlers without a stage keep their registration order.
+//
+// A stage other handlers depend on must report its result during the event:
+// AsyncFileEventHandlers, coalescing DebouncedHandlers and, with
+// SaveAllWindow, BatchHandlers can't be in it. AddFilesEventHandlers rejects
+// a handler completing such a combination and Validate reports it.
+type StagedHandler interface {
+	Stage() string       // eg: "compile"
+	DependsOn() []string // eg: ["codegen"]
+}
+
+// handlerStage returns the stage and dependencies declared by handler, if any
+func handlerStage(handler FilesEventHandlers) (stage string, deps []string) {
+	if sh, ok := handler.(StagedHandler); ok {
+		return sh.Stage(), sh.DependsOn()
+	}
+	return "", nil
+}
+
+// orderHandlers sorts handlers topologically by their stage dependencies.
+// The sort is stable: among handlers that are ready to run, registration order
+// wins. Dependencies on stages no handler declares are ignored. On a cycle,
+// the remaining handlers are appended in registration order; the cycle is
+// reported once by Validate and AddFilesEventHandlers, not per event.
+func (h *DevWatch) orderHandlers(handlers []FilesEventHandlers) []FilesEventHandlers {
+	order, _ := stageOrder(handlers)
+	ordered := make([]FilesEventHandlers, len(order))
+	for i, id := range order {
+		ordered[i] = handlers[id]
+	}
+	return ordered
+}
+
+// orderedIDs is orderHandlers returning registration indexes. The index
+// identifies a handler in per handler state: handlers of non comparable
+// types (eg: a struct value holding a slice) can't key a map.
+func orderedIDs(handlers []FilesEventHandlers) []int {
+	order, _ := stageOrder(handlers)
+	return order
+}
+
+// stageCycle reports whether the stage dependencies of handlers form a cycle
+func stageCycle(handlers []FilesEventHandlers) bool {
+	_, cycle := stageOrder(handlers)
+	return cycle
+}
+
+// stageOrder returns the indexes of handlers in stage order, also
+// reporting a dependency cycle
+func stageOrder(handlers []FilesEventHandlers) ([]int, bool) {
+	// count pending handlers per stage so a stage is complete only when all
+	// of its handlers have been emitted
+	pending := make(map[string]int)
+	staged := false
+	for _, handler := range handlers {
+		stage, deps := handlerStage(handler)
+		if stage != "" {
+			pending[stage]++
+		}
+		if stage != "" || len(deps) > 0 {
+			staged = true
+		}
+	}
+
+	ordered := make([]int, 0, len(handlers))
+	if !staged {
+		for i := range handlers {
+			ordered = append(ordered, i)
+		}
+		return ordered, false
+	}
+	emitted := make([]bool, len(handlers))
+
+	for len(ordered) < len(handlers) {
+		progress := false
+		for i, handler := range handlers {
+			if emitted[i] {
+				continue
+			}
+			stage, deps := handlerStage(handler)
+			ready := true
+			for _, dep := range deps {
+				if dep != stage && pending[dep] > 0 {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+			emitted[i] = true
+			ordered = append(ordered, i)
+			if stage != "" {
+				pending[stage]--
+			}
+			progress = true
+		}
+
+		if !progress {
+			for i := range handlers {
+				if !emitted[i] {
+					ordered = append(ordered, i)
+				}
+			}
+			return ordered, true
+		}
+	}
+
+	return ordered, false
+}
+
+// upstreamFailed reports whether any stage handler depends on failed in the current event
+func upstreamFailed(handler FilesEventHandlers, failedStages map[string]bool) bool {
+	_, deps := handlerStage(handler)
+	return slices.ContainsFunc(deps, func(dep string) bool { return failedStages[dep] })
+}
+
+// deferredResult reports whether the outcome of handler is only known after
+// the event: async, coalesced by its debounce policy or batched by
+// SaveAllWindow. Background handlers never count for stages.
+func (h *DevWatch) deferredResult(handler FilesEventHandlers) bool {
+	if isBackground(handler) {
+		return false
+	}
+	if _, ok := handler.(AsyncFileEventHandler); ok {
+		return true
+	}
+	if dh, ok := handler.(DebouncedHandler); ok {
+		if policy, quiet := dh.Debounce(); policy != EveryEvent && quiet > 0 {
+			return true
+		}
+	}
+	_, batch := handler.(BatchHandler)
+	return batch && h.SaveAllWindow > 0
+}
+
+// deferredUpstream returns the handlers of handlers with a deferred result
+// in a stage others depend on: their failure could not skip the dependents
+func (h *DevWatch) deferredUpstream(handlers []FilesEventHandlers) []FilesEventHandlers {
+	needed := make(map[string]bool)
+	for _, handler := range handlers {
+		stage, deps := handlerStage(handler)
+		for _, dep := range deps {
+			if dep != stage {
+				needed[dep] = true
+			}
+		}
+	}
+	var upstream []FilesEventHandlers
+	for _, handler := range handlers {
+		if stage, _ := handlerStage(handler); stage != "" && needed[stage] && h.deferredResult(handler) {
+			upstream = append(upstream, handler)
+		}
+	}
+	return upstream
+}
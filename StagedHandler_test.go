@@ -0,0 +1,169 @@
+package devwatch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// stageHandler records its invocations in a shared order log
+type stageHandler struct {
+	stage string
+	deps  []string
+	fail  bool
+	mu    *sync.Mutex
+	order *[]string
+}
+
+func (s *stageHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	s.mu.Lock()
+	*s.order = append(*s.order, s.stage)
+	s.mu.Unlock()
+	if s.fail {
+		return errors.New(s.stage + " failed")
+	}
+	return nil
+}
+
+func (s *stageHandler) Stage() string                     { return s.stage }
+func (s *stageHandler) DependsOn() []string               { return s.deps }
+func (s *stageHandler) SupportedExtensions() []string     { return []string{".proto"} }
+func (s *stageHandler) MainInputFileRelativePath() string { return "" }
+func (s *stageHandler) UnobservedFiles() []string         { return nil }
+
+func TestStagedHandlers(t *testing.T) {
+	tempDir := t.TempDir()
+	protoFile := filepath.Join(tempDir, "api.proto")
+	if err := os.WriteFile(protoFile, []byte("syntax = \"proto3\";"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	codegen := &stageHandler{stage: "codegen", mu: &mu, order: &order}
+	compile := &stageHandler{stage: "compile", deps: []string{"codegen"}, mu: &mu, order: &order}
+	bundle := &stageHandler{stage: "bundle", deps: []string{"compile"}, mu: &mu, order: &order}
+
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		// registered in reverse order on purpose
+		FilesEventHandlers: []FilesEventHandlers{bundle, compile, codegen},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	t.Run("dependency order", func(t *testing.T) {
+		order = nil
+		if err := w.Trigger(protoFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"codegen", "compile", "bundle"}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("got order %v, want %v", order, want)
+		}
+	})
+
+	t.Run("upstream failure skips downstream", func(t *testing.T) {
+		order = nil
+		codegen.fail = true
+		defer func() { codegen.fail = false }()
+		if err := w.Trigger(protoFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"codegen"}
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("got order %v, want %v", order, want)
+		}
+	})
+
+	t.Run("cycle falls back to registration order", func(t *testing.T) {
+		a := &stageHandler{stage: "a", deps: []string{"b"}, mu: &mu, order: &order}
+		b := &stageHandler{stage: "b", deps: []string{"a"}, mu: &mu, order: &order}
+		got := w.orderHandlers([]FilesEventHandlers{a, b})
+		if len(got) != 2 || got[0] != a || got[1] != b {
+			t.Errorf("expected registration order on cycle")
+		}
+	})
+}
+
+func TestStagedHandlers_CycleLoggedOnce(t *testing.T) {
+	tempDir := t.TempDir()
+	protoFile := filepath.Join(tempDir, "api.proto")
+	if err := os.WriteFile(protoFile, []byte("syntax = \"proto3\";"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	cycles := 0
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		Logger: func(message ...any) {
+			if strings.Contains(fmt.Sprint(message...), "dependency cycle") {
+				cycles++
+			}
+		},
+	})
+	w.AddFilesEventHandlers(
+		&stageHandler{stage: "a", deps: []string{"b"}, mu: &mu, order: &order},
+		&stageHandler{stage: "b", deps: []string{"a"}, mu: &mu, order: &order},
+	)
+	for range 3 {
+		if err := w.Trigger(protoFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cycles != 1 {
+		t.Errorf("expected the cycle logged once when registered, got %d", cycles)
+	}
+	if len(order) != 6 {
+		t.Errorf("expected both handlers to run on every event, got %v", order)
+	}
+}
+
+// asyncStage is a stage handler reporting its result after the event
+type asyncStage struct{ stageHandler }
+
+func (a *asyncStage) NewFileEventAsync(fileName, extension, filePath, event string) <-chan error {
+	ch := make(chan error, 1)
+	ch <- a.NewFileEvent(fileName, extension, filePath, event)
+	return ch
+}
+
+func TestStagedHandlers_DeferredUpstream(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	codegen := &asyncStage{stageHandler{stage: "codegen", mu: &mu, order: &order}}
+	compile := &stageHandler{stage: "compile", deps: []string{"codegen"}, mu: &mu, order: &order}
+
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir: t.TempDir(),
+		Logger:     func(message ...any) { logs = append(logs, fmt.Sprint(message...)) },
+	})
+	w.AddFilesEventHandlers(codegen, compile)
+	if len(w.FilesEventHandlers) != 1 || w.FilesEventHandlers[0] != codegen {
+		t.Errorf("expected the dependent of an async stage rejected, got %v", w.FilesEventHandlers)
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], "rejected") {
+		t.Errorf("expected the rejection logged, got %v", logs)
+	}
+
+	// configured handlers are reported by Validate
+	w = New(&WatchConfig{AppRootDir: t.TempDir(), FilesEventHandlers: []FilesEventHandlers{codegen, compile}})
+	if !slices.ContainsFunc(w.Validate(), func(err error) bool { return strings.Contains(err.Error(), "stage codegen has dependents") }) {
+		t.Errorf("expected Validate to report the async stage, got %v", w.Validate())
+	}
+
+	// without dependents an async stage is fine
+	w = New(&WatchConfig{AppRootDir: t.TempDir(), Logger: func(message ...any) {}})
+	w.AddFilesEventHandlers(codegen)
+	if len(w.deferredUpstream(w.FilesEventHandlers)) != 0 {
+		t.Error("expected no deferred upstream without dependents")
+	}
+}
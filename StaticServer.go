@@ -0,0 +1,139 @@
+package devwatch
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+// StaticServer serves the web assets of frontend-only projects, so watching,
+// serving and reloading come from one package. HTML pages get the
+// LiveReload script injected and responses are never cached.
+//
+//	cfg.Serve = &devwatch.StaticServer{Addr: "localhost:8080", Dir: "web"}
+//
+// FileWatcherStart starts it and uses its LiveReload as BrowserReloadPaths
+// when no browser reload is configured.
+type StaticServer struct {
+	Addr       string      // default "localhost:8080"
+	Dir        string      // served folder relative to AppRootDir (default AppRootDir)
+	LiveReload *LiveReload // created when nil; use Route for per page reloads
+
+	mu       sync.Mutex
+	server   *http.Server
+	listener net.Listener
+}
+
+// staticServerAddr is the default StaticServer address
+const staticServerAddr = "localhost:8080"
+
+// ServeDir returns a handler serving the files in dir with no-cache headers,
+// injecting LiveReloadScript into HTML pages and mounting lr on LiveReloadPrefix
+func ServeDir(dir string, lr *LiveReload) http.Handler {
+	files := http.FileServer(http.Dir(dir))
+	mux := http.NewServeMux()
+	mux.Handle(LiveReloadPrefix, lr)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		name := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+r.URL.Path)))
+		if info, err := os.Stat(name); err == nil && info.IsDir() {
+			name = filepath.Join(name, "index.html")
+		}
+		if filepath.Ext(name) != ".html" {
+			files.ServeHTTP(w, r)
+			return
+		}
+		page, err := os.ReadFile(name)
+		if err != nil {
+			files.ServeHTTP(w, r) // 404 and permission errors
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(injectLiveReload(page))
+	})
+	return mux
+}
+
+// injectLiveReload adds LiveReloadScript before </body>, or at the end of the page
+func injectLiveReload(page []byte) []byte {
+	i := bytes.LastIndex(bytes.ToLower(page), []byte("</body>"))
+	if i < 0 {
+		return append(page, LiveReloadScript...)
+	}
+	out := make([]byte, 0, len(page)+len(LiveReloadScript))
+	out = append(out, page[:i]...)
+	out = append(out, LiveReloadScript...)
+	return append(out, page[i:]...)
+}
+
+// start listens on Addr and serves Dir in the background
+func (s *StaticServer) start(h *DevWatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.LiveReload == nil {
+		s.LiveReload = &LiveReload{}
+	}
+	if s.LiveReload.Focus == nil {
+		s.LiveReload.Focus = h
+	}
+	addr := s.Addr
+	if addr == "" {
+		addr = staticServerAddr
+	}
+	dir := s.Dir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(h.AppRootDir, dir)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	s.server = &http.Server{Handler: ServeDir(dir, s.LiveReload)}
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			h.say("static-server-error", err)
+		}
+	}(s.server)
+	h.say("static-server", dir, "http://"+listener.Addr().String())
+	return nil
+}
+
+// URL returns the address the server listens on eg: "http://127.0.0.1:8080", "" before it started
+func (s *StaticServer) URL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return ""
+	}
+	return "http://" + s.listener.Addr().String()
+}
+
+// close stops the server; open live reload streams are cut
+func (s *StaticServer) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		s.server.Close()
+		s.server = nil
+	}
+}
+
+// startServer starts WatchConfig.Serve and wires its LiveReload as the browser reload
+func (h *DevWatch) startServer() {
+	if h.Serve == nil {
+		return
+	}
+	if err := h.Serve.start(h); err != nil {
+		h.say("static-server-error", err)
+		return
+	}
+	if h.BrowserReload == nil && h.BrowserReloadPaths == nil {
+		h.BrowserReloadPaths = h.Serve.LiveReload.ReloadPaths
+	}
+}
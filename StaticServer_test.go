@@ -0,0 +1,82 @@
+package devwatch
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func httpGet(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+func TestServeDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html": "<html><body><h1>home</h1></body></html>",
+		"about.html": "<HTML><BODY>about</BODY></HTML>",
+		"app.css":    "body{}",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv := httptest.NewServer(ServeDir(dir, &LiveReload{}))
+	t.Cleanup(srv.Close)
+
+	resp, body := httpGet(t, srv.URL+"/")
+	if body != "<html><body><h1>home</h1>"+LiveReloadScript+"</body></html>" {
+		t.Errorf("expected script injected before </body>, got %s", body)
+	}
+	if !strings.Contains(resp.Header.Get("Cache-Control"), "no-cache") {
+		t.Errorf("expected no-cache header, got %q", resp.Header.Get("Cache-Control"))
+	}
+	if _, body := httpGet(t, srv.URL+"/about.html"); !strings.Contains(body, LiveReloadScript+"</BODY>") {
+		t.Errorf("expected case-insensitive injection, got %s", body)
+	}
+	if _, body := httpGet(t, srv.URL+"/app.css"); body != "body{}" {
+		t.Errorf("expected assets served unchanged, got %s", body)
+	}
+	if resp, _ := httpGet(t, srv.URL+"/devwatch/livereload.js"); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected live reload script, got %d", resp.StatusCode)
+	}
+	if resp, _ := httpGet(t, srv.URL+"/missing.html"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestStaticServer(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "web", "index.html"), []byte("hi"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		Serve:      &StaticServer{Addr: "127.0.0.1:0", Dir: "web"},
+		Logger:     func(message ...any) {},
+	})
+	w.startServer()
+	defer w.Serve.close()
+
+	if w.BrowserReloadPaths == nil {
+		t.Error("expected the server LiveReload to be used as browser reload")
+	}
+	if _, body := httpGet(t, w.Serve.URL()+"/"); body != "hi"+LiveReloadScript {
+		t.Errorf("unexpected page: %s", body)
+	}
+}
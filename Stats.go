@@ -0,0 +1,111 @@
+package devwatch
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxLatencySamples bounds the memory used by reload statistics
+const maxLatencySamples = 256
+
+// ReloadStats summarizes the feedback loop speed of recent saves
+type ReloadStats struct {
+	Saves int // saves that ended in a browser reload
+	// Suppressed counts reload requests collapsed into a later reload by MaxReloadsPerSecond
+	Suppressed int
+	Handlers   LatencyStats // event received -> handlers done
+	EndToEnd   LatencyStats // event received -> reload fired
+	// Builds holds the size and duration of each artifact per build, see
+	// also WriteBuildCSV
+	Builds []BuildTrend
+}
+
+// LatencyStats holds percentiles over the recent samples
+type LatencyStats struct {
+	P50, P90, P99, Max time.Duration
+}
+
+// pendingSave is a save whose handlers are done and waits for the reload
+type pendingSave struct {
+	received     time.Time
+	handlersDone time.Time
+}
+
+// reloadStats collects per-save latencies
+type reloadStats struct {
+	mu       sync.Mutex
+	pending  []pendingSave
+	saves    int
+	dropped  int // reload requests suppressed by the rate limit
+	handlers []time.Duration
+	endToEnd []time.Duration
+}
+
+// handlersDone records that the handlers of an event received at received finished
+func (s *reloadStats) handlersDone(received time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, pendingSave{received: received, handlersDone: time.Now()})
+}
+
+// reloaded closes all pending saves with the reload time
+func (s *reloadStats) reloaded() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, p := range s.pending {
+		s.saves++
+		s.handlers = appendSample(s.handlers, p.handlersDone.Sub(p.received))
+		s.endToEnd = appendSample(s.endToEnd, now.Sub(p.received))
+	}
+	s.pending = s.pending[:0]
+}
+
+// dropPending forgets the pending saves of a reload that didn't happen, so
+// the next reload doesn't count their wait
+func (s *reloadStats) dropPending() {
+	s.mu.Lock()
+	s.pending = s.pending[:0]
+	s.mu.Unlock()
+}
+
+// suppressed counts a reload request collapsed by the rate limit
+func (s *reloadStats) suppressed() {
+	s.mu.Lock()
+	s.dropped++
+	s.mu.Unlock()
+}
+
+func appendSample(samples []time.Duration, d time.Duration) []time.Duration {
+	if len(samples) == maxLatencySamples {
+		samples = samples[1:]
+	}
+	return append(samples, d)
+}
+
+// Stats returns latency percentiles for the most recent saves that ended in a
+// browser reload, useful to spot regressions when adding handlers.
+func (h *DevWatch) Stats() ReloadStats {
+	h.stats.mu.Lock()
+	defer h.stats.mu.Unlock()
+	return ReloadStats{
+		Saves:      h.stats.saves,
+		Suppressed: h.stats.dropped,
+		Handlers:   percentiles(h.stats.handlers),
+		EndToEnd:   percentiles(h.stats.endToEnd),
+		Builds:     h.builds.snapshot(),
+	}
+}
+
+func percentiles(samples []time.Duration) LatencyStats {
+	if len(samples) == 0 {
+		return LatencyStats{}
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	at := func(p int) time.Duration {
+		return sorted[(len(sorted)-1)*p/100]
+	}
+	return LatencyStats{P50: at(50), P90: at(90), P99: at(99), Max: sorted[len(sorted)-1]}
+}
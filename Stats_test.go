@@ -0,0 +1,119 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// slowCSSHandler takes a fixed time to process each event
+type slowCSSHandler struct {
+	delay time.Duration
+}
+
+func (s *slowCSSHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+func (s *slowCSSHandler) SupportedExtensions() []string     { return []string{".css"} }
+func (s *slowCSSHandler) MainInputFileRelativePath() string { return "" }
+func (s *slowCSSHandler) UnobservedFiles() []string         { return nil }
+
+func TestStats(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloadCalled := make(chan struct{}, 4)
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&slowCSSHandler{delay: 20 * time.Millisecond}},
+		BrowserReload: func() error {
+			reloadCalled <- struct{}{}
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	if got := w.Stats(); got.Saves != 0 {
+		t.Fatalf("expected empty stats, got %+v", got)
+	}
+
+	for range 2 {
+		if err := w.Trigger(cssFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-reloadCalled:
+		case <-time.After(time.Second):
+			t.Fatal("expected reload")
+		}
+	}
+
+	stats := w.Stats()
+	if stats.Saves != 2 {
+		t.Errorf("Saves = %d, want 2", stats.Saves)
+	}
+	if stats.Handlers.P50 < 20*time.Millisecond {
+		t.Errorf("handler latency %v should include handler time", stats.Handlers.P50)
+	}
+	if stats.EndToEnd.P50 < stats.Handlers.P50 {
+		t.Errorf("end-to-end latency %v lower than handler latency %v", stats.EndToEnd.P50, stats.Handlers.P50)
+	}
+	if stats.EndToEnd.Max < stats.EndToEnd.P99 {
+		t.Errorf("Max %v lower than P99 %v", stats.EndToEnd.Max, stats.EndToEnd.P99)
+	}
+}
+
+func TestStats_FocusModeReload(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloadCalled := make(chan struct{}, 4)
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&slowCSSHandler{}},
+		BrowserReload: func() error {
+			reloadCalled <- struct{}{}
+			return nil
+		},
+		Logger: func(message ...any) {},
+	})
+	waitReload := func() {
+		t.Helper()
+		select {
+		case <-reloadCalled:
+		case <-time.After(time.Second):
+			t.Fatal("expected reload")
+		}
+	}
+
+	// the held reload doesn't close the save, nor does the reload ending focus mode
+	w.SetFocusMode(true)
+	if err := w.Trigger(cssFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	w.SetFocusMode(false)
+	waitReload()
+
+	if err := w.Trigger(cssFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	waitReload()
+
+	stats := w.Stats()
+	if stats.Saves != 1 {
+		t.Errorf("Saves = %d, want only the save reloaded outside focus mode", stats.Saves)
+	}
+	if stats.EndToEnd.Max >= 250*time.Millisecond {
+		t.Errorf("end-to-end latency %v includes the save held by focus mode", stats.EndToEnd.Max)
+	}
+}
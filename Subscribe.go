@@ -0,0 +1,108 @@
+package devwatch
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBuffer is the channel size of each subscriber; records are
+// dropped for subscribers that fall behind instead of blocking the watcher.
+const subscriberBuffer = 64
+
+// EventRecord describes a processed file event and its outcome
+type EventRecord struct {
+	ID uint64 `json:"id"`
+	// Parent is the event whose handlers wrote this file (eg: codegen output),
+	// Depth the number of ancestors
+	Parent   uint64          `json:"parent,omitempty"`
+	Depth    int             `json:"depth,omitempty"`
+	Time     time.Time       `json:"time"`
+	Path     string          `json:"path"`
+	Source   string          `json:"source,omitempty"` // WatchConfig.RootSources name of Path
+	Event    string          `json:"event"`            // create, remove, write, rename, reload or transaction
+	Files    []string        `json:"files,omitempty"`  // files saved together in a transaction, see SaveAllWindow
+	Handlers []HandlerResult `json:"handlers,omitempty"`
+	Skipped  string          `json:"skipped,omitempty"` // reason the event was not dispatched eg: "comment-only"
+	Reload   bool            `json:"reload"`            // a browser reload was scheduled
+	Audit    bool            `json:"audit,omitempty"`   // AuditFor: handlers and reload were recorded, not run
+}
+
+// HandlerResult is the outcome of a single handler invocation
+type HandlerResult struct {
+	Handler     string        `json:"handler"`
+	Duration    time.Duration `json:"duration"`
+	Error       string        `json:"error,omitempty"`
+	Diagnostics []Diagnostic  `json:"diagnostics,omitempty"` // parsed from Error, eg: ExecHandler compiler output
+	Superseded  bool          `json:"superseded,omitempty"`  // cancelled by a newer save, see InterruptStaleBuilds
+}
+
+// subscribers fans out event records to observers
+type subscribers struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]chan EventRecord
+	lastID uint64
+}
+
+// Subscribe returns a channel receiving a copy of every processed event and
+// its outcome, for dashboards, tests and logging sinks. Slow subscribers miss
+// records instead of blocking the watcher. Call cancel to unsubscribe; it
+// closes the channel.
+func (h *DevWatch) Subscribe() (<-chan EventRecord, func()) {
+	h.subs.mu.Lock()
+	defer h.subs.mu.Unlock()
+	if h.subs.subs == nil {
+		h.subs.subs = make(map[int]chan EventRecord)
+	}
+	id := h.subs.nextID
+	h.subs.nextID++
+	ch := make(chan EventRecord, subscriberBuffer)
+	h.subs.subs[id] = ch
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.subs.mu.Lock()
+			defer h.subs.mu.Unlock()
+			delete(h.subs.subs, id)
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// newEventRecord creates a record with a unique ID for an incoming event
+func (h *DevWatch) newEventRecord(path, event string) *EventRecord {
+	h.subs.mu.Lock()
+	h.subs.lastID++
+	id := h.subs.lastID
+	h.subs.mu.Unlock()
+	return &EventRecord{ID: id, Time: time.Now(), Path: path, Source: h.sourceOf(path), Event: event}
+}
+
+// publish sends rec to all subscribers without blocking, to JournalPath, to
+// the OnNotify aggregation, to Logger in json LogFormat and to the audit report
+func (h *DevWatch) publish(rec *EventRecord) {
+	h.journal(rec)
+	h.notify(rec)
+	h.logRecord(rec)
+	h.auditRecord(rec)
+	h.subs.mu.Lock()
+	defer h.subs.mu.Unlock()
+	for _, ch := range h.subs.subs {
+		select {
+		case ch <- *rec:
+		default:
+		}
+	}
+}
+
+// addResult appends the outcome of a handler invocation to rec
+func (rec *EventRecord) addResult(handler FilesEventHandlers, start time.Time, err error) {
+	result := HandlerResult{Handler: handlerName(handler), Duration: time.Since(start)}
+	if err != nil {
+		result.Error = err.Error()
+		result.Diagnostics = DiagnosticsOf(err)
+	}
+	rec.Handlers = append(rec.Handlers, result)
+}
@@ -0,0 +1,61 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		FilesEventHandlers: []FilesEventHandlers{
+			&namedCSSHandler{name: "assets"},
+			failingCSSHandler{},
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	first, cancelFirst := w.Subscribe()
+	second, cancelSecond := w.Subscribe()
+	defer cancelSecond()
+
+	if err := w.Trigger(cssFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ch := range []<-chan EventRecord{first, second} {
+		select {
+		case rec := <-ch:
+			if rec.Path != cssFile || rec.Event != "write" || !rec.Reload {
+				t.Errorf("unexpected record %+v", rec)
+			}
+			if len(rec.Handlers) != 2 || rec.Handlers[0].Handler != "assets" || rec.Handlers[1].Error != "compile error" {
+				t.Errorf("unexpected handler results %+v", rec.Handlers)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected event record")
+		}
+	}
+
+	cancelFirst()
+	cancelFirst() // safe to call twice
+	if _, ok := <-first; ok {
+		t.Error("expected channel closed after cancel")
+	}
+
+	// remaining subscriber still receives records with increasing IDs
+	if err := w.Trigger(cssFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if rec := <-second; rec.ID != 2 {
+		t.Errorf("record ID = %d, want 2", rec.ID)
+	}
+}
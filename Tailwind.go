@@ -0,0 +1,209 @@
+package devwatch
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// tailwindConfigs are the config file names looked up when Tailwind.Config is empty
+var tailwindConfigs = []string{"tailwind.config.js", "tailwind.config.cjs", "tailwind.config.mjs", "tailwind.config.ts"}
+
+// tailwindDefaultContent is used when the config declares no content globs
+// (eg: Tailwind v4, configured from the stylesheet)
+var tailwindDefaultContent = []string{"**/*.html", "**/*.templ", "**/*.js", "**/*.ts", "**/*.jsx", "**/*.tsx", "**/*.go"}
+
+// Tailwind rebuilds a Tailwind CSS stylesheet with the Tailwind CLI when a
+// file matching the content globs of tailwind.config.js, the config itself
+// or a stylesheet changes:
+//
+//	watcher.AddFilesEventHandlers(&devwatch.Tailwind{
+//		Dir:    appRoot,
+//		Input:  "web/css/input.css",
+//		Output: "web/public/style.css",
+//	})
+//
+// Output is a generated path: its watcher events don't reach handlers. The
+// browser reload reports Output instead of the changed template, so a
+// LiveReload with InjectCSS swaps the stylesheet without reloading the page.
+type Tailwind struct {
+	Dir     string    // project root, usually AppRootDir
+	Input   string    // stylesheet with the tailwind directives, relative to Dir
+	Output  string    // built stylesheet relative to Dir
+	Config  string    // relative to Dir (default: the tailwind.config.* found in Dir)
+	Command []string  // default: npx tailwindcss (eg: []string{"./tailwindcss"} for the standalone binary)
+	Minify  bool      // pass --minify
+	Log     io.Writer // also receives the CLI output
+
+	mu      sync.Mutex
+	loaded  bool
+	content []string // content globs relative to Dir
+}
+
+// Name implements NamedHandler
+func (t *Tailwind) Name() string { return "tailwind" }
+
+// SupportedExtensions implements FilesEventHandlers; templates are matched
+// by MatchFile against the content globs
+func (t *Tailwind) SupportedExtensions() []string { return []string{".css"} }
+
+// MatchFile implements FileMatcher: the config and the files matching the content globs
+func (t *Tailwind) MatchFile(filePath string) bool {
+	rel := t.rel(filePath)
+	if rel == "" {
+		return false
+	}
+	if rel == t.configPath() {
+		return true
+	}
+	for _, glob := range t.globs() {
+		if matchGlob(glob, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnsAllGoFiles implements AllGoFilesHandler: class names may appear in any Go file
+func (t *Tailwind) OwnsAllGoFiles() bool { return true }
+
+// GeneratedPaths implements CodegenHandler
+func (t *Tailwind) GeneratedPaths() []string { return []string{t.Output} }
+
+// ReloadTargets implements ReloadTargetHandler: pages only need the new stylesheet
+func (t *Tailwind) ReloadTargets(sourcePath string) []string { return []string{t.Output} }
+
+// NewFileEvent runs the Tailwind CLI; a config change reloads the content globs first
+func (t *Tailwind) NewFileEvent(fileName, extension, filePath, event string) error {
+	if t.rel(filePath) == t.configPath() {
+		t.mu.Lock()
+		t.loaded = false
+		t.mu.Unlock()
+	}
+	command := t.Command
+	if len(command) == 0 {
+		command = []string{"npx", "tailwindcss"}
+	}
+	args := append([]string{}, command[1:]...)
+	args = append(args, "-i", filepath.FromSlash(t.Input), "-o", filepath.FromSlash(t.Output))
+	if config := t.configPath(); config != "" {
+		args = append(args, "-c", filepath.FromSlash(config))
+	}
+	if t.Minify {
+		args = append(args, "--minify")
+	}
+	cmd := exec.Command(command[0], args...)
+	cmd.Dir = t.Dir
+	_, err := runCommand(cmd, t.Name(), t.Log)
+	return err
+}
+
+// rel returns filePath relative to Dir with forward slashes, "" outside Dir
+func (t *Tailwind) rel(filePath string) string {
+	rel, err := filepath.Rel(t.Dir, filePath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// configPath returns Config or the tailwind.config.* found in Dir
+func (t *Tailwind) configPath() string {
+	if t.Config != "" {
+		return path.Clean(filepath.ToSlash(t.Config))
+	}
+	for _, name := range tailwindConfigs {
+		if _, err := os.Stat(filepath.Join(t.Dir, name)); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// globs returns the content globs of the config, read on first use and
+// after each config change
+func (t *Tailwind) globs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.loaded {
+		t.loaded = true
+		t.content = nil
+		if config := t.configPath(); config != "" {
+			if data, err := os.ReadFile(filepath.Join(t.Dir, filepath.FromSlash(config))); err == nil {
+				t.content = tailwindContent(string(data))
+			}
+		}
+		if len(t.content) == 0 {
+			t.content = tailwindDefaultContent
+		}
+	}
+	return t.content
+}
+
+var (
+	tailwindContentKey = regexp.MustCompile(`\bcontent\s*:\s*(\{[^\[]*\bfiles\s*:\s*)?\[`)
+	quotedString       = regexp.MustCompile("[\"'`]([^\"'`]+)[\"'`]")
+)
+
+// tailwindContent extracts the content globs of a tailwind config, eg:
+// content: ["./web/**/*.{html,templ}", "./ui/**/*.go"]. Negated globs are skipped.
+func tailwindContent(config string) []string {
+	loc := tailwindContentKey.FindStringIndex(config)
+	if loc == nil {
+		return nil
+	}
+	list := config[loc[1]:]
+	if end := strings.IndexByte(list, ']'); end >= 0 {
+		list = list[:end]
+	}
+	var globs []string
+	for _, m := range quotedString.FindAllStringSubmatch(list, -1) {
+		glob := strings.TrimPrefix(m[1], "./")
+		if !strings.HasPrefix(glob, "!") {
+			globs = append(globs, glob)
+		}
+	}
+	return globs
+}
+
+// matchGlob reports whether the slash path rel matches glob: "**" matches
+// any number of folders, "{a,b}" alternatives, other segments follow path.Match
+func matchGlob(glob, rel string) bool {
+	if open := strings.IndexByte(glob, '{'); open >= 0 {
+		if end := strings.IndexByte(glob[open:], '}'); end >= 0 {
+			for _, alt := range strings.Split(glob[open+1:open+end], ",") {
+				if matchGlob(glob[:open]+alt+glob[open+end+1:], rel) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return matchSegments(strings.Split(glob, "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(glob, parts []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(glob[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(glob[0], parts[0]); !ok {
+			return false
+		}
+		glob, parts = glob[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
@@ -0,0 +1,86 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTailwind_ContentGlobs(t *testing.T) {
+	config := `module.exports = {
+  content: ["./web/**/*.{html,templ}", './ui/*.go', "!./web/vendor/**"],
+  theme: { extend: {} },
+}`
+	globs := tailwindContent(config)
+	if want := []string{"web/**/*.{html,templ}", "ui/*.go"}; !slices.Equal(globs, want) {
+		t.Fatalf("globs = %q, want %q", globs, want)
+	}
+	if got := tailwindContent(`export default { content: { files: ["src/**/*.js"] } }`); !slices.Equal(got, []string{"src/**/*.js"}) {
+		t.Errorf("object form globs = %q", got)
+	}
+
+	for _, tt := range []struct {
+		path string
+		want bool
+	}{
+		{"web/index.html", true},
+		{"web/admin/users/list.templ", true},
+		{"web/app.js", false},
+		{"ui/button.go", true},
+		{"ui/forms/input.go", false},
+	} {
+		if got := slices.ContainsFunc(globs, func(g string) bool { return matchGlob(g, tt.path) }); got != tt.want {
+			t.Errorf("match %s = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTailwind_RebuildReportsStylesheet(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"tailwind.config.js": `module.exports = { content: ["./web/**/*.html"] }`,
+		"web/css/input.css":  "@tailwind base;",
+		"web/index.html":     "<html></html>",
+		"web/app.js":         "main()",
+	})
+
+	tw := &Tailwind{
+		Dir:     root,
+		Input:   "web/css/input.css",
+		Output:  "web/public/style.css",
+		Command: []string{"sh", "-c", `mkdir -p "$(dirname "$4")" && cp "$2" "$4"`, "sh"}, // $1..$4: -i input -o output
+	}
+	reloaded := make(chan []string, 1)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{tw},
+		BrowserReloadPaths: func(paths []string) error { reloaded <- paths; return nil },
+		Logger:             func(message ...any) {},
+	})
+	if errs := w.Validate(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	if !tw.MatchFile(filepath.Join(root, "web", "index.html")) || tw.MatchFile(filepath.Join(root, "web", "app.js")) {
+		t.Error("only files matching the content globs must be handled")
+	}
+	if err := w.Trigger("web/index.html", "write"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case paths := <-reloaded:
+		if !slices.Equal(paths, []string{"web/public/style.css"}) {
+			t.Errorf("reload paths = %v, want the stylesheet", paths)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a reload")
+	}
+	if _, err := os.Stat(filepath.Join(root, "web", "public", "style.css")); err != nil {
+		t.Errorf("expected the CLI to write the output: %v", err)
+	}
+	if !w.isGenerated(filepath.Join(root, "web", "public", "style.css")) {
+		t.Error("output must be generated")
+	}
+}
@@ -0,0 +1,116 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateValidator parses changed html/template and text/template files
+// on save, so a syntax error shows up at once (through OnError and the
+// Diagnostics of Subscribe records) instead of as a runtime error in the
+// server. While a template is broken, browser reloads are skipped (see
+// ArtifactVerifier).
+//
+//	watcher.AddFilesEventHandlers(&devwatch.TemplateValidator{})
+//
+// Both packages share the parser, so one validator covers them. Function
+// names are not checked unless Funcs is set.
+type TemplateValidator struct {
+	Extensions []string         // default: .html .tmpl .gohtml
+	Funcs      template.FuncMap // when set, calls to other functions are errors
+	Delims     [2]string        // default: "{{" "}}"
+
+	mu     sync.Mutex
+	broken map[string]error // file path => parse error
+}
+
+// TemplateError is returned by TemplateValidator for a template that doesn't parse
+type TemplateError struct {
+	Diagnostic
+	Err error
+}
+
+func (e *TemplateError) Error() string { return e.Err.Error() }
+
+func (e *TemplateError) Unwrap() error { return e.Err }
+
+// Name implements NamedHandler
+func (v *TemplateValidator) Name() string { return "templates" }
+
+func (v *TemplateValidator) SupportedExtensions() []string {
+	if len(v.Extensions) > 0 {
+		return v.Extensions
+	}
+	return []string{".html", ".tmpl", ".gohtml"}
+}
+
+// NewFileEvent parses filePath, forgetting it when it was removed
+func (v *TemplateValidator) NewFileEvent(fileName, extension, filePath, event string) error {
+	var err error
+	if event != "remove" && event != "rename" {
+		err = v.parse(filePath)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if err == nil {
+		delete(v.broken, filePath)
+		return nil
+	}
+	if v.broken == nil {
+		v.broken = make(map[string]error)
+	}
+	v.broken[filePath] = err
+	return err
+}
+
+// VerifyArtifacts implements ArtifactVerifier: no reload while a template is broken
+func (v *TemplateValidator) VerifyArtifacts() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	files := make([]string, 0, len(v.broken))
+	for file := range v.broken {
+		files = append(files, file)
+	}
+	slices.Sort(files)
+	var errs []error
+	for _, file := range files {
+		errs = append(errs, v.broken[file])
+	}
+	return errors.Join(errs...)
+}
+
+// parse parses the template at filePath, returning a TemplateError
+func (v *TemplateValidator) parse(filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if v.Funcs != nil {
+		_, err = template.New(filePath).Delims(v.Delims[0], v.Delims[1]).Funcs(v.Funcs).Parse(string(data))
+	} else {
+		tree := parse.New(filePath)
+		tree.Mode = parse.SkipFuncCheck | parse.ParseComments
+		_, err = tree.Parse(string(data), v.Delims[0], v.Delims[1], make(map[string]*parse.Tree))
+	}
+	if err == nil {
+		return nil
+	}
+
+	// "template: <name>:<line>: <message>"
+	diag := Diagnostic{File: filePath, Severity: "error", Message: err.Error()}
+	if rest, ok := strings.CutPrefix(err.Error(), "template: "+filePath+":"); ok {
+		if line, message, ok := strings.Cut(rest, ": "); ok {
+			if n, convErr := strconv.Atoi(line); convErr == nil {
+				diag.Line, diag.Message = n, message
+			}
+		}
+	}
+	return &TemplateError{Diagnostic: diag, Err: err}
+}
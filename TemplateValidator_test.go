@@ -0,0 +1,72 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestTemplateValidator_BlocksReloadWhileBroken(t *testing.T) {
+	root := t.TempDir()
+	page := filepath.Join(root, "web", "index.html")
+	writeTree(t, root, map[string]string{"web/index.html": "<h1>{{.Title}}</h1>\n<p>{{ if .Ok }}</p>\n"})
+
+	var reloads atomic.Int32
+	var reported atomic.Value
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{&TemplateValidator{}},
+		BrowserReload:      func() error { reloads.Add(1); return nil },
+		OnError: func(err error) {
+			if diags := DiagnosticsOf(err); len(diags) > 0 {
+				reported.Store(diags[0])
+			}
+		},
+		Logger: func(message ...any) {},
+	})
+
+	if err := w.Trigger(page, "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	diag, _ := reported.Load().(Diagnostic)
+	if diag.File != page || diag.Line != 3 || !strings.Contains(diag.Message, "unexpected EOF") {
+		t.Errorf("diagnostic = %+v, want the unclosed if", diag)
+	}
+	if n := reloads.Load(); n != 0 {
+		t.Errorf("expected no reload while the template is broken, got %d", n)
+	}
+
+	if err := os.WriteFile(page, []byte("<h1>{{.Title | upper}}</h1>\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(page, "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := reloads.Load(); n != 1 {
+		t.Errorf("expected a reload once fixed, got %d", n)
+	}
+}
+
+func TestTemplateValidator_Funcs(t *testing.T) {
+	root := t.TempDir()
+	page := filepath.Join(root, "page.tmpl")
+	writeTree(t, root, map[string]string{"page.tmpl": "[[ upper .Name ]]"})
+
+	v := &TemplateValidator{Delims: [2]string{"[[", "]]"}, Funcs: template.FuncMap{"lower": strings.ToLower}}
+	if err := v.NewFileEvent("page.tmpl", ".tmpl", page, "write"); err == nil || !strings.Contains(err.Error(), `"upper" not defined`) {
+		t.Errorf("expected the unknown function to fail, got %v", err)
+	}
+	v.Funcs["upper"] = strings.ToUpper
+	if err := v.NewFileEvent("page.tmpl", ".tmpl", page, "write"); err != nil {
+		t.Errorf("expected the template to parse, got %v", err)
+	}
+	if err := v.VerifyArtifacts(); err != nil {
+		t.Errorf("expected no broken template left, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package devwatch
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cdvelop/godepfind"
+)
+
+// TestRunner is a Go handler that runs "go test" for the package of each
+// changed .go file and for the packages importing it (found with
+// godepfind), a watch-mode test runner out of the box. go test's own result
+// cache skips packages whose inputs did not change.
+//
+//	watcher.AddFilesEventHandlers(&devwatch.TestRunner{Dir: appRoot, Flags: []string{"-short"}})
+//
+// Failures are returned as ExecError so test and build messages reach
+// OnError and Subscribe as Diagnostics.
+type TestRunner struct {
+	Dir    string    // module root, usually AppRootDir
+	Flags  []string  // extra go test flags eg: []string{"-race", "-short"}
+	Output io.Writer // also receives the go test output
+	// Coverage records which files each test executes (running every test
+	// once on its own the first time) and afterwards reruns only the tests
+	// covering the changed file
+	Coverage bool
+
+	mu     sync.Mutex
+	status map[string]bool // import path -> last run passed
+	cover  testCoverage
+}
+
+func (r *TestRunner) SupportedExtensions() []string { return []string{".go"} }
+
+// OwnsAllGoFiles implements AllGoFilesHandler: every package may have tests
+func (r *TestRunner) OwnsAllGoFiles() bool { return true }
+
+// Name implements NamedHandler
+func (r *TestRunner) Name() string { return "go test" }
+
+// NewFileEvent tests the package of filePath and its reverse dependencies
+func (r *TestRunner) NewFileEvent(fileName, extension, filePath, event string) error {
+	pkgs := r.packages(fileName, filePath)
+	if len(pkgs) == 0 {
+		return nil
+	}
+	if r.Coverage {
+		return r.runCovered(fileName, filePath, pkgs)
+	}
+	cmd := exec.Command("go", append(append([]string{"test"}, r.Flags...), pkgs...)...)
+	cmd.Dir = r.Dir
+	output, err := runCommand(cmd, r.Name(), r.Output)
+	r.record(output)
+	return err
+}
+
+// Status returns whether the last run of each tested package passed, keyed
+// by import path eg: "example/internal/api"
+func (r *TestRunner) Status() map[string]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := make(map[string]bool, len(r.status))
+	for pkg, passed := range r.status {
+		status[pkg] = passed
+	}
+	return status
+}
+
+// packages returns the go test arguments for a changed file: its package
+// directory plus the packages importing it. Test files are not imported so
+// only their own package is tested.
+func (r *TestRunner) packages(fileName, filePath string) []string {
+	dir := filepath.Dir(filePath)
+	if !hasGoFiles(dir) {
+		return nil // package removed
+	}
+	rel, err := filepath.Rel(r.Dir, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	pkg := "./" + filepath.ToSlash(rel)
+	if strings.HasSuffix(fileName, "_test.go") {
+		return []string{pkg}
+	}
+
+	finder := godepfind.New(r.Dir)
+	finder.SetTestImports(true)
+	// the reverse dependencies include pkg itself; a failed lookup still
+	// tests the changed package
+	deps, err := finder.FindReverseDeps("./...", []string{pkg})
+	if err != nil || len(deps) == 0 {
+		return []string{pkg}
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// record stores the per package result lines of a go test run
+// eg: "ok  \texample/api\t0.01s", "FAIL\texample/api\t0.02s"
+func (r *TestRunner) record(output string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.status == nil {
+		r.status = make(map[string]bool)
+	}
+	for line := range strings.Lines(output) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "ok":
+			r.status[fields[1]] = true
+		case "FAIL":
+			r.status[fields[1]] = false
+		}
+	}
+}
+
+// hasGoFiles reports whether dir contains any .go file
+func hasGoFiles(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package devwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTestRunner(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":              "module a\n\ngo 1.20\n",
+		"lib/lib.go":          "package lib\n\nfunc Two() int { return 2 }\n",
+		"lib/lib_test.go":     "package lib\n\nimport \"testing\"\n\nfunc TestTwo(t *testing.T) {\n\tif Two() != 2 {\n\t\tt.Fatal(\"bad\")\n\t}\n}\n",
+		"app/app.go":          "package app\n\nimport \"a/lib\"\n\nfunc Four() int { return lib.Two() * 2 }\n",
+		"app/app_test.go":     "package app\n\nimport \"testing\"\n\nfunc TestFour(t *testing.T) {\n\tif Four() != 5 {\n\t\tt.Fatal(\"want 5\")\n\t}\n}\n",
+		"other/other.go":      "package other\n",
+		"other/other_test.go": "package other\n\nimport \"testing\"\n\nfunc TestOther(t *testing.T) { t.Fatal(\"must not run\") }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runner := &TestRunner{Dir: root}
+	if got := runner.packages("lib_test.go", filepath.Join(root, "lib", "lib_test.go")); !reflect.DeepEqual(got, []string{"./lib"}) {
+		t.Errorf("test file should only test its package, got %v", got)
+	}
+
+	err := runner.NewFileEvent("lib.go", ".go", filepath.Join(root, "lib", "lib.go"), "write")
+	var execErr *ExecError
+	if !errors.As(err, &execErr) {
+		t.Fatalf("expected failing dependent package, got %v", err)
+	}
+	if want := map[string]bool{"a/lib": true, "a/app": false}; !reflect.DeepEqual(runner.Status(), want) {
+		t.Errorf("status %v, want %v", runner.Status(), want)
+	}
+	if diags := DiagnosticsOf(err); len(diags) == 0 || diags[0].File != "app_test.go" || diags[0].Message != "want 5" {
+		t.Errorf("expected test failure diagnostic, got %+v", diags)
+	}
+
+	if err := runner.NewFileEvent("lib.go", ".go", filepath.Join(root, "gone", "lib.go"), "remove"); err != nil {
+		t.Errorf("removed package should be skipped, got %v", err)
+	}
+}
+
+func TestTestRunner_Coverage(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module a\n\ngo 1.20\n",
+		"lib/two.go":      "package lib\n\nfunc Two() int { return 2 }\n",
+		"lib/three.go":    "package lib\n\nfunc Three() int { return 3 }\n",
+		"lib/lib_test.go": "package lib\n\nimport \"testing\"\n\nfunc TestTwo(t *testing.T) { Two() }\n\nfunc TestThree(t *testing.T) { Three() }\n",
+		"app/app.go":      "package app\n\nimport \"a/lib\"\n\nfunc Four() int { return lib.Two() * 2 }\n",
+		"app/app_test.go": "package app\n\nimport \"testing\"\n\nfunc TestFour(t *testing.T) { Four() }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out strings.Builder
+	runner := &TestRunner{Dir: root, Flags: []string{"-v"}, Output: &out, Coverage: true}
+	if err := runner.NewFileEvent("two.go", ".go", filepath.Join(root, "lib", "two.go"), "write"); err != nil {
+		t.Fatal(err)
+	}
+	if got := runner.cover.covering("a/lib", "a/lib/two.go"); !reflect.DeepEqual(got, []string{"TestTwo"}) {
+		t.Errorf("expected TestTwo to cover two.go, got %v", got)
+	}
+	if got := runner.cover.covering("a/app", "a/lib/two.go"); !reflect.DeepEqual(got, []string{"TestFour"}) {
+		t.Errorf("expected TestFour to cover two.go, got %v", got)
+	}
+
+	out.Reset()
+	if err := runner.NewFileEvent("three.go", ".go", filepath.Join(root, "lib", "three.go"), "write"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "TestThree") || strings.Contains(out.String(), "TestTwo") || strings.Contains(out.String(), "TestFour") {
+		t.Errorf("expected only TestThree to rerun, got:\n%s", out.String())
+	}
+}
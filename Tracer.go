@@ -0,0 +1,38 @@
+package devwatch
+
+import "context"
+
+// Tracer creates spans around event processing: one span per file event, a
+// child span per handler and one span for each browser reload. It mirrors the
+// small subset of OpenTelemetry devwatch needs, so an otel tracer can be
+// plugged in with a few lines of adapter code (see README) without adding the
+// dependency to every consumer.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span)
+}
+
+// Span is a unit of traced work. End receives the outcome (nil on success).
+type Span interface {
+	End(err error)
+}
+
+// noopSpan is used when no Tracer is configured
+type noopSpan struct{}
+
+func (noopSpan) End(error) {}
+
+// startSpan starts a span with the configured Tracer or returns a no-op span.
+// attrs are key, value pairs; the map is only built when a Tracer is set.
+func (h *DevWatch) startSpan(ctx context.Context, name string, attrs ...string) (context.Context, Span) {
+	if h.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+	var m map[string]string
+	if len(attrs) > 0 {
+		m = make(map[string]string, len(attrs)/2)
+		for i := 0; i+1 < len(attrs); i += 2 {
+			m[attrs[i]] = attrs[i+1]
+		}
+	}
+	return h.Tracer.Start(ctx, name, m)
+}
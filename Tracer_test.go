@@ -0,0 +1,103 @@
+package devwatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+// recordingTracer records finished spans with their parent span name
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []recordedSpan
+}
+
+type recordedSpan struct {
+	name, parent string
+	err          error
+}
+
+type recordingSpan struct {
+	tracer       *recordingTracer
+	name, parent string
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	return context.WithValue(ctx, spanKey{}, name), &recordingSpan{tracer: r, name: name, parent: parent}
+}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, recordedSpan{name: s.name, parent: s.parent, err: err})
+}
+
+func (r *recordingTracer) get() []recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]recordedSpan{}, r.spans...)
+}
+
+// failingCSSHandler always fails
+type failingCSSHandler struct{}
+
+func (failingCSSHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	return errors.New("compile error")
+}
+func (failingCSSHandler) SupportedExtensions() []string     { return []string{".css"} }
+func (failingCSSHandler) MainInputFileRelativePath() string { return "" }
+func (failingCSSHandler) UnobservedFiles() []string         { return nil }
+
+func TestTracerSpans(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tracer := &recordingTracer{}
+	reloadCalled := make(chan struct{}, 1)
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{SupportedExtensions_: []string{".css"}}, failingCSSHandler{}},
+		BrowserReload: func() error {
+			reloadCalled <- struct{}{}
+			return nil
+		},
+		Tracer: tracer,
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	if err := w.Trigger(cssFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloadCalled:
+	case <-time.After(time.Second):
+		t.Fatal("expected reload")
+	}
+	time.Sleep(10 * time.Millisecond) // reload span ends after BrowserReload returns
+
+	spans := tracer.get()
+	want := []recordedSpan{
+		{name: "devwatch.handler", parent: "devwatch.event"},
+		{name: "devwatch.handler", parent: "devwatch.event", err: errors.New("compile error")},
+		{name: "devwatch.event"},
+		{name: "devwatch.reload"},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("spans = %+v, want %+v", spans, want)
+	}
+	for i := range want {
+		if spans[i].name != want[i].name || spans[i].parent != want[i].parent || (spans[i].err == nil) != (want[i].err == nil) {
+			t.Errorf("span %d = %+v, want %+v", i, spans[i], want[i])
+		}
+	}
+}
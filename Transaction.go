@@ -0,0 +1,161 @@
+package devwatch
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BatchHandler is an optional capability for handlers processing the files
+// of a save transaction (see WatchConfig.SaveAllWindow) in one call instead
+// of one call per file
+type BatchHandler interface {
+	NewFileChanges(changes []FileChange) error
+}
+
+// pendingSaves buffers file events until SaveAllWindow passed without one
+type pendingSaves struct {
+	mu     sync.Mutex
+	events []queuedEvent
+	timer  *time.Timer
+	gen    int // incremented by each event, a stale timer's generation is older
+}
+
+// saveTransaction collects the outcome of the files saved together
+type saveTransaction struct {
+	rec     *EventRecord
+	goCalls map[int]error // Go handlers already called, by index in FilesEventHandlers
+	batches []*txBatch
+}
+
+// txBatch is the changes of a transaction for one BatchHandler
+type txBatch struct {
+	id      int // index in FilesEventHandlers
+	handler FilesEventHandlers
+	changes []FileChange
+}
+
+// queueSave queues a file event, grouping events closer than SaveAllWindow
+// into one transaction
+func (h *DevWatch) queueSave(fileName, path, eventType string, isDelete bool) {
+	if h.SaveAllWindow <= 0 {
+		h.enqueueFileEvent(fileName, path, eventType, isDelete)
+		return
+	}
+	ev := queuedEvent{fileName: fileName, path: path, eventType: eventType, isDelete: isDelete}
+
+	h.saves.mu.Lock()
+	defer h.saves.mu.Unlock()
+	if i := slices.IndexFunc(h.saves.events, func(e queuedEvent) bool { return e.path == path }); i >= 0 {
+		if h.saves.events[i].eventType == "create" && eventType == "write" {
+			ev.eventType = "create"
+		}
+		h.saves.events = slices.Delete(h.saves.events, i, i+1)
+	}
+	h.saves.events = append(h.saves.events, ev)
+	if h.saves.timer != nil {
+		h.saves.timer.Stop()
+	}
+	h.saves.gen++
+	gen := h.saves.gen
+	h.saves.timer = time.AfterFunc(h.SaveAllWindow, func() { h.flushSaves(gen) })
+}
+
+// flushSaves queues a lone event or handles a burst as one transaction.
+// gen < 0 flushes at once, eg: on Shutdown.
+func (h *DevWatch) flushSaves(gen int) {
+	h.saves.mu.Lock()
+	if gen >= 0 && h.saves.gen != gen {
+		h.saves.mu.Unlock()
+		return // a later event restarted the window
+	}
+	h.saves.gen++ // a pending timer has nothing left to flush
+	events := h.saves.events
+	h.saves.events = nil
+	h.saves.mu.Unlock()
+
+	switch len(events) {
+	case 0:
+	case 1:
+		h.enqueueFileEvent(events[0].fileName, events[0].path, events[0].eventType, events[0].isDelete)
+	default:
+		h.handleTransaction(events)
+	}
+}
+
+// handleTransaction dispatches the files saved together: each file goes
+// through the handlers, except that Go handlers build once and
+// BatchHandlers get one call with all their changes. The outcome is
+// published as a single "transaction" record and the browser reloads once.
+func (h *DevWatch) handleTransaction(events []queuedEvent) {
+	// the reload waits for the whole transaction like for async handlers
+	h.async.add()
+	defer h.async.done()
+
+	tx := &saveTransaction{
+		rec:     h.newEventRecord(events[0].path, "transaction"),
+		goCalls: make(map[int]error),
+	}
+	for _, ev := range events {
+		tx.rec.Files = append(tx.rec.Files, h.relativePath(ev.path))
+		h.handleEvent(ev.fileName, ev.path, ev.eventType, ev.isDelete, tx)
+	}
+
+	for _, batch := range tx.batches {
+		start := time.Now()
+		err := h.profileHandler(context.Background(), handlerName(batch.handler), batch.changes[0].FilePath, func() error {
+			return batch.handler.(BatchHandler).NewFileChanges(batch.changes)
+		})
+		tx.rec.addResult(batch.handler, start, err)
+		if err != nil {
+			if h.OnError != nil && DiagnosticsOf(err) != nil {
+				h.OnError(err)
+			}
+			continue
+		}
+		for _, change := range batch.changes {
+			h.changed.add(h.relativePath(change.FilePath), change.Event, true)
+		}
+		tx.rec.Reload = true
+		h.scheduleReload()
+	}
+	h.publish(tx.rec)
+}
+
+// batch defers the change for a BatchHandler to the end of the transaction
+func (tx *saveTransaction) batch(id int, handler FilesEventHandlers, change FileChange) bool {
+	if _, ok := handler.(BatchHandler); !ok {
+		return false
+	}
+	for _, b := range tx.batches {
+		if b.id == id {
+			b.changes = append(b.changes, change)
+			return true
+		}
+	}
+	tx.batches = append(tx.batches, &txBatch{id: id, handler: handler, changes: []FileChange{change}})
+	return true
+}
+
+// lookup returns the result of a Go handler already called in the transaction
+func (tx *saveTransaction) lookup(id int, goFile bool) (err error, done bool) {
+	if !goFile {
+		return nil, false
+	}
+	err, done = tx.goCalls[id]
+	return err, done
+}
+
+// record remembers the result of a Go handler call
+func (tx *saveTransaction) record(id int, goFile bool, err error) {
+	if goFile {
+		tx.goCalls[id] = err
+	}
+}
+
+// merge adds the outcome of one file of the transaction to its record
+func (tx *saveTransaction) merge(rec *EventRecord) {
+	tx.rec.Handlers = append(tx.rec.Handlers, rec.Handlers...)
+	tx.rec.Reload = tx.rec.Reload || rec.Reload
+}
@@ -0,0 +1,170 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// batchRecorder records the batches it receives
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]FileChange
+}
+
+func (b *batchRecorder) NewFileChanges(changes []FileChange) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.batches = append(b.batches, changes)
+	return nil
+}
+
+func (b *batchRecorder) NewFileEvent(fileName, extension, filePath, event string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.batches = append(b.batches, []FileChange{{FilePath: filePath}})
+	return nil
+}
+
+func (b *batchRecorder) SupportedExtensions() []string { return []string{".css"} }
+
+func TestSaveAllWindow(t *testing.T) {
+	tempDir := t.TempDir()
+	batch := &batchRecorder{}
+	perFile := &eventRecorder{}
+	var reloads atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{batch, perFile},
+		SaveAllWindow:      30 * time.Millisecond,
+		BrowserReload:      func() error { reloads.Add(1); return nil },
+		Logger:             func(message ...any) {},
+	})
+	records, cancel := w.Subscribe()
+	defer cancel()
+	stop := make(chan struct{})
+	workers := w.startWorkers(stop)
+	defer func() {
+		close(stop)
+		workers.Wait()
+	}()
+
+	// "save all"
+	for _, name := range []string{"a.css", "b.css", "c.css"} {
+		path := filepath.Join(tempDir, name)
+		os.WriteFile(path, []byte(name), 0644)
+		w.queueSave(name, path, "write", false)
+	}
+
+	var rec EventRecord
+	select {
+	case rec = <-records:
+	case <-time.After(time.Second):
+		t.Fatal("expected a transaction record")
+	}
+	if rec.Event != "transaction" || len(rec.Files) != 3 {
+		t.Errorf("record = %+v, want one transaction of 3 files", rec)
+	}
+	batch.mu.Lock()
+	if len(batch.batches) != 1 || len(batch.batches[0]) != 3 {
+		t.Errorf("batches = %v, want one call with 3 changes", batch.batches)
+	}
+	batch.mu.Unlock()
+	if got := len(perFile.received()); got != 3 {
+		t.Errorf("per file handler got %d events, want 3", got)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if got := reloads.Load(); got != 1 {
+		t.Errorf("reloads = %d, want 1", got)
+	}
+	select {
+	case rec := <-records:
+		t.Errorf("unexpected record %+v, the transaction should be the only one", rec)
+	default:
+	}
+
+	// a lone save is dispatched as usual
+	path := filepath.Join(tempDir, "a.css")
+	os.WriteFile(path, []byte("a2"), 0644)
+	w.queueSave("a.css", path, "write", false)
+	select {
+	case rec := <-records:
+		if rec.Event != "write" || len(rec.Files) != 0 {
+			t.Errorf("lone save record = %+v, want a write event", rec)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a record for the lone save")
+	}
+}
+
+func TestSaveAllWindow_SameTypeHandlers(t *testing.T) {
+	tempDir := t.TempDir()
+	b1, b2 := &batchRecorder{}, &batchRecorder{}
+	g1, g2 := &allGoHandler{}, &allGoHandler{}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{b1, b2, g1, g2},
+		Logger:             func(message ...any) {},
+	})
+
+	var events []queuedEvent
+	for _, name := range []string{"a.css", "b.css", "a.go", "b.go"} {
+		path := filepath.Join(tempDir, name)
+		os.WriteFile(path, []byte("package main\n"), 0644)
+		events = append(events, queuedEvent{fileName: name, path: path, eventType: "write"})
+	}
+	w.handleTransaction(events)
+
+	// unnamed handlers of one type are distinct handlers
+	for i, b := range []*batchRecorder{b1, b2} {
+		b.mu.Lock()
+		if len(b.batches) != 1 || len(b.batches[0]) != 2 {
+			t.Errorf("batch handler %d got %v, want one call with 2 changes", i+1, b.batches)
+		}
+		b.mu.Unlock()
+	}
+	for i, g := range []*allGoHandler{g1, g2} {
+		if got := g.calls.Load(); got != 1 {
+			t.Errorf("go handler %d called %d times, want once", i+1, got)
+		}
+	}
+}
+
+// sliceBatch is a value type BatchHandler that can't key a map
+type sliceBatch struct {
+	exts    []string
+	batches *[][]FileChange
+}
+
+func (s sliceBatch) NewFileChanges(changes []FileChange) error {
+	*s.batches = append(*s.batches, changes)
+	return nil
+}
+
+func (s sliceBatch) NewFileEvent(fileName, extension, filePath, event string) error { return nil }
+func (s sliceBatch) SupportedExtensions() []string                                  { return s.exts }
+
+func TestSaveAllWindow_ValueHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	var batches [][]FileChange
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{sliceBatch{exts: []string{".css"}, batches: &batches}},
+		Logger:             func(message ...any) {},
+	})
+
+	var events []queuedEvent
+	for _, name := range []string{"a.css", "b.css"} {
+		path := filepath.Join(tempDir, name)
+		os.WriteFile(path, []byte(name), 0644)
+		events = append(events, queuedEvent{fileName: name, path: path, eventType: "write"})
+	}
+	w.handleTransaction(events)
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Errorf("got %v, want one call with 2 changes", batches)
+	}
+}
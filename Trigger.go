@@ -0,0 +1,53 @@
+package devwatch
+
+import (
+	"errors"
+	"path/filepath"
+)
+
+// Trigger forces the full handler pipeline for path as if the watcher had
+// received event for it, without touching the file.
+// Useful for "rebuild now" buttons, CLI commands and tests.
+// event: create, remove, write, rename (empty defaults to write).
+// Relative paths are resolved against AppRootDir.
+func (h *DevWatch) Trigger(path, event string) error {
+	if h.closing.Load() {
+		return errShuttingDown
+	}
+	if event == "" {
+		event = "write"
+	}
+	switch event {
+	case "create", "remove", "write", "rename":
+	default:
+		return errors.New("Trigger invalid event: " + event)
+	}
+
+	if path != "" && !filepath.IsAbs(path) && h.AppRootDir != "" {
+		path = filepath.Join(h.AppRootDir, path)
+	}
+
+	fileName, err := GetFileName(path)
+	if err != nil {
+		return err
+	}
+
+	if h.Contain(path) {
+		return errors.New("Trigger path is unobserved: " + path)
+	}
+
+	isDeleteEvent := event == "remove"
+	if !isDeleteEvent {
+		info, err := statRetry(path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			h.handleDirectoryEvent(fileName, path, event)
+			return nil
+		}
+	}
+
+	h.handleFileEvent(fileName, path, event, isDeleteEvent)
+	return nil
+}
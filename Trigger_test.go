@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTrigger(t *testing.T) {
+	tempDir := t.TempDir()
+	cssFile := filepath.Join(tempDir, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var called int32
+	reloadCalled := make(chan struct{}, 1)
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".css"},
+		}},
+		BrowserReload: func() error {
+			reloadCalled <- struct{}{}
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	// relative path resolved against AppRootDir
+	if err := w.Trigger("style.css", ""); err != nil {
+		t.Fatalf("Trigger returned error: %v", err)
+	}
+	if atomic.LoadInt32(&called) != 1 {
+		t.Fatal("expected handler to be called by Trigger")
+	}
+
+	select {
+	case <-reloadCalled:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("expected browser reload after Trigger")
+	}
+
+	if err := w.Trigger("style.css", "chmod"); err == nil {
+		t.Error("expected error for invalid event")
+	}
+	if err := w.Trigger("missing.css", "write"); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if err := w.Trigger(".hidden.css", "write"); err == nil {
+		t.Error("expected error for unobserved path")
+	}
+}
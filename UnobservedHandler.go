@@ -0,0 +1,15 @@
+package devwatch
+
+// UnobservedHandler is an optional capability for handlers that write files
+// the watcher must ignore (eg: their build output).
+type UnobservedHandler interface {
+	UnobservedFiles() []string // eg: main.exe, main.js
+}
+
+// handlerUnobserved returns the files handler asks the watcher to ignore
+func handlerUnobserved(handler FilesEventHandlers) []string {
+	if uh, ok := handler.(UnobservedHandler); ok {
+		return uh.UnobservedFiles()
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package devwatch
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Validate checks the configuration for common mistakes and reports all of
+// them at once: missing AppRootDir, handlers that can never match a file,
+// main input files that don't exist or are hidden by ignore rules, a
+// dependency cycle between pipeline stages or a stage with dependents
+// whose result is deferred.
+// FileWatcherStart runs it and logs every problem found.
+func (h *DevWatch) Validate() []error {
+	var errs []error
+
+	if h.AppRootDir == "" {
+		errs = append(errs, errors.New("AppRootDir is empty"))
+	} else if info, err := os.Stat(h.AppRootDir); err != nil {
+		errs = append(errs, errors.New("AppRootDir not found: "+h.AppRootDir))
+	} else if !info.IsDir() {
+		errs = append(errs, errors.New("AppRootDir is not a directory: "+h.AppRootDir))
+	}
+
+	if h.LogFormat != "" && h.LogFormat != "json" {
+		errs = append(errs, errors.New(`LogFormat must be "" or "json": `+h.LogFormat))
+	}
+
+	h.loadUnobservedFiles()
+
+	for i, handler := range h.FilesEventHandlers {
+		if handler == nil {
+			errs = append(errs, fmt.Errorf("handler at index %d is nil", i))
+			continue
+		}
+		name := handlerName(handler)
+
+		_, byName := handler.(FilenameHandler)
+		_, byMatcher := handler.(FileMatcher)
+		if len(handler.SupportedExtensions()) == 0 && !byName && !byMatcher {
+			errs = append(errs, errors.New("handler "+name+": SupportedExtensions is empty, it will never receive events"))
+		}
+		if sh, ok := handler.(SourceHandler); ok {
+			for _, source := range sh.Sources() {
+				if !slices.Contains(slices.Collect(maps.Values(h.RootSources)), source) {
+					errs = append(errs, errors.New("handler "+name+": source "+source+" is not named by RootSources"))
+				}
+			}
+		}
+
+		mains := handlerMains(handler)
+		if len(mains) == 0 {
+			if _, goHandler := matchExtension("x.go", handler.SupportedExtensions()); goHandler && !ownsAllGoFiles(handler) {
+				errs = append(errs, errors.New("handler "+name+": supports .go but declares no main input file"))
+			}
+			continue
+		}
+		if h.AppRootDir == "" {
+			continue
+		}
+
+		for _, main := range mains {
+			mainPath := filepath.Join(h.AppRootDir, main)
+			if _, err := os.Stat(mainPath); err != nil {
+				errs = append(errs, errors.New("handler "+name+": main input file not found: "+main))
+			} else if h.Contain(mainPath) {
+				errs = append(errs, errors.New("handler "+name+": main input file is hidden by ignore rules: "+main))
+			}
+		}
+	}
+
+	for _, handler := range h.deferredUpstream(h.FilesEventHandlers) {
+		stage, _ := handlerStage(handler)
+		errs = append(errs, errors.New("handler "+handlerName(handler)+": stage "+stage+" has dependents but its result is async, debounced or batched, a failure won't skip them"))
+	}
+	if stageCycle(h.FilesEventHandlers) {
+		errs = append(errs, errors.New("pipeline stages: dependency cycle, handlers run in registration order"))
+	}
+
+	return errs
+}
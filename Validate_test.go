@@ -0,0 +1,66 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "cmd", "server"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "cmd", "server", "main.go"), []byte("package main"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid config", func(t *testing.T) {
+		w := New(&WatchConfig{
+			AppRootDir: tempDir,
+			FilesEventHandlers: []FilesEventHandlers{
+				&FakeFilesEventHandler{SupportedExtensions_: []string{".go"}, MainInputFile: "cmd/server/main.go"},
+			},
+			Logger: func(message ...any) {},
+		})
+		if errs := w.Validate(); len(errs) != 0 {
+			t.Errorf("expected no errors, got %v", errs)
+		}
+	})
+
+	t.Run("all problems reported", func(t *testing.T) {
+		w := New(&WatchConfig{
+			AppRootDir: tempDir,
+			FilesEventHandlers: []FilesEventHandlers{
+				&FakeFilesEventHandler{}, // no extensions
+				&FakeFilesEventHandler{SupportedExtensions_: []string{".go"}, MainInputFile: "missing.go"}, // missing main
+				&FakeFilesEventHandler{SupportedExtensions_: []string{".go"}, MainInputFile: "cmd/server/main.go"},
+			},
+			UnobservedFiles: func() []string { return []string{"cmd"} }, // hides main input
+			Logger:          func(message ...any) {},
+		})
+		errs := w.Validate()
+		wants := []string{"SupportedExtensions is empty", "not found: missing.go", "hidden by ignore rules"}
+		if len(errs) != 4 { // first handler also has missing fake/main.go
+			t.Errorf("expected 4 errors, got %d: %v", len(errs), errs)
+		}
+		joined := ""
+		for _, err := range errs {
+			joined += err.Error() + "\n"
+		}
+		for _, want := range wants {
+			if !strings.Contains(joined, want) {
+				t.Errorf("missing error containing %q in:\n%s", want, joined)
+			}
+		}
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		w := New(&WatchConfig{AppRootDir: filepath.Join(tempDir, "nope"), Logger: func(message ...any) {}})
+		errs := w.Validate()
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), "AppRootDir not found") {
+			t.Errorf("unexpected errors %v", errs)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// wasmFreshTimeout is how long a reload waits for a wasm output to be rewritten
+const wasmFreshTimeout = 2 * time.Second
+
+// WasmOutputHandler is an optional capability for handlers that compile a
+// WebAssembly binary. Before the browser reloads, devwatch verifies the
+// output was rewritten after the event that triggered the build, so the
+// page never loads a stale main.wasm.
+type WasmOutputHandler interface {
+	WasmOutputPath() string // eg: "web/public/main.wasm" relative to AppRootDir
+}
+
+// wasmCoordinator tracks the wasm outputs the next reload must wait for
+type wasmCoordinator struct {
+	mu      sync.Mutex
+	pending map[string]time.Time // output path -> modification time before the build
+	version string
+}
+
+// wasmMark records the modification time of handler's wasm output before
+// the handler runs. Call the returned func once the handler succeeded (or
+// started async work) to make the next reload wait for a newer output.
+// File timestamps come from a coarse kernel clock, so the output is compared
+// with its previous mtime rather than with the event time.
+func (h *DevWatch) wasmMark(handler FilesEventHandlers) func() {
+	wh, ok := handler.(WasmOutputHandler)
+	if !ok || wh.WasmOutputPath() == "" {
+		return func() {}
+	}
+	output := wh.WasmOutputPath()
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(h.AppRootDir, output)
+	}
+	var before time.Time
+	if info, err := statRetry(output); err == nil {
+		before = info.ModTime()
+	}
+
+	return func() {
+		h.wasm.mu.Lock()
+		defer h.wasm.mu.Unlock()
+		if h.wasm.pending == nil {
+			h.wasm.pending = make(map[string]time.Time)
+		}
+		if prev, exists := h.wasm.pending[output]; !exists || before.Before(prev) {
+			h.wasm.pending[output] = before
+		}
+	}
+}
+
+// wasmReady waits until every expected wasm output was rewritten after its
+// build started. Returns false (and logs) when one stays stale.
+func (h *DevWatch) wasmReady() bool {
+	h.wasm.mu.Lock()
+	pending := h.wasm.pending
+	h.wasm.pending = nil
+	h.wasm.mu.Unlock()
+
+	var newest time.Time
+	for output, before := range pending {
+		mtime, fresh := waitNewer(output, before, wasmFreshTimeout)
+		if !fresh {
+			h.say("wasm-stale", output)
+			return false
+		}
+		if mtime.After(newest) {
+			newest = mtime
+		}
+	}
+
+	if !newest.IsZero() {
+		h.wasm.mu.Lock()
+		h.wasm.version = "v=" + strconv.FormatInt(newest.UnixNano(), 36)
+		h.wasm.mu.Unlock()
+	}
+	return true
+}
+
+// waitNewer polls path until its modification time is after t or timeout expires
+func waitNewer(path string, t time.Time, timeout time.Duration) (time.Time, bool) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if info, err := statRetry(path); err == nil && info.Size() > 0 && info.ModTime().After(t) {
+			return info.ModTime(), true
+		}
+		if time.Now().After(deadline) {
+			return time.Time{}, false
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// WasmVersion returns a cache-busting query parameter ("v=...") for the last
+// verified wasm output, or "" when no wasm handler ran yet. BrowserReload can
+// append it to the wasm URL in its reload message.
+func (h *DevWatch) WasmVersion() string {
+	h.wasm.mu.Lock()
+	defer h.wasm.mu.Unlock()
+	return h.wasm.version
+}
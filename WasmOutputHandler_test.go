@@ -0,0 +1,74 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// wasmHandler compiles main.go into main.wasm, or leaves it stale when skip is set
+type wasmHandler struct {
+	output string
+	skip   bool
+}
+
+func (w *wasmHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	if w.skip {
+		return nil
+	}
+	return os.WriteFile(w.output, []byte("\x00asm"), 0644)
+}
+
+func (w *wasmHandler) SupportedExtensions() []string     { return []string{".wgo"} }
+func (w *wasmHandler) MainInputFileRelativePath() string { return "" }
+func (w *wasmHandler) UnobservedFiles() []string         { return nil }
+func (w *wasmHandler) WasmOutputPath() string            { return "main.wasm" }
+
+func TestWasmOutputHandler(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "app.wgo")
+	output := filepath.Join(root, "main.wasm")
+	for _, f := range []string{src, output} {
+		if err := os.WriteFile(f, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-time.Minute)
+	os.Chtimes(output, past, past)
+
+	var reloads int32
+	handler := &wasmHandler{output: output}
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		BrowserReload: func() error {
+			atomic.AddInt32(&reloads, 1)
+			return nil
+		},
+		Logger: func(message ...any) { t.Log(message...) },
+	})
+
+	// fresh output: reload fires and exposes a cache-busting version
+	if err := w.Trigger("app.wgo", "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if atomic.LoadInt32(&reloads) != 1 {
+		t.Fatalf("expected reload after wasm rebuild, got %d", reloads)
+	}
+	if w.WasmVersion() == "" {
+		t.Error("expected WasmVersion after verified rebuild")
+	}
+
+	// stale output: reload is skipped
+	handler.skip = true
+	if err := w.Trigger("app.wgo", "write"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(wasmFreshTimeout + 300*time.Millisecond)
+	if atomic.LoadInt32(&reloads) != 1 {
+		t.Errorf("expected reload to be skipped for stale wasm output, got %d", reloads)
+	}
+}
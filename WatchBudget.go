@@ -0,0 +1,257 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// defaultPollInterval is how often directories beyond WatchBudget are scanned
+const defaultPollInterval = 2 * time.Second
+
+// WatchAllocation reports how the directories were split between watch
+// handles and polling, see WatchConfig.WatchBudget
+type WatchAllocation struct {
+	Budget       int           // WatchConfig.WatchBudget (0 = unlimited)
+	Watched      int           // directories added to the watcher
+	Polled       []string      // directories scanned every PollInterval, relative to AppRootDir
+	PollInterval time.Duration // scan period of Polled
+}
+
+// fileStamp is what polling compares to detect a change
+type fileStamp struct {
+	dir     bool
+	size    int64
+	modTime time.Time
+}
+
+// watchBudget holds the directories left out of the watcher
+type watchBudget struct {
+	mu       sync.Mutex
+	deferred map[string]bool                 // canonical dirs the startup census left to polling
+	polled   map[string]map[string]fileStamp // polled dir => entry name => stamp
+}
+
+// WatchAllocation returns the current split between watched and polled directories
+func (h *DevWatch) WatchAllocation() WatchAllocation {
+	h.budget.mu.Lock()
+	polled := make([]string, 0, len(h.budget.polled))
+	for dir := range h.budget.polled {
+		polled = append(polled, h.relativePath(dir))
+	}
+	h.budget.mu.Unlock()
+	slices.Sort(polled)
+	return WatchAllocation{
+		Budget:       h.WatchBudget,
+		Watched:      h.registry.count(),
+		Polled:       polled,
+		PollInterval: h.pollInterval(),
+	}
+}
+
+func (h *DevWatch) pollInterval() time.Duration {
+	if h.PollInterval > 0 {
+		return h.PollInterval
+	}
+	return defaultPollInterval
+}
+
+// overBudget reports whether dir must be polled instead of watched: the
+// startup census left it out, or the watcher already holds WatchBudget
+// directories
+func (h *DevWatch) overBudget(dir string) bool {
+	if h.WatchBudget <= 0 || h.registry.has(dir) {
+		return false
+	}
+	h.budget.mu.Lock()
+	deferred := h.budget.deferred[canonicalPath(dir)]
+	h.budget.mu.Unlock()
+	return deferred || h.registry.count() >= h.WatchBudget
+}
+
+// planWatchBudget counts the directories under the watch roots and, when
+// they exceed WatchBudget, picks the ones to watch: subtrees holding the
+// most files some handler supports first, shallower folders on ties. The
+// others are polled.
+func (h *DevWatch) planWatchBudget() {
+	type dirCensus struct {
+		path     string
+		depth    int
+		matching int // files supported by a handler in the subtree
+	}
+	var dirs []*dirCensus
+	index := make(map[string]*dirCensus)
+	for _, root := range h.watchRoots() {
+		rootDepth := strings.Count(filepath.Clean(root), string(filepath.Separator))
+		h.walkRoot(root, func(path string, isDir bool) error {
+			if isDir {
+				if h.Contain(path) || h.beyondDepth(path) {
+					return filepath.SkipDir
+				}
+				d := &dirCensus{path: path, depth: strings.Count(path, string(filepath.Separator)) - rootDepth}
+				dirs = append(dirs, d)
+				index[path] = d
+				return nil
+			}
+			if h.Contain(path) || !h.anyHandlerSupports(path) {
+				return nil
+			}
+			for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+				d, ok := index[dir]
+				if !ok {
+					break
+				}
+				d.matching++
+			}
+			return nil
+		})
+	}
+	if len(dirs) <= h.WatchBudget {
+		return
+	}
+
+	// ancestors hold at least the files of their subtrees, so the chosen
+	// directories always include the parents of the chosen ones
+	slices.SortStableFunc(dirs, func(a, b *dirCensus) int {
+		if a.matching != b.matching {
+			return b.matching - a.matching
+		}
+		return a.depth - b.depth
+	})
+	deferred := make(map[string]bool, len(dirs)-h.WatchBudget)
+	for _, d := range dirs[h.WatchBudget:] {
+		deferred[canonicalPath(d.path)] = true
+	}
+	h.budget.mu.Lock()
+	h.budget.deferred = deferred
+	h.budget.mu.Unlock()
+	h.say("watch-budget", h.WatchBudget, len(dirs), h.pollInterval())
+}
+
+// anyHandlerSupports reports whether some handler would receive path
+func (h *DevWatch) anyHandlerSupports(path string) bool {
+	extension := filepath.Ext(path)
+	rel, source := h.relativePath(path), h.sourceOf(path)
+	for _, handler := range h.FilesEventHandlers {
+		if _, ok := handlerSupports(handler, path, extension); ok && handlerInScope(handler, rel) && handlerForSource(handler, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// pollDirectory starts polling dir; its current entries are the baseline
+func (h *DevWatch) pollDirectory(dir string) {
+	h.budget.mu.Lock()
+	defer h.budget.mu.Unlock()
+	if _, polled := h.budget.polled[dir]; polled {
+		return
+	}
+	if h.budget.polled == nil {
+		h.budget.polled = make(map[string]map[string]fileStamp)
+	}
+	h.budget.polled[dir] = readStamps(dir)
+	h.say("path-polled", dir)
+}
+
+// readStamps returns the stamps of the entries of dir, nil when it is gone
+func readStamps(dir string) map[string]fileStamp {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	stamps := make(map[string]fileStamp, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		stamps[entry.Name()] = fileStamp{dir: entry.IsDir(), size: info.Size(), modTime: info.ModTime()}
+	}
+	return stamps
+}
+
+// startPolling scans the polled directories every PollInterval and sends
+// their changes as watcher events until the returned stop is called
+func (h *DevWatch) startPolling() (<-chan fsnotify.Event, func()) {
+	events := make(chan fsnotify.Event)
+	if h.WatchBudget <= 0 {
+		return events, func() {}
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(h.pollInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			for _, event := range h.pollChanges() {
+				select {
+				case events <- event:
+				case <-stop:
+					return
+				}
+			}
+		}
+	}()
+	return events, func() {
+		close(stop)
+		<-done
+	}
+}
+
+// pollChanges scans the polled directories and returns the changes since
+// the previous scan. Directories that are gone stop being polled.
+func (h *DevWatch) pollChanges() []fsnotify.Event {
+	h.budget.mu.Lock()
+	dirs := make([]string, 0, len(h.budget.polled))
+	for dir := range h.budget.polled {
+		dirs = append(dirs, dir)
+	}
+	h.budget.mu.Unlock()
+	slices.Sort(dirs) // parents first: new subdirectories are registered before their files
+
+	var events []fsnotify.Event
+	for _, dir := range dirs {
+		current := readStamps(dir)
+		h.budget.mu.Lock()
+		previous, polled := h.budget.polled[dir]
+		if !polled {
+			h.budget.mu.Unlock()
+			continue
+		}
+		if current == nil {
+			delete(h.budget.polled, dir)
+		} else {
+			h.budget.polled[dir] = current
+		}
+		h.budget.mu.Unlock()
+
+		for name, stamp := range current {
+			path := filepath.Join(dir, name)
+			before, existed := previous[name]
+			switch {
+			case !existed:
+				events = append(events, fsnotify.Event{Name: path, Op: fsnotify.Create})
+			case !stamp.dir && (stamp.size != before.size || !stamp.modTime.Equal(before.modTime)):
+				events = append(events, fsnotify.Event{Name: path, Op: fsnotify.Write})
+			}
+		}
+		for name := range previous {
+			if _, exists := current[name]; !exists {
+				events = append(events, fsnotify.Event{Name: filepath.Join(dir, name), Op: fsnotify.Remove})
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,58 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchBudget_PollsLowPrioritySubtrees(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{"web/app.css", "docs/a/b/notes.txt"} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := &eventRecorder{}
+	exit := make(chan bool)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		WatchBudget:        2,
+		PollInterval:       50 * time.Millisecond,
+		Logger:             func(message ...any) {},
+		ExitChan:           exit,
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	defer func() {
+		close(exit)
+		wg.Wait()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	alloc := w.WatchAllocation()
+	if alloc.Watched != 2 || !slices.Equal(alloc.Polled, []string{"docs", "docs/a", "docs/a/b"}) {
+		t.Fatalf("allocation = %+v, want root and web watched, docs polled", alloc)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "docs", "a", "b", "print.css"), []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for !slices.Contains(handler.received(), "print.css:create") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the polled file to reach the handler, got %v", handler.received())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,117 @@
+package devwatch
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WebSocketReload is a ReloadTransport sending each ReloadMessage as JSON
+// text to the WebSocket clients connected to it, eg: editor extensions or
+// dev tools preferring WebSockets to the EventSource of LiveReload:
+//
+//	ws := &devwatch.WebSocketReload{}
+//	mux.Handle("/devwatch/ws", ws)
+//	cfg.ReloadTransports = append(cfg.ReloadTransports, ws)
+//	// client: new WebSocket("ws://localhost:8080/devwatch/ws").onmessage = ...
+//
+// Browsers may only connect from pages of the host serving the socket, so
+// other sites the developer visits can't; clients sending no Origin (editor
+// extensions, CLIs) are accepted.
+type WebSocketReload struct {
+	Origins []string // other page origins accepted eg: "http://localhost:5173"
+
+	mu      sync.Mutex
+	clients map[*wsClient]bool
+}
+
+// wsClient is a connected WebSocket; writes are serialized
+type wsClient struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// wsWriteTimeout drops clients not reading their messages
+const wsWriteTimeout = 5 * time.Second
+
+// ServeHTTP accepts a WebSocket client and keeps it until it disconnects
+func (ws *WebSocketReload) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !originAllowed(r, ws.Origins) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	conn, br, err := upgradeWS(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	client := &wsClient{conn: conn}
+	ws.mu.Lock()
+	if ws.clients == nil {
+		ws.clients = make(map[*wsClient]bool)
+	}
+	ws.clients[client] = true
+	ws.mu.Unlock()
+	defer ws.drop(client)
+
+	// clients send nothing but control frames
+	for {
+		op, payload, err := readWSFrame(br, true)
+		if err != nil {
+			return
+		}
+		switch op {
+		case wsClose:
+			client.write(wsClose, nil)
+			return
+		case wsPing:
+			client.write(wsPong, payload)
+		}
+	}
+}
+
+// NotifyReload sends msg to every connected client; clients failing to
+// receive it are disconnected
+func (ws *WebSocketReload) NotifyReload(msg ReloadMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	clients := make([]*wsClient, 0, len(ws.clients))
+	for client := range ws.clients {
+		clients = append(clients, client)
+	}
+	ws.mu.Unlock()
+	for _, client := range clients {
+		if client.write(wsText, data) != nil {
+			ws.drop(client)
+		}
+	}
+	return nil
+}
+
+// Clients returns the number of connected clients
+func (ws *WebSocketReload) Clients() int {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return len(ws.clients)
+}
+
+// drop disconnects client
+func (ws *WebSocketReload) drop(client *wsClient) {
+	ws.mu.Lock()
+	delete(ws.clients, client)
+	ws.mu.Unlock()
+	client.conn.Close()
+}
+
+// write sends one frame to the client
+func (c *wsClient) write(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return writeWSFrame(c.conn, opcode, payload, false)
+}
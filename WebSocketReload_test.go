@@ -0,0 +1,111 @@
+package devwatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebSocketReload(t *testing.T) {
+	ws := &WebSocketReload{}
+	srv := httptest.NewServer(ws)
+	t.Cleanup(srv.Close)
+
+	conn, br, err := dialWS("ws"+strings.TrimPrefix(srv.URL, "http")+"/devwatch/ws", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(time.Second)
+	for ws.Clients() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 1 client, got %d", ws.Clients())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// a ping is answered, the client stays connected
+	if err := writeWSFrame(conn, wsPing, []byte("hi"), true); err != nil {
+		t.Fatal(err)
+	}
+	if op, payload, err := readWSFrame(br, false); err != nil || op != wsPong || string(payload) != "hi" {
+		t.Fatalf("expected pong, got %v %q %v", op, payload, err)
+	}
+
+	if err := ws.NotifyReload(ReloadMessage{Paths: []string{"web/public/main.js"}, Time: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	op, payload, err := readWSFrame(br, false)
+	if err != nil || op != wsText {
+		t.Fatalf("expected a text message, got %v %v", op, err)
+	}
+	var msg ReloadMessage
+	if err := json.Unmarshal(payload, &msg); err != nil || !slices.Equal(msg.Paths, []string{"web/public/main.js"}) {
+		t.Errorf("unexpected message %s: %v", payload, err)
+	}
+
+	writeWSFrame(conn, wsClose, nil, true)
+	for ws.Clients() != 0 {
+		if time.Now().After(deadline.Add(time.Second)) {
+			t.Fatalf("expected the closed client to be dropped")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWebSocketReload_NotWebSocket(t *testing.T) {
+	srv := httptest.NewServer(&WebSocketReload{})
+	t.Cleanup(srv.Close)
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Errorf("expected 400 for a plain request, got %d", resp.StatusCode)
+	}
+}
+
+func TestWebSocketReload_Origin(t *testing.T) {
+	srv := httptest.NewServer(&WebSocketReload{Origins: []string{"http://localhost:5173"}})
+	t.Cleanup(srv.Close)
+	for origin, want := range map[string]int{
+		"https://evil.example":  http.StatusForbidden,
+		"http://localhost:5173": http.StatusSwitchingProtocols,
+		srv.URL:                 http.StatusSwitchingProtocols,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("origin %s: got status %d, want %d", origin, resp.StatusCode, want)
+		}
+	}
+}
+
+func TestReadWSFrame_Malformed(t *testing.T) {
+	// a client frame claiming a 2^64-1 bytes payload
+	huge := []byte{0x81, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}
+	if _, _, err := readWSFrame(bytes.NewReader(huge), true); err == nil {
+		t.Error("expected an oversized frame to be rejected")
+	}
+
+	var unmasked bytes.Buffer
+	writeWSFrame(&unmasked, wsText, []byte("hi"), false)
+	if _, _, err := readWSFrame(&unmasked, true); err == nil {
+		t.Error("expected an unmasked client frame to be rejected")
+	}
+}
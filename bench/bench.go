@@ -0,0 +1,123 @@
+// Package bench generates synthetic devwatch workloads and measures event
+// dispatch latency and allocations, to validate changes to the event loop,
+// queueing and parallelism.
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cdvelop/devwatch"
+)
+
+// Workload describes a synthetic project tree
+type Workload struct {
+	Dirs        int    // number of directories (nested two levels deep)
+	FilesPerDir int    // files created in each directory
+	Extension   string // file extension eg: ".css" (default ".css")
+	FileSize    int    // bytes per file (default 256)
+	Rate        int    // events per second when dispatching, 0 = as fast as possible
+}
+
+// Generate creates the workload tree under root and returns the file paths
+func (w Workload) Generate(root string) ([]string, error) {
+	if w.Dirs <= 0 || w.FilesPerDir <= 0 {
+		return nil, errors.New("Generate: Dirs and FilesPerDir must be positive")
+	}
+	ext := w.Extension
+	if ext == "" {
+		ext = ".css"
+	}
+	size := w.FileSize
+	if size <= 0 {
+		size = 256
+	}
+	content := []byte(strings.Repeat("x", size))
+
+	var files []string
+	for d := range w.Dirs {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%d", d%10), fmt.Sprintf("dir%d", d))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		for f := range w.FilesPerDir {
+			path := filepath.Join(dir, fmt.Sprintf("file%d%s", f, ext))
+			if err := os.WriteFile(path, content, 0644); err != nil {
+				return nil, err
+			}
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// Result summarizes a dispatch run
+type Result struct {
+	Events         int
+	Total          time.Duration
+	P50, P99, Max  time.Duration // per event dispatch latency
+	AllocsPerEvent float64
+	BytesPerEvent  float64
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%d events in %v (p50 %v, p99 %v, max %v) %.1f allocs/event %.0f B/event",
+		r.Events, r.Total, r.P50, r.P99, r.Max, r.AllocsPerEvent, r.BytesPerEvent)
+}
+
+// Dispatch sends a "write" event for each path through dw.Trigger, paced by
+// w.Rate, and measures latency and allocations per event
+func (w Workload) Dispatch(dw *devwatch.DevWatch, paths []string) (Result, error) {
+	var interval time.Duration
+	if w.Rate > 0 {
+		interval = time.Second / time.Duration(w.Rate)
+	}
+
+	latencies := make([]time.Duration, 0, len(paths))
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for i, path := range paths {
+		if interval > 0 {
+			if wait := time.Until(start.Add(time.Duration(i) * interval)); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		t := time.Now()
+		if err := dw.Trigger(path, "write"); err != nil {
+			return Result{}, err
+		}
+		latencies = append(latencies, time.Since(t))
+	}
+
+	total := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	r := Result{Events: len(paths), Total: total}
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		r.P50 = latencies[(len(latencies)-1)*50/100]
+		r.P99 = latencies[(len(latencies)-1)*99/100]
+		r.Max = latencies[len(latencies)-1]
+		r.AllocsPerEvent = float64(after.Mallocs-before.Mallocs) / float64(len(paths))
+		r.BytesPerEvent = float64(after.TotalAlloc-before.TotalAlloc) / float64(len(paths))
+	}
+	return r, nil
+}
+
+// NopHandler accepts every event for its extensions without doing work,
+// so measurements only include devwatch overhead
+type NopHandler struct {
+	Extensions []string
+}
+
+func (n NopHandler) NewFileEvent(fileName, extension, filePath, event string) error { return nil }
+func (n NopHandler) SupportedExtensions() []string                                  { return n.Extensions }
@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/cdvelop/devwatch"
+)
+
+func newWatch(root string) *devwatch.DevWatch {
+	return devwatch.New(&devwatch.WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []devwatch.FilesEventHandlers{NopHandler{Extensions: []string{".css"}}},
+		Logger:             func(message ...any) {},
+		LoopLimit:          -1, // workloads replay unchanged files
+		UnobservedFiles:    func() []string { return []string{".git", "node_modules", "dist", ".vscode"} },
+	})
+}
+
+func TestWorkload(t *testing.T) {
+	root := t.TempDir()
+	w := Workload{Dirs: 4, FilesPerDir: 5}
+	files, err := w.Generate(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 20 {
+		t.Fatalf("expected 20 files, got %d", len(files))
+	}
+
+	r, err := w.Dispatch(newWatch(root), files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Events != 20 || r.Max <= 0 {
+		t.Errorf("unexpected result: %v", r)
+	}
+	t.Log(r)
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	root := b.TempDir()
+	files, err := Workload{Dirs: 20, FilesPerDir: 10}.Generate(root)
+	if err != nil {
+		b.Fatal(err)
+	}
+	dw := newWatch(root)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		if err := dw.Trigger(files[i%len(files)], "write"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkContain(b *testing.B) {
+	root := b.TempDir()
+	files, err := Workload{Dirs: 20, FilesPerDir: 10}.Generate(root)
+	if err != nil {
+		b.Fatal(err)
+	}
+	dw := newWatch(root)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		dw.Contain(files[i%len(files)])
+	}
+}
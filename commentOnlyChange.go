@@ -0,0 +1,115 @@
+package devwatch
+
+import (
+	"crypto/sha256"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// goTokenSignature hashes the token stream of a Go source file ignoring
+// comments and whitespace. Two versions of a file with the same signature
+// differ only in comments or formatting. Comments that change the build are
+// hashed as code: directives (//go:build, //go:embed, //go:generate, ...)
+// and the cgo preamble right before import "C".
+func goTokenSignature(filePath string) ([32]byte, bool) {
+	src, err := readFileRetry(filePath)
+	if err != nil {
+		return [32]byte{}, false
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile(filePath, fset.Base(), len(src))
+	var s scanner.Scanner
+	var scanErr bool
+	s.Init(file, src, func(token.Position, string) { scanErr = true }, scanner.ScanComments)
+
+	hasher := sha256.New()
+	write := func(tok token.Token, lit string) {
+		hasher.Write([]byte(tok.String()))
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(lit))
+		hasher.Write([]byte{0})
+	}
+	var comments, preamble []string // comments since the last token, before the last import
+	prev := token.ILLEGAL
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.COMMENT {
+			if isDirective(lit) {
+				write(tok, lit)
+			}
+			comments = append(comments, lit)
+			continue
+		}
+		if tok == token.SEMICOLON {
+			lit = ";" // automatic and explicit semicolons are equivalent
+		}
+		if prev == token.IMPORT && tok == token.STRING && lit == `"C"` {
+			write(token.COMMENT, strings.Join(preamble, "\n"))
+		}
+		write(tok, lit)
+		if tok == token.IMPORT {
+			preamble = comments
+		}
+		comments, prev = nil, tok
+	}
+	if scanErr {
+		return [32]byte{}, false
+	}
+
+	var sig [32]byte
+	copy(sig[:], hasher.Sum(nil))
+	return sig, true
+}
+
+// isDirective reports whether comment instructs the toolchain, eg: //go:embed
+func isDirective(comment string) bool {
+	return strings.HasPrefix(comment, "//go:") || strings.HasPrefix(comment, "// +build")
+}
+
+// recordGoSignature stores the token signature of a Go file when
+// SkipCommentOnlyChanges is enabled
+func (h *DevWatch) recordGoSignature(filePath string) {
+	if !h.SkipCommentOnlyChanges {
+		return
+	}
+	sig, ok := goTokenSignature(filePath)
+	h.sigMu.Lock()
+	defer h.sigMu.Unlock()
+	if h.goSignatures == nil {
+		h.goSignatures = make(map[string][32]byte)
+	}
+	if ok {
+		h.goSignatures[filePath] = sig
+	} else {
+		delete(h.goSignatures, filePath)
+	}
+}
+
+// isCommentOnlyChange reports whether a write to a Go file only touched
+// comments or whitespace since the last recorded version, and records the new one.
+func (h *DevWatch) isCommentOnlyChange(filePath, event string) bool {
+	if !h.SkipCommentOnlyChanges {
+		return false
+	}
+	if event != "write" {
+		h.recordGoSignature(filePath)
+		return false
+	}
+
+	h.sigMu.Lock()
+	previous, known := h.goSignatures[filePath]
+	h.sigMu.Unlock()
+
+	h.recordGoSignature(filePath)
+
+	h.sigMu.Lock()
+	current, ok := h.goSignatures[filePath]
+	h.sigMu.Unlock()
+
+	return known && ok && current == previous
+}
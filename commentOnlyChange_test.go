@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsCommentOnlyChange(t *testing.T) {
+	goFile := filepath.Join(t.TempDir(), "main.go")
+	write := func(content string) {
+		if err := os.WriteFile(goFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := New(&WatchConfig{SkipCommentOnlyChanges: true, Logger: func(message ...any) {}})
+
+	write("package main\n\nfunc main() {}\n")
+	w.recordGoSignature(goFile)
+
+	steps := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"add comment", "package main\n\n// main starts the app\nfunc main() {} // entry\n", true},
+		{"reformat", "package main\nfunc main() {\n}\n", true},
+		{"code change", "package main\n\nfunc main() { println(1) }\n", false},
+		{"same code again", "package main\n\nfunc main() { println(1) } /* note */\n", true},
+		{"string literal change", "package main\n\nfunc main() { println(2) }\n", false},
+		{"build constraint", "//go:build linux\n\npackage main\n\nfunc main() { println(2) }\n", false},
+		{"build constraint edit", "//go:build darwin\n\npackage main\n\nfunc main() { println(2) }\n", false},
+		{"embed", "//go:build darwin\n\npackage main\n\n//go:embed a.txt\nvar a string\n\nfunc main() { println(2) }\n", false},
+		{"embed pattern edit", "//go:build darwin\n\npackage main\n\n//go:embed b.txt\nvar a string\n\nfunc main() { println(2) }\n", false},
+		{"generate", "//go:build darwin\n\npackage main\n\n//go:generate stringer\n//go:embed b.txt\nvar a string\n\nfunc main() { println(2) }\n", false},
+		{"linkname", "//go:build darwin\n\npackage main\n\n//go:linkname now time.now\n//go:generate stringer\n//go:embed b.txt\nvar a string\n\nfunc main() { println(2) }\n", false},
+		{"comment beside directives", "//go:build darwin\n\npackage main\n\n// now is time.now\n//go:linkname now time.now\n//go:generate stringer\n//go:embed b.txt\nvar a string\n\nfunc main() { println(2) }\n", true},
+		{"cgo preamble", "package main\n\n// #include <stdio.h>\nimport \"C\"\n\nfunc main() {}\n", false},
+		{"cgo preamble edit", "package main\n\n// #include <stdlib.h>\nimport \"C\"\n\nfunc main() {}\n", false},
+		{"cgo block preamble", "package main\n\n/*\n#include <stdlib.h>\n*/\nimport \"C\"\n\nfunc main() {}\n", false},
+		{"comment after cgo import", "package main\n\n/*\n#include <stdlib.h>\n*/\nimport \"C\" // cgo\n\nfunc main() {}\n", true},
+	}
+	for _, step := range steps {
+		write(step.content)
+		if got := w.isCommentOnlyChange(goFile, "write"); got != step.want {
+			t.Errorf("%s: isCommentOnlyChange = %v, want %v", step.name, got, step.want)
+		}
+	}
+
+	// disabled by default
+	w.SkipCommentOnlyChanges = false
+	if w.isCommentOnlyChange(goFile, "write") {
+		t.Error("expected no detection when SkipCommentOnlyChanges is false")
+	}
+}
@@ -0,0 +1,81 @@
+package devwatch
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// containCacheSize bounds the cached Contain decisions; the cache is
+// cleared when it fills up
+const containCacheSize = 8192
+
+// ruleVersion identifies the rules Contain decisions depend on. Ignore
+// entries, internal paths and handlers are only ever added, so their
+// counts change whenever the rules do.
+type ruleVersion struct {
+	unobserved int
+	internal   int
+	handlers   int
+	root       string
+}
+
+// ruleVersion returns the current rules version
+func (h *DevWatch) ruleVersion() ruleVersion {
+	h.noAddMu.RLock()
+	unobserved := len(h.no_add_to_watch)
+	h.noAddMu.RUnlock()
+	h.internal.mu.RLock()
+	internal := len(h.internal.paths)
+	h.internal.mu.RUnlock()
+	return ruleVersion{unobserved: unobserved, internal: internal, handlers: len(h.FilesEventHandlers), root: h.AppRootDir}
+}
+
+// containCache is the path -> decision index in front of Contain
+type containCache struct {
+	mu        sync.Mutex
+	version   ruleVersion
+	decisions map[string]bool
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+}
+
+// get returns the cached decision for path if the rules did not change
+func (c *containCache) get(path string, version ruleVersion) (decision, ok bool) {
+	c.mu.Lock()
+	if c.version == version {
+		decision, ok = c.decisions[path]
+	}
+	c.mu.Unlock()
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return decision, ok
+}
+
+// put stores decision, dropping every entry computed under older rules
+func (c *containCache) put(path string, version ruleVersion, decision bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.decisions == nil || c.version != version || len(c.decisions) >= containCacheSize {
+		c.decisions = make(map[string]bool)
+		c.version = version
+	}
+	c.decisions[path] = decision
+}
+
+// ContainCacheStats reports the effectiveness of the Contain decision cache
+type ContainCacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// ContainCacheStats returns the Contain cache hit and miss counters
+func (h *DevWatch) ContainCacheStats() ContainCacheStats {
+	h.containCache.mu.Lock()
+	entries := len(h.containCache.decisions)
+	h.containCache.mu.Unlock()
+	return ContainCacheStats{Hits: h.containCache.hits.Load(), Misses: h.containCache.misses.Load(), Entries: entries}
+}
@@ -0,0 +1,40 @@
+package devwatch
+
+import "strings"
+
+// beyondDepth reports whether dir lies deeper than WatchConfig.MaxDepth
+// below its watch root, or deeper than the DepthLimits entry of the most
+// specific folder containing it
+func (h *DevWatch) beyondDepth(dir string) bool {
+	if h.MaxDepth <= 0 && len(h.DepthLimits) == 0 {
+		return false
+	}
+	abs := absSlashPath(dir)
+
+	// limit < 0: unlimited below base
+	limit, base := -1, ""
+	for _, root := range h.watchRoots() {
+		if r := absSlashPath(root); withinDir(abs, r) {
+			base = r
+			if h.MaxDepth > 0 {
+				limit = h.MaxDepth
+			}
+			break
+		}
+	}
+	for folder, folderLimit := range h.DepthLimits {
+		f := absSlashPath(h.absPath(folder))
+		if withinDir(abs, f) && len(f) > len(base) {
+			limit, base = folderLimit, f
+		}
+	}
+	if limit < 0 {
+		return false
+	}
+	return strings.Count(strings.TrimPrefix(abs, base), "/") > limit
+}
+
+// withinDir reports whether the slash path p is dir or lies below it
+func withinDir(p, dir string) bool {
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
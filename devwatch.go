@@ -1,7 +1,9 @@
 package devwatch
 
 import (
+	"io/fs"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cdvelop/godepfind"
@@ -10,12 +12,13 @@ import (
 
 // FilesEventHandlers unifies asset and Go file event handling.
 // It allows handlers to specify which file extensions they support and how to process them.
+//
+// Everything else is optional, discovered by type assertion: MainInputHandler,
+// UnobservedHandler, NamedHandler, AsyncFileEventHandler, ScopedHandler, ...
 type FilesEventHandlers interface {
-	MainInputFileRelativePath() string // eg: go => "app/server/main.go" | js =>"app/pwa/public/main.js"
 	// NewFileEvent handles file events (create, remove, write, rename).
 	NewFileEvent(fileName, extension, filePath, event string) error
 	SupportedExtensions() []string // eg: [".go"], [".js",".css"], etc.
-	UnobservedFiles() []string     // eg: main.exe, main.js
 }
 
 // event: create, remove, write, rename
@@ -24,14 +27,192 @@ type FolderEvent interface {
 }
 
 type WatchConfig struct {
-	AppRootDir         string               // eg: "home/user/myNewApp"
+	AppRootDir string // eg: "home/user/myNewApp"
+	// ExtraRootDirs are watched besides AppRootDir (eg: a shared module).
+	// Roots that overlap are registered once, each directory is watched once.
+	ExtraRootDirs []string
+	// RootSources names the events of each root for FileChange.Source and
+	// SourceHandler, eg: {"web": "frontend", "../api": "api"}. Keys are roots
+	// or directories below them, absolute or relative to AppRootDir; the
+	// deepest one containing a file names it.
+	RootSources map[string]string
+	// DefaultIgnores skips VCS and tool folders (.git, .hg, .svn, .jj, .bzr,
+	// node_modules, vendor, .terraform) inside the watch roots. On when nil;
+	// DefaultIgnores: new(bool) watches them like any other directory.
+	DefaultIgnores *bool
+	// DefaultIgnoreDirs replaces the folder names skipped by DefaultIgnores
+	// when not nil, eg: []string{".git", "node_modules", "dist"}
+	DefaultIgnoreDirs []string
+	// MaxDepth limits the directory levels watched below each root (0 =
+	// unlimited), eg: 2 watches root/a/b but not root/a/b/c. Deeper folders
+	// are neither watched nor walked at startup.
+	MaxDepth int
+	// DepthLimits overrides MaxDepth below folders relative to AppRootDir
+	// (the most specific one wins), eg: {"web/dist": 1} watches web/dist and
+	// its children only; a negative limit lifts MaxDepth below the folder.
+	DepthLimits map[string]int
+	// WatchBudget caps the directories added to the watcher (0 = unlimited),
+	// eg: to stay under the inotify watch limit. When the tree has more, the
+	// subtrees holding files some handler supports are watched first and the
+	// other directories are polled every PollInterval (default 2s). See
+	// WatchAllocation.
+	WatchBudget  int
+	PollInterval time.Duration
+	// FS is walked by InitialRegistration instead of the OS filesystem,
+	// rooted at AppRootDir (eg: os.DirFS for a chroot, fstest.MapFS in tests).
+	// Found files reach handlers as AppRootDir/<name>; live events still come from the OS.
+	FS                 fs.FS
 	FilesEventHandlers []FilesEventHandlers // All file event handlers are managed here
 	FolderEvents       FolderEvent          // when directories are created/removed for architecture detection
+	// AutoDetect wires the default pipeline of DetectProject(AppRootDir)
+	// when FilesEventHandlers is empty at start (go build of each main,
+	// js/wasm mains, tailwind, npm run build)
+	AutoDetect bool
 
 	BrowserReload func() error // when change frontend files reload browser
+	// BrowserReloadPaths replaces BrowserReload when set: it receives the files
+	// (relative to AppRootDir) handled since the last reload, eg: LiveReload.ReloadPaths
+	BrowserReloadPaths func(paths []string) error
+	// ReloadTransports are notified of each reload too, eg: a WebSocketReload
+	// for an editor extension next to a LiveReload for the pages
+	ReloadTransports []ReloadTransport
+	// ReloadOnDelete reloads the browser when an observed non Go file or
+	// folder is removed or moved away (eg: to the trash), after its handlers
+	// succeeded or when no handler handles it. By default a removal only
+	// reloads when a handler processed it, and moved away files are ignored.
+	ReloadOnDelete bool
+	// OnSourceRemoved is called with the outputs of a removed source (see
+	// ArtifactHandler) to delete stale generated files eg: dist/app.js
+	OnSourceRemoved func(sourcePath string, outputs []string) error
+	PreReloadCheck  func() error  // runs right before each reload; an error skips it (eg: binary missing)
+	ReadyProbe      *ReadyProbe   // optional: wait for the restarted server to accept connections before reloading
+	Serve           *StaticServer // optional: serve the web assets with live reload (frontend-only projects)
+	AsyncTimeout    time.Duration // max wait for AsyncFileEventHandler work before reloading (default 30s)
+	// OpenBrowserURL opens the default browser at this URL (eg:
+	// "http://localhost:8080") once InitialRegistration built the project;
+	// after a failed initial build, at the first successful one
+	OpenBrowserURL string
+	OpenBrowser    func(url string) error // replaces the system browser eg: a specific browser command
+	// MaxReloadsPerSecond bounds browser reloads during event storms (0 = unlimited);
+	// extra requests collapse into one trailing reload, see Stats().Suppressed
+	MaxReloadsPerSecond float64
 
+	Profiles map[string]WatchProfile // named handler/path subsets switchable at runtime with SetProfile eg: "frontend"
+
+	// Hasher detects content changes for the smart debounce and shared work
+	// (default ContentHasher). ModTimeHasher skips reading huge files.
+	Hasher Hasher
+
+	// AssetFingerprints keeps a content hash per asset handled successfully,
+	// see Fingerprints. ManifestPath also writes it as JSON, relative to
+	// AppRootDir eg: "web/assets.json" (implies AssetFingerprints).
+	AssetFingerprints bool
+	ManifestPath      string
+
+	DiffMaxBytes           int  // include a diff in FileChange for files up to this size (0 disables)
+	SkipCommentOnlyChanges bool // skip handlers and reload when a .go write only touched comments/whitespace; //go: directives and the cgo preamble count as code
+	SkipGeneratedGo        bool // don't dispatch "Code generated ... DO NOT EDIT." files to Go handlers (avoids codegen loops), except to those claiming them by name or FileMatcher
+	WarnSensitiveFiles     bool // log once per observed sensitive file (see IsSensitiveFile) eg: "server.key"
+	// SensitivePatterns are extra base name patterns (path.Match syntax) of
+	// files holding secrets, eg: []string{"*.secret", "credentials.json"}.
+	// Their content is never read into diffs or snapshots, like the built-in
+	// ones of IsSensitiveFile.
+	SensitivePatterns []string
+	// VendorChanges watches the module vendor/ folder and dispatches edits of a
+	// vendored package to the Go handlers importing it. By default vendored
+	// files are never owned by Go handlers. Either way vendor/modules.txt is
+	// watched and "go mod vendor" resets the dependency cache.
+	VendorChanges bool
+	// WarmOwnership computes the ownership of every Go file for each Go
+	// handler in the background after startup and caches it, so saves that
+	// don't change imports skip the dependency analysis
+	WarmOwnership bool
+	// IncrementalDeps answers Go file ownership from an in-memory import
+	// graph of the module, updated on each save by parsing only the saved
+	// file's import block and rebuilt when go.mod changes. Test files and
+	// files excluded by build constraints still go through godepfind.
+	IncrementalDeps bool
+
+	OnIdle      func(idleFor time.Duration) // called once when no events arrived for IdleTimeout eg: run full test suite
+	IdleTimeout time.Duration               // quiet period before OnIdle fires (default 5s)
+
+	// SaveAllWindow groups file events arriving less than this apart (eg:
+	// 30ms, an IDE "save all") into one transaction: Go handlers build once,
+	// BatchHandlers get one call with all the changes, a single "transaction"
+	// record is published and the browser reloads once. Every event waits
+	// the window before dispatch (0 disables).
+	SaveAllWindow time.Duration
+	// InterruptStaleBuilds cancels the context of ContextFileChangeHandler
+	// calls still running when a newer save of the same file (or of another
+	// non-test Go file of its package) is queued, so the handler starts over
+	// on the newest code instead of finishing a stale build first. A handler
+	// the newer event doesn't reach (eg: a comment-only change) runs again.
+	InterruptStaleBuilds bool
+
+	// MaxConcurrentEvents is the number of file events handled in parallel
+	// (default 1: one at a time, in order). Events wait in a bounded queue
+	// and are coalesced per path; see eventQueue for the overflow policy.
+	MaxConcurrentEvents int
+
+	// Format rewrites saved .go files before handlers run eg: &devwatch.Formatter{}
+	Format *Formatter
+
+	Tracer Tracer // optional spans per event, handler and reload (eg: OpenTelemetry adapter)
+	// ProfileLabels tags handler calls with the pprof labels "devwatch.handler"
+	// and "devwatch.path" so CPU profiles can be filtered per handler
+	ProfileLabels bool
+	// SlowHandlerThreshold logs a warning with duration and path for handler
+	// calls taking longer (0 disables), see also SlowestHandlers
+	SlowHandlerThreshold time.Duration
+	// BuildGrowthWarning logs when a build artifact grows by more than this
+	// fraction over its previous build eg: 0.1 for 10% (0 disables), see
+	// Stats().Builds
+	BuildGrowthWarning float64
+
+	// MaxEventChain drops events once handlers writing files triggered this
+	// many events in a row (a loop), reported through OnError (default 10)
+	MaxEventChain int
+	// OverloadEventsPerSecond is the raw event rate that, sustained for 3
+	// seconds, logs and invokes OnOverload with a snapshot of the busiest
+	// paths and handlers (0 disables)
+	OverloadEventsPerSecond float64
+	OnOverload              func(OverloadReport)
+	// LoopLimit and LoopWindow trip a circuit breaker when the same unchanged
+	// file reaches the same handler more than LoopLimit times within
+	// LoopWindow (defaults 10 and 5s): the pair is paused until the file
+	// content changes and the loop is reported through OnError. A negative
+	// LoopLimit disables the breaker.
+	LoopLimit  int
+	LoopWindow time.Duration
+
+	// JournalPath appends every event record (see Subscribe) as a JSON line
+	// to this file, relative to AppRootDir eg: ".devwatch/events.log", for
+	// post-mortem analysis with ReadJournal. It is rotated past
+	// JournalMaxBytes (default 10MB) keeping JournalKeep old files (default 3).
+	JournalPath     string
+	JournalMaxBytes int64
+	JournalKeep     int
+
+	// AuditFor previews the watcher on a new repo: for this long after
+	// FileWatcherStart, handler calls and browser reloads are recorded
+	// (Subscribe, JournalPath) instead of made. Then OnAudit receives the
+	// AuditReport (logged when nil) and the watcher acts normally.
+	AuditFor time.Duration
+	OnAudit  func(AuditReport)
+
+	OnError func(err error) // called with errors that stopped a reload eg: failed artifact verification, ExecError with Diagnostics
+	// OnNotify receives one Notification per failing handler, updated in
+	// place at most once per NotifyInterval (default 5s) while it keeps
+	// failing and resolved by its next success, see Notifications
+	OnNotify       func(Notification)
+	NotifyInterval time.Duration
+
+	Debug           bool                 // log debug diagnostics eg: "no handler owns this file"
 	Logger          func(message ...any) // For logging output
+	Messages        MessageProvider      // overrides the log messages eg: devwatch.Messages{"listening": "Escuchando cambios ..."}
+	LogFormat       string               // "json": Logger receives one NDJSON LogLine per message, handler run and event
 	ExitChan        chan bool            // global channel to signal the exit
+	SingleInstance  bool                 // refuse to start when another devwatch watches AppRootDir, see LockInstance
 	UnobservedFiles func() []string      // files that are not observed by the watcher eg: ".git", ".gitignore", ".vscode",  "examples",
 }
 
@@ -41,9 +222,93 @@ type DevWatch struct {
 	depFinder       *godepfind.GoDepFind // Dependency finder for Go projects
 	no_add_to_watch map[string]bool
 	noAddMu         sync.RWMutex
+	rules           ignoreRules  // no_add_to_watch compiled for Contain
+	containCache    containCache // Contain decisions per path
 	// reload timer to debounce browser reloads across multiple events
 	reloadTimer *time.Timer
 	reloadMutex sync.Mutex
+	limiter     reloadLimiter // MaxReloadsPerSecond, guarded by reloadMutex
+	// in-flight AsyncFileEventHandler work the reload waits for
+	async asyncTracker
+	// periodic tasks registered with Every
+	sched scheduler
+	// number of handler dispatches in progress and time of the last one (unix nano)
+	dispatching atomic.Int32
+	lastEventAt atomic.Int64
+	// last known content per file used to build FileChange diffs
+	contents  map[string]string
+	contentMu sync.Mutex
+	// token signatures of Go files used to detect comment-only changes
+	goSignatures map[string][32]byte
+	sigMu        sync.Mutex
+	// active watch profile name ("" = full)
+	activeProfile string
+	profileMu     sync.RWMutex
+	// files handled since the last reload, see BrowserReloadPaths
+	changed changedPaths
+	// asset fingerprints exposed by Fingerprints and written to ManifestPath
+	manifest assetManifest
+	// per-save latency samples exposed by Stats
+	stats reloadStats
+	// artifact sizes per successful build, exposed by Stats and WriteBuildCSV
+	builds buildStats
+	// observers registered with Subscribe
+	subs subscribers
+	// failing handlers reported to OnNotify
+	notes notifier
+	// would-be actions recorded during AuditFor
+	audit auditState
+	// handler calls InterruptStaleBuilds can cancel
+	interrupts interrupts
+	// event bursts of DebouncedHandlers
+	debounces debouncer
+	// events waiting for SaveAllWindow to group them
+	saves pendingSaves
+	// OpenBrowserURL state
+	opener browserOpener
+	// handler main input files that don't exist yet
+	missingMains map[string]bool
+	mainMu       sync.Mutex
+	// Restart state: watcherMu guards watcher swaps and the current loop run
+	watcherMu sync.Mutex
+	registry  watchRegistry   // directories added to the watcher
+	moves     dirMoves        // watched directories moved away, paired with their new path
+	budget    watchBudget     // directories polled beyond WatchBudget
+	census    extensionCensus // files per extension found by InitialRegistration
+	activity  dirActivity     // last event per directory, rescanned on kernel overflow
+	rescanMu  sync.Mutex      // one overflow rescan at a time
+	internal  internalPaths   // paths written by devwatch subsystems, see RegisterInternalPath
+	echoes    selfWrites      // file versions rewritten by Format, their events are dropped
+	sensitive sensitiveWarnings
+	calls     invocations      // recent handler calls, see SlowestHandlers
+	chains    eventChains      // handler runs, to link events to the event that caused them
+	loops     loopBreaker      // handler/path pairs paused by LoopLimit
+	rate      rateGuard        // raw event rate, see OverloadEventsPerSecond
+	lockPath  string           // lock file held by LockInstance
+	ownership ownershipCache   // Go file ownership per main input, see WarmOwnership
+	imports   importGraph      // module import graph, see IncrementalDeps
+	cache     *Cache           // shared handler cache, see Cache
+	artifacts artifactRegistry // outputs produced per source, see Artifacts
+	cacheOnce sync.Once
+	eventLog  eventJournal
+	deps      depCache    // package dirs per main input (vendored and workspace dependencies)
+	workspace goWorkspace // go.work modules
+	wasm      wasmCoordinator
+	restartMu sync.Mutex
+	run       *watchRun
+	// focus mode pauses reloads; focusMissed records a reload held back meanwhile
+	focus       atomic.Bool
+	focusMissed atomic.Bool
+	// graceful Shutdown state: closing stops accepting events, stop ends the watch loop
+	closing atomic.Bool
+	// reloadsClosed (guarded by reloadMutex) stops handlers finishing after Shutdown from scheduling reloads
+	reloadsClosed bool
+	stop          chan struct{}
+	stopMu        sync.Mutex
+	stopOnce      sync.Once
+	// queued file events and the dispatch slots shared by workers and Trigger
+	queue    eventQueue
+	dispatch dispatchSlots
 	// logMu           sync.Mutex // No longer needed with Print func
 }
 
@@ -0,0 +1,114 @@
+package devwatch
+
+import (
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dirMoveWindow is how long a watched directory moved away waits for the
+// create event of its new path (both come from the same kernel move)
+const dirMoveWindow = 200 * time.Millisecond
+
+// FolderRenameEvent is an optional capability for FolderEvent handlers that
+// want both paths when a watched directory is renamed or moved within the
+// tree. Without it, NewFolderEvent receives the new path with event "rename".
+type FolderRenameEvent interface {
+	NewFolderRename(oldPath, newPath string) error
+}
+
+// dirMove is a watched directory that was moved away
+type dirMove struct {
+	from     string
+	children []string // subdirectories relative to from, slash separated
+	at       time.Time
+}
+
+// dirMoves holds the directories moved away until their new path shows up
+type dirMoves struct {
+	mu      sync.Mutex
+	pending []dirMove
+}
+
+// directoryMovedAway drops the watches of a renamed directory and its
+// children (the kernel keeps them under their old names) and remembers the
+// move, so the create event of the new path can be paired with it
+func (h *DevWatch) directoryMovedAway(path string, released []string) {
+	from := canonicalPath(path)
+	move := dirMove{from: path, at: time.Now()}
+	watcher := h.currentWatcher()
+	for _, dir := range released {
+		watcher.Remove(filepath.FromSlash(dir))
+		if dir == from {
+			continue
+		}
+		move.children = append(move.children, strings.TrimPrefix(dir, from+"/"))
+	}
+	slices.Sort(move.children)
+
+	h.moves.mu.Lock()
+	defer h.moves.mu.Unlock()
+	h.moves.pending = slices.DeleteFunc(h.moves.pending, func(m dirMove) bool {
+		return time.Since(m.at) > dirMoveWindow
+	})
+	h.moves.pending = append(h.moves.pending, move)
+}
+
+// directoryMovedHere watches a directory created by a move of a watched
+// directory, with its subdirectories, and notifies FolderEvents of the
+// rename. It reports false when path is not the new path of a recent move.
+func (h *DevWatch) directoryMovedHere(path string) bool {
+	if h.Contain(path) {
+		return false
+	}
+	var children []string
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || p == path {
+			return nil
+		}
+		if h.Contain(p) || h.beyondDepth(p) {
+			return filepath.SkipDir
+		}
+		if rel, err := filepath.Rel(path, p); err == nil {
+			children = append(children, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	slices.Sort(children)
+
+	h.moves.mu.Lock()
+	i := slices.IndexFunc(h.moves.pending, func(m dirMove) bool {
+		return time.Since(m.at) <= dirMoveWindow && slices.Equal(m.children, children)
+	})
+	if i < 0 {
+		h.moves.mu.Unlock()
+		return false
+	}
+	from := h.moves.pending[i].from
+	h.moves.pending = slices.Delete(h.moves.pending, i, i+1)
+	h.moves.mu.Unlock()
+
+	if _, err := h.watchDirectory(path); err != nil {
+		return true
+	}
+	for _, child := range children {
+		h.watchDirectory(filepath.Join(path, filepath.FromSlash(child)))
+	}
+	h.say("dir-moved", from, path)
+
+	if h.FolderEvents != nil {
+		var err error
+		if fr, ok := h.FolderEvents.(FolderRenameEvent); ok {
+			err = fr.NewFolderRename(from, path)
+		} else if name, nerr := GetFileName(path); nerr == nil {
+			err = h.FolderEvents.NewFolderEvent(name, path, "rename")
+		}
+		if err != nil {
+			h.say("folder-event-error", err)
+		}
+	}
+	return true
+}
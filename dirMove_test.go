@@ -0,0 +1,95 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// folderRenames records the folder renames and the css paths it receives
+type folderRenames struct {
+	mu      sync.Mutex
+	renames [][2]string
+	creates []string
+	paths   chan string
+}
+
+func (f *folderRenames) NewFolderEvent(folderName, path, event string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if event == "create" {
+		f.creates = append(f.creates, path)
+	}
+	return nil
+}
+func (f *folderRenames) NewFolderRename(oldPath, newPath string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.renames = append(f.renames, [2]string{oldPath, newPath})
+	return nil
+}
+func (f *folderRenames) NewFileEvent(fileName, extension, filePath, event string) error {
+	f.paths <- filePath
+	return nil
+}
+func (f *folderRenames) SupportedExtensions() []string { return []string{".css"} }
+
+func TestDirectoryMove_KeepsWatches(t *testing.T) {
+	root := t.TempDir()
+	oldDir := filepath.Join(root, "theme")
+	if err := os.MkdirAll(filepath.Join(oldDir, "parts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &folderRenames{paths: make(chan string, 10)}
+	exit := make(chan bool)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{rec},
+		FolderEvents:       rec,
+		Logger:             func(message ...any) {},
+		ExitChan:           exit,
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	defer func() {
+		close(exit)
+		wg.Wait()
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	rec.mu.Lock()
+	rec.creates = nil // initial registration
+	rec.mu.Unlock()
+
+	newDir := filepath.Join(root, "skin")
+	if err := os.Rename(oldDir, newDir); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	css := filepath.Join(newDir, "parts", "app.css")
+	if err := os.WriteFile(css, []byte("a{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-rec.paths:
+		if got != css {
+			t.Errorf("event path = %q, want %q", got, css)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected an event for a file in the moved directory")
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.renames) != 1 || rec.renames[0] != [2]string{oldDir, newDir} {
+		t.Errorf("renames = %v, want [%s %s]", rec.renames, oldDir, newDir)
+	}
+	if len(rec.creates) != 0 {
+		t.Errorf("a move must not report created folders, got %v", rec.creates)
+	}
+}
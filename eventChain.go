@@ -0,0 +1,96 @@
+package devwatch
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMaxEventChain is used when WatchConfig.MaxEventChain is not set
+const defaultMaxEventChain = 10
+
+// mtimeSlack tolerates file systems stamping modification times with a
+// coarse clock (a kernel tick behind time.Now)
+const mtimeSlack = 10 * time.Millisecond
+
+// maxCauseWindows bounds the handler runs kept to find event parents
+const maxCauseWindows = 64
+
+// causeWindow is the time span an event's handlers ran in. A file modified
+// within it was most likely written by those handlers (eg: codegen output).
+type causeWindow struct {
+	id    uint64
+	chain []string // paths from the root event to this one
+	start time.Time
+	end   time.Time // zero while running
+}
+
+// eventChains links events to the event whose handlers wrote their file
+type eventChains struct {
+	mu      sync.Mutex
+	windows []*causeWindow
+}
+
+// parentOf returns the most recent handler run of another file that was
+// active when path was modified. Saves of the file being handled are
+// treated as user edits, not causality.
+func (c *eventChains) parentOf(path string, modified time.Time) *causeWindow {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := len(c.windows) - 1; i >= 0; i-- {
+		w := c.windows[i]
+		if w.chain[len(w.chain)-1] == path {
+			continue
+		}
+		if !modified.Before(w.start.Add(-mtimeSlack)) && (w.end.IsZero() || !modified.After(w.end)) {
+			return w
+		}
+	}
+	return nil
+}
+
+// begin opens the window of an event; call the returned func once its handlers returned
+func (c *eventChains) begin(id uint64, path string, parent *causeWindow) func() {
+	w := &causeWindow{id: id, chain: []string{path}, start: time.Now()}
+	if parent != nil {
+		w.chain = append(append([]string(nil), parent.chain...), path)
+	}
+	c.mu.Lock()
+	if len(c.windows) == maxCauseWindows {
+		c.windows = c.windows[1:]
+	}
+	c.windows = append(c.windows, w)
+	c.mu.Unlock()
+	return func() {
+		c.mu.Lock()
+		w.end = time.Now()
+		c.mu.Unlock()
+	}
+}
+
+// eventParent finds the event whose handlers wrote filePath. It returns an
+// error when the chain grew longer than MaxEventChain: handlers keep
+// triggering each other.
+func (h *DevWatch) eventParent(filePath string, isDelete bool) (*causeWindow, error) {
+	if isDelete {
+		return nil, nil
+	}
+	info, err := statRetry(filePath)
+	if err != nil {
+		return nil, nil
+	}
+	parent := h.chains.parentOf(h.relativePath(filePath), info.ModTime())
+	if parent == nil {
+		return nil, nil
+	}
+	limit := h.MaxEventChain
+	if limit <= 0 {
+		limit = defaultMaxEventChain
+	}
+	if len(parent.chain) >= limit {
+		return parent, errors.New("event loop: chain longer than " + strconv.Itoa(limit) + ": " + strings.Join(append(parent.chain, h.relativePath(filePath)), " -> "))
+	}
+	return parent, nil
+}
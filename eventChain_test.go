@@ -0,0 +1,62 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEventChain(t *testing.T) {
+	root := t.TempDir()
+	protoFile := filepath.Join(root, "api.proto")
+	if err := os.WriteFile(protoFile, []byte("syntax = \"proto3\";"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var loopErr error
+	w := New(&WatchConfig{
+		AppRootDir:    root,
+		MaxEventChain: 3,
+		OnError:       func(err error) { loopErr = err },
+		Logger:        func(message ...any) { t.Log(message...) },
+	})
+	// each .step file writes the next one: 1.step -> 2.step -> ...
+	_, err := w.Handle(".proto", ".step").Name("chain").OnEvent(func(fileName, extension, filePath, event string) error {
+		n := 0
+		if extension == ".step" {
+			n = int(fileName[0] - '0')
+		}
+		return os.WriteFile(filepath.Join(root, string(rune('1'+n))+".step"), nil, 0644)
+	}).Register()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, cancel := w.Subscribe()
+	defer cancel()
+	for _, file := range []string{"api.proto", "1.step", "2.step", "3.step"} {
+		if err := w.Trigger(file, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []EventRecord
+	for range 4 {
+		got = append(got, <-records)
+	}
+	if got[0].Parent != 0 || got[0].Depth != 0 {
+		t.Errorf("the user save has no parent, got %+v", got[0])
+	}
+	for i := 1; i < 3; i++ {
+		if got[i].Parent != got[i-1].ID || got[i].Depth != i {
+			t.Errorf("event %d: expected parent %d at depth %d, got %+v", i, got[i-1].ID, i, got[i])
+		}
+	}
+	if got[3].Skipped != "loop" {
+		t.Errorf("expected the chain to be broken at MaxEventChain, got %+v", got[3])
+	}
+	if loopErr == nil || !strings.Contains(loopErr.Error(), "api.proto -> 1.step -> 2.step -> 3.step") {
+		t.Errorf("expected loop error with the chain, got %v", loopErr)
+	}
+}
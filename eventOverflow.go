@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// overflowWindow is how far back directories count as recently active
+// when the kernel reports dropped events
+const overflowWindow = 30 * time.Second
+
+// handleOverflow responds to a kernel event queue overflow: events were lost,
+// so the recently active directories are rescanned for files modified
+// during the window. The watch loop runs it in its own goroutine so the walk
+// doesn't hold up new events; overflows are handled one at a time.
+func (h *DevWatch) handleOverflow() {
+	h.rescanMu.Lock()
+	defer h.rescanMu.Unlock()
+	since := time.Now().Add(-overflowWindow)
+	dirs := h.activity.since(since)
+	h.say("overflow", len(dirs))
+	h.rescan(since, dirs)
+}
+
+// rescan registers new subdirectories of dirs and queues a write event for
+// each observed file modified after since
+func (h *DevWatch) rescan(since time.Time, dirs []string) {
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if h.Contain(path) {
+				if info.IsDir() {
+					return filepath.SkipDir // eg: node_modules
+				}
+				return nil
+			}
+			if info.IsDir() {
+				if h.beyondDepth(path) {
+					return filepath.SkipDir
+				}
+				h.addDirectoryToWatcher(path)
+				return nil
+			}
+			if info.ModTime().After(since) {
+				h.enqueueFileEvent(info.Name(), path, "write", false)
+			}
+			return nil
+		})
+		if err != nil {
+			h.say("rescan-error", dir, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package devwatch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestHandleOverflow(t *testing.T) {
+	tempDir := t.TempDir()
+	webDir := filepath.Join(tempDir, "web")
+	if err := os.MkdirAll(filepath.Join(webDir, "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(webDir, "node_modules", "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	oldFile := filepath.Join(tempDir, "old.css")
+	newFile := filepath.Join(webDir, "css", "style.css")
+	ignoredFile := filepath.Join(webDir, "node_modules", "lib", "lib.css")
+	for _, f := range []string{oldFile, newFile, ignoredFile} {
+		if err := os.WriteFile(f, []byte("body {}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(oldFile, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		Logger:     func(message ...any) { logs = append(logs, fmt.Sprint(message...)) },
+	})
+	w.watcher = watcher
+
+	// only web saw activity recently; the root did long ago
+	w.activity.record(webDir, time.Now())
+	w.activity.record(tempDir, past)
+
+	w.handleOverflow()
+
+	ev, ok := w.queue.pop()
+	if !ok || ev.path != newFile || ev.eventType != "write" {
+		t.Fatalf("expected rescan to queue %s, got %+v", newFile, ev)
+	}
+	if _, ok := w.queue.pop(); ok {
+		t.Error("expected files outside recently active directories or not modified to be skipped")
+	}
+	if w.registry.claim(filepath.Join(webDir, "css")) {
+		t.Error("expected rescan to register new subdirectories")
+	}
+	w.containCache.mu.Lock()
+	_, walked := w.containCache.decisions[ignoredFile]
+	w.containCache.mu.Unlock()
+	if walked {
+		t.Error("expected rescan to skip ignored directories")
+	}
+	if len(logs) == 0 || !strings.Contains(logs[0], "overflow") {
+		t.Errorf("expected an overflow warning, got %v", logs)
+	}
+}
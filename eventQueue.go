@@ -0,0 +1,202 @@
+package devwatch
+
+import (
+	"errors"
+	"sync"
+)
+
+// eventQueueSize bounds the file events waiting for a free worker
+const eventQueueSize = 256
+
+// queuedEvent is a file event read from fsnotify waiting to be handled
+type queuedEvent struct {
+	fileName  string
+	path      string
+	eventType string
+	isDelete  bool
+}
+
+// eventQueue decouples reading fsnotify events from running handlers so a
+// long build never blocks the watcher channel (the kernel drops events when
+// its queue overflows).
+//
+// Policy:
+//   - an event for a path already waiting replaces it (coalesce); a write
+//     after a create stays a create
+//   - a path is handled by one worker at a time, events keep their order per path
+//   - when eventQueueSize paths are waiting, events for new paths are dropped,
+//     logged and reported through OnError
+//   - on ExitChan the waiting events are dropped
+type eventQueue struct {
+	mu       sync.Mutex
+	order    []string // waiting paths, oldest first
+	pending  map[string]queuedEvent
+	inFlight map[string]bool
+	ready    chan struct{} // signals workers that an event may be available
+}
+
+func (q *eventQueue) init() {
+	if q.pending == nil {
+		q.pending = make(map[string]queuedEvent)
+		q.inFlight = make(map[string]bool)
+		q.ready = make(chan struct{}, eventQueueSize)
+	}
+}
+
+// clear drops the waiting events; events in flight are not affected
+func (q *eventQueue) clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, path := range q.order {
+		delete(q.pending, path)
+	}
+	q.order = nil
+}
+
+// readyChan returns the channel workers wait on
+func (q *eventQueue) readyChan() chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.init()
+	return q.ready
+}
+
+// push adds ev to the queue. Returns false when the event was dropped.
+func (q *eventQueue) push(ev queuedEvent) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.init()
+
+	if prev, waiting := q.pending[ev.path]; waiting {
+		if prev.eventType == "create" && ev.eventType == "write" {
+			ev.eventType = "create"
+		}
+		q.pending[ev.path] = ev
+		return true
+	}
+	if len(q.order) >= eventQueueSize {
+		return false
+	}
+	q.pending[ev.path] = ev
+	q.order = append(q.order, ev.path)
+	q.signal()
+	return true
+}
+
+// pop returns the oldest event whose path is not being handled and marks
+// its path in flight
+func (q *eventQueue) pop() (queuedEvent, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, path := range q.order {
+		if q.inFlight[path] {
+			continue
+		}
+		ev := q.pending[path]
+		delete(q.pending, path)
+		q.order = append(q.order[:i], q.order[i+1:]...)
+		q.inFlight[path] = true
+		return ev, true
+	}
+	return queuedEvent{}, false
+}
+
+// done releases path after its event was handled
+func (q *eventQueue) done(path string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.inFlight, path)
+	if _, waiting := q.pending[path]; waiting {
+		q.signal()
+	}
+}
+
+// len returns the number of events waiting or being handled
+func (q *eventQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.order) + len(q.inFlight)
+}
+
+// signal wakes a worker; callers must hold q.mu
+func (q *eventQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default: // workers already have pending wake ups
+	}
+}
+
+// enqueueFileEvent queues a file event for the workers
+func (h *DevWatch) enqueueFileEvent(fileName, path, eventType string, isDelete bool) {
+	if !h.queue.push(queuedEvent{fileName: fileName, path: path, eventType: eventType, isDelete: isDelete}) {
+		h.say("queue-full", eventType, path)
+		if h.OnError != nil {
+			h.OnError(errors.New("event queue full, dropped: " + eventType + " " + h.relativePath(path)))
+		}
+		return
+	}
+	h.interruptStale(path)
+}
+
+// startWorkers runs MaxConcurrentEvents workers handling queued events until
+// stop is closed. Events still queued when stop closes are handled, call
+// queue.clear first to drop them.
+func (h *DevWatch) startWorkers(stop <-chan struct{}) *sync.WaitGroup {
+	ready := h.queue.readyChan()
+	var wg sync.WaitGroup
+	for range h.maxConcurrentEvents() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					h.handleQueued()
+					return
+				case <-ready:
+				}
+				h.handleQueued()
+			}
+		}()
+	}
+	return &wg
+}
+
+// handleQueued handles queued events until none is available
+func (h *DevWatch) handleQueued() {
+	for {
+		ev, ok := h.queue.pop()
+		if !ok {
+			return
+		}
+		h.handleFileEvent(ev.fileName, ev.path, ev.eventType, ev.isDelete)
+		h.queue.done(ev.path)
+	}
+}
+
+// maxConcurrentEvents returns the number of event workers (default 1)
+func (h *DevWatch) maxConcurrentEvents() int {
+	if h.MaxConcurrentEvents > 0 {
+		return h.MaxConcurrentEvents
+	}
+	return 1
+}
+
+// dispatchSlots limits concurrent handleFileEvent calls (workers and Trigger)
+type dispatchSlots struct {
+	once  sync.Once
+	slots chan struct{}
+}
+
+// acquireDispatch waits for a free dispatch slot; call releaseDispatch when done
+func (h *DevWatch) acquireDispatch() {
+	h.dispatch.once.Do(func() {
+		h.dispatch.slots = make(chan struct{}, h.maxConcurrentEvents())
+	})
+	h.dispatch.slots <- struct{}{}
+}
+
+// releaseDispatch frees the slot taken by acquireDispatch
+func (h *DevWatch) releaseDispatch() {
+	<-h.dispatch.slots
+}
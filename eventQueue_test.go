@@ -0,0 +1,81 @@
+package devwatch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEventQueue(t *testing.T) {
+	var q eventQueue
+
+	// create then write on the same path coalesces into one create
+	q.push(queuedEvent{path: "a.css", eventType: "create"})
+	q.push(queuedEvent{path: "a.css", eventType: "write"})
+	q.push(queuedEvent{path: "b.css", eventType: "write"})
+	if n := q.len(); n != 2 {
+		t.Fatalf("expected 2 queued events, got %d", n)
+	}
+
+	ev, ok := q.pop()
+	if !ok || ev.path != "a.css" || ev.eventType != "create" {
+		t.Fatalf("expected coalesced create for a.css, got %+v", ev)
+	}
+
+	// a.css is in flight: a new event for it waits behind b.css
+	q.push(queuedEvent{path: "a.css", eventType: "write"})
+	ev, _ = q.pop()
+	if ev.path != "b.css" {
+		t.Fatalf("expected b.css while a.css is in flight, got %+v", ev)
+	}
+	if _, ok := q.pop(); ok {
+		t.Fatal("expected no event available while a.css is in flight")
+	}
+	q.done("a.css")
+	if ev, ok := q.pop(); !ok || ev.path != "a.css" {
+		t.Fatalf("expected a.css after it was released, got %+v", ev)
+	}
+}
+
+func TestEventQueue_Full(t *testing.T) {
+	var q eventQueue
+	for i := range eventQueueSize {
+		if !q.push(queuedEvent{path: fmt.Sprintf("f%d.css", i), eventType: "write"}) {
+			t.Fatalf("unexpected drop at %d", i)
+		}
+	}
+	if q.push(queuedEvent{path: "new.css", eventType: "write"}) {
+		t.Error("expected event for a new path to be dropped when the queue is full")
+	}
+	if !q.push(queuedEvent{path: "f0.css", eventType: "remove"}) {
+		t.Error("expected event for a waiting path to be coalesced when the queue is full")
+	}
+}
+
+func TestEventQueue_FullReported(t *testing.T) {
+	var reported []error
+	w := New(&WatchConfig{
+		AppRootDir: t.TempDir(),
+		OnError:    func(err error) { reported = append(reported, err) },
+		Logger:     func(message ...any) {},
+	})
+	for i := range eventQueueSize + 1 {
+		w.enqueueFileEvent("f.css", fmt.Sprintf("f%d.css", i), "write", false)
+	}
+	if len(reported) != 1 {
+		t.Fatalf("expected the dropped event reported through OnError, got %v", reported)
+	}
+}
+
+func TestEventQueue_Clear(t *testing.T) {
+	var q eventQueue
+	q.push(queuedEvent{path: "a.css", eventType: "write"})
+	q.push(queuedEvent{path: "b.css", eventType: "write"})
+	q.pop()
+	q.clear()
+	if n := q.len(); n != 1 {
+		t.Fatalf("expected only the event in flight left, got %d", n)
+	}
+	if _, ok := q.pop(); ok {
+		t.Error("expected the waiting events dropped")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package devwatch
+
+// isFileLocked reports whether err comes from a file held open by another
+// process. Unix editors and compilers don't take mandatory locks, so
+// errors are never considered transient here.
+func isFileLocked(err error) bool {
+	return false
+}
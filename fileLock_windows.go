@@ -0,0 +1,23 @@
+//go:build windows
+
+package devwatch
+
+import (
+	"errors"
+	"syscall"
+)
+
+// Windows error codes returned while another process holds the file
+const (
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+)
+
+// isFileLocked reports whether err comes from a file held open by another process
+func isFileLocked(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+	return errno == errorSharingViolation || errno == errorLockViolation || errno == syscall.ERROR_ACCESS_DENIED
+}
@@ -0,0 +1,59 @@
+package devwatch
+
+import (
+	"os"
+	"time"
+)
+
+// fileRetryAttempts and fileRetryBackoff control how long a locked file
+// (editor or compiler still writing it, common on Windows) is retried:
+// 10ms, 20ms, 40ms before giving up.
+const (
+	fileRetryAttempts = 4
+	fileRetryBackoff  = 10 * time.Millisecond
+)
+
+// transientFileError reports whether err may clear by itself shortly
+// (eg: sharing violation). Replaced in tests.
+var transientFileError = isFileLocked
+
+// retryFile runs op until it succeeds, fails with a permanent error or
+// the attempts are exhausted
+func retryFile(op func() error) error {
+	wait := fileRetryBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = op(); err == nil || attempt == fileRetryAttempts || !transientFileError(err) {
+			return err
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// statRetry is os.Stat retrying while the file is locked
+func statRetry(path string) (info os.FileInfo, err error) {
+	err = retryFile(func() error {
+		info, err = os.Stat(path)
+		return err
+	})
+	return info, err
+}
+
+// openRetry is os.Open retrying while the file is locked
+func openRetry(path string) (f *os.File, err error) {
+	err = retryFile(func() error {
+		f, err = os.Open(path)
+		return err
+	})
+	return f, err
+}
+
+// readFileRetry is os.ReadFile retrying while the file is locked
+func readFileRetry(path string) (data []byte, err error) {
+	err = retryFile(func() error {
+		data, err = os.ReadFile(path)
+		return err
+	})
+	return data, err
+}
@@ -0,0 +1,38 @@
+package devwatch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRetryFile(t *testing.T) {
+	errLocked := errors.New("locked")
+	errGone := errors.New("gone")
+	transientFileError = func(err error) bool { return errors.Is(err, errLocked) }
+	defer func() { transientFileError = isFileLocked }()
+
+	// lock released on the third attempt
+	calls := 0
+	err := retryFile(func() error {
+		calls++
+		if calls < 3 {
+			return errLocked
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("expected success after 3 attempts, got err=%v calls=%d", err, calls)
+	}
+
+	// permanent errors are not retried
+	calls = 0
+	if err := retryFile(func() error { calls++; return errGone }); err != errGone || calls != 1 {
+		t.Fatalf("expected single attempt for permanent error, got err=%v calls=%d", err, calls)
+	}
+
+	// lock never released: give up after fileRetryAttempts
+	calls = 0
+	if err := retryFile(func() error { calls++; return errLocked }); err != errLocked || calls != fileRetryAttempts {
+		t.Fatalf("expected %d attempts, got err=%v calls=%d", fileRetryAttempts, err, calls)
+	}
+}
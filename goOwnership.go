@@ -0,0 +1,67 @@
+package devwatch
+
+// MultiMainHandler is an optional capability for Go handlers that build
+// several entrypoints (eg: one per lambda function). A file is owned by the
+// handler when any of the main inputs depends on it. When implemented it
+// takes precedence over MainInputHandler.
+type MultiMainHandler interface {
+	MainInputFileRelativePaths() []string // eg: ["lambdas/users/main.go", "lambdas/orders/main.go"]
+}
+
+// AllGoFilesHandler is an optional capability for Go handlers that want every
+// .go event regardless of dependency analysis (eg: a formatter or linter).
+// When OwnsAllGoFiles returns true, ownership checks are skipped entirely and
+// no main input file is required.
+type AllGoFilesHandler interface {
+	OwnsAllGoFiles() bool
+}
+
+// ownsAllGoFiles reports whether handler opted out of dependency analysis
+func ownsAllGoFiles(handler FilesEventHandlers) bool {
+	ah, ok := handler.(AllGoFilesHandler)
+	return ok && ah.OwnsAllGoFiles()
+}
+
+// handlerMains returns the main input files declared by handler
+func handlerMains(handler FilesEventHandlers) []string {
+	if mh, ok := handler.(MultiMainHandler); ok {
+		return mh.MainInputFileRelativePaths()
+	}
+	if main := handlerMain(handler); main != "" {
+		return []string{main}
+	}
+	return nil
+}
+
+// goFileIsMine decides whether a Go handler owns filePath using dependency
+// analysis against each of the handler main input files.
+func (h *DevWatch) goFileIsMine(handler FilesEventHandlers, filePath, event string) (bool, error) {
+	vendored := isVendored(h.relativePath(filePath))
+	if vendored && !h.VendorChanges {
+		return false, nil
+	}
+	if ownsAllGoFiles(handler) {
+		return true, nil
+	}
+	if vendored {
+		return h.depsOwn(handler, filePath)
+	}
+	if h.workspace.active(h) {
+		return h.workspaceFileIsMine(handler, filePath, event)
+	}
+	var lastErr error
+	for _, main := range handlerMains(handler) {
+		if !h.mainInputReady(main) {
+			continue
+		}
+		isMine, err := h.mainOwnsFile(main, filePath, event)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if isMine {
+			return true, nil
+		}
+	}
+	return false, lastErr
+}
@@ -0,0 +1,114 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// multiMainHandler builds several lambda entrypoints
+type multiMainHandler struct {
+	FakeFilesEventHandler
+	mains []string
+	files []string
+}
+
+func (m *multiMainHandler) MainInputFileRelativePaths() []string { return m.mains }
+
+func (m *multiMainHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	m.files = append(m.files, fileName)
+	return nil
+}
+
+func TestMultiMainHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                 "module testapp\n\ngo 1.21\n",
+		"lambdas/users/main.go":  "package main\n\nimport \"testapp/users\"\n\nfunc main() { users.Run() }\n",
+		"lambdas/orders/main.go": "package main\n\nimport \"testapp/orders\"\n\nfunc main() { orders.Run() }\n",
+		"users/users.go":         "package users\n\nfunc Run() {}\n",
+		"orders/orders.go":       "package orders\n\nfunc Run() {}\n",
+		"billing/billing.go":     "package billing\n\nfunc Run() {}\n",
+		"cmd/billing/main.go":    "package main\n\nimport \"testapp/billing\"\n\nfunc main() { billing.Run() }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := &multiMainHandler{
+		FakeFilesEventHandler: FakeFilesEventHandler{SupportedExtensions_: []string{".go"}},
+		mains:                 []string{"lambdas/users/main.go", "lambdas/orders/main.go"},
+	}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	for _, f := range []string{"users/users.go", "orders/orders.go", "billing/billing.go"} {
+		if err := w.Trigger(f, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(handler.files) != 2 || handler.files[0] != "users.go" || handler.files[1] != "orders.go" {
+		t.Errorf("handler received %v, want [users.go orders.go]", handler.files)
+	}
+	if errs := w.Validate(); len(errs) != 0 {
+		t.Errorf("unexpected validation errors: %v", errs)
+	}
+}
+
+// formatterHandler wants every Go file
+type formatterHandler struct {
+	FakeFilesEventHandler
+	files []string
+}
+
+func (f *formatterHandler) OwnsAllGoFiles() bool              { return true }
+func (f *formatterHandler) MainInputFileRelativePath() string { return "" }
+
+func (f *formatterHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	f.files = append(f.files, fileName)
+	return nil
+}
+
+func TestAllGoFilesHandler(t *testing.T) {
+	tempDir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":        "module testapp\n\ngo 1.21\n",
+		"tools/tool.go": "package tools\n",
+		"main.go":       "package main\n\nfunc main() {}\n",
+	} {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	formatter := &formatterHandler{FakeFilesEventHandler: FakeFilesEventHandler{SupportedExtensions_: []string{".go"}}}
+	w := New(&WatchConfig{
+		AppRootDir:         tempDir,
+		FilesEventHandlers: []FilesEventHandlers{formatter},
+		Logger:             func(message ...any) { t.Log(message...) },
+	})
+
+	w.Trigger("tools/tool.go", "write")
+	w.Trigger("main.go", "write")
+
+	if len(formatter.files) != 2 {
+		t.Errorf("wildcard handler received %v, want both files", formatter.files)
+	}
+	if errs := w.Validate(); len(errs) != 0 {
+		t.Errorf("wildcard handlers need no main input, got %v", errs)
+	}
+}
@@ -0,0 +1,48 @@
+package devwatch
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// goModulePath returns the module path declared in AppRootDir/go.mod, or ""
+func (h *DevWatch) goModulePath() string {
+	return readModulePath(h.AppRootDir)
+}
+
+// readModulePath returns the module path declared in dir/go.mod
+func readModulePath(dir string) string {
+	f, err := os.Open(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// goPackageOf resolves the import path of the package containing filePath
+// from the module path in go.mod, eg: "myapp/internal/users". Returns the
+// project-relative directory when there is no go.mod.
+func (h *DevWatch) goPackageOf(filePath string) string {
+	dir := path.Dir(h.relativePath(filePath))
+	module := h.goModulePath()
+	switch {
+	case module == "":
+		return dir
+	case dir == ".":
+		return module
+	default:
+		return module + "/" + dir
+	}
+}
@@ -0,0 +1,152 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cdvelop/godepfind"
+)
+
+// goWorkFile lists the modules of a multi-module workspace
+const goWorkFile = "go.work"
+
+// goWorkspace holds the modules of AppRootDir/go.work, each analyzed by its
+// own dependency finder. Loaded on first use, reset when go.work changes.
+type goWorkspace struct {
+	mu      sync.Mutex
+	loaded  bool
+	modules []string // absolute module dirs, nested modules first
+	finders map[string]*godepfind.GoDepFind
+}
+
+// reset forgets the workspace; it is read again on the next Go event
+func (w *goWorkspace) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.loaded, w.modules, w.finders = false, nil, nil
+}
+
+// load reads go.work once. Callers must hold w.mu.
+func (w *goWorkspace) load(h *DevWatch) {
+	if w.loaded {
+		return
+	}
+	w.loaded = true
+	if h.AppRootDir == "" {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(h.AppRootDir, goWorkFile))
+	if err != nil {
+		return // not a workspace
+	}
+	for _, use := range parseGoWorkUse(string(data)) {
+		if !filepath.IsAbs(use) {
+			use = filepath.Join(h.AppRootDir, use)
+		}
+		w.modules = append(w.modules, filepath.Clean(use))
+	}
+	// longest first so a file resolves to its innermost module
+	slices.SortFunc(w.modules, func(a, b string) int { return len(b) - len(a) })
+	w.finders = make(map[string]*godepfind.GoDepFind)
+}
+
+// active reports whether AppRootDir is a go.work workspace
+func (w *goWorkspace) active(h *DevWatch) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.load(h)
+	return len(w.modules) > 0
+}
+
+// moduleOf returns the workspace module directory containing path, "" when none
+func (w *goWorkspace) moduleOf(h *DevWatch, path string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.load(h)
+	for _, dir := range w.modules {
+		if _, err := GetRelPath(dir, path); err == nil {
+			return dir
+		}
+	}
+	return ""
+}
+
+// finder returns the dependency finder rooted at the workspace module dir
+func (w *goWorkspace) finder(dir string) *godepfind.GoDepFind {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	f, ok := w.finders[dir]
+	if !ok {
+		f = godepfind.New(dir)
+		if w.finders != nil {
+			w.finders[dir] = f
+		}
+	}
+	return f
+}
+
+// parseGoWorkUse returns the module directories of the use directives in a go.work file
+func parseGoWorkUse(data string) []string {
+	var uses []string
+	inBlock := false
+	for line := range strings.Lines(data) {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if inBlock {
+			if line == ")" {
+				inBlock = false
+			} else if line != "" {
+				uses = append(uses, strings.Trim(line, "\"`"))
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "use" {
+			continue
+		}
+		if fields[1] == "(" {
+			inBlock = true
+		} else {
+			uses = append(uses, strings.Trim(fields[1], "\"`"))
+		}
+	}
+	return uses
+}
+
+// workspaceFileIsMine resolves Go ownership across workspace modules: files
+// in the module of the main input use its dependency finder, files in other
+// modules are owned when the main package depends on their package
+func (h *DevWatch) workspaceFileIsMine(handler FilesEventHandlers, filePath, event string) (bool, error) {
+	fileModule := h.workspace.moduleOf(h, filePath)
+	if fileModule == "" {
+		return false, nil
+	}
+	var lastErr error
+	for _, main := range handlerMains(handler) {
+		if !h.mainInputReady(main) {
+			continue
+		}
+		mainPath := h.mainInputPath(main)
+		var isMine bool
+		var err error
+		if mainModule := h.workspace.moduleOf(h, mainPath); mainModule == fileModule {
+			rel, _ := filepath.Rel(mainModule, mainPath)
+			isMine, err = h.workspace.finder(mainModule).ThisFileIsMine(filepath.ToSlash(rel), filePath, event)
+		} else {
+			isMine, err = h.mainDependsOn(main, filepath.Dir(filePath))
+		}
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if isMine {
+			return true, nil
+		}
+	}
+	return false, lastErr
+}
@@ -0,0 +1,62 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseGoWorkUse(t *testing.T) {
+	data := "go 1.22\n\nuse ./tools // codegen\n\nuse (\n\t./a\n\t\"./b\"\n\t// ./old\n)\n\nreplace example.com/x => ./x\n"
+	if got, want := parseGoWorkUse(data), []string{"./tools", "./a", "./b"}; !slices.Equal(got, want) {
+		t.Errorf("parseGoWorkUse = %v, want %v", got, want)
+	}
+}
+
+func TestWorkspaceOwnership(t *testing.T) {
+	t.Setenv("GOFLAGS", "") // workspaces reject -mod=mod
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":         "go 1.22\n\nuse (\n\t./a\n\t./b\n)\n",
+		"a/go.mod":        "module a\n\ngo 1.22\n",
+		"a/cmd/main.go":   "package main\n\nimport \"b/lib\"\n\nfunc main() { lib.F() }\n",
+		"b/go.mod":        "module b\n\ngo 1.22\n",
+		"b/lib/lib.go":    "package lib\n\nfunc F() {}\n",
+		"b/other/o.go":    "package other\n",
+		"b/cmd/b/main.go": "package main\n\nfunc main() {}\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	handler := &FakeFilesEventHandler{SupportedExtensions_: []string{".go"}, MainInputFile: "a/cmd/main.go"}
+	w := New(&WatchConfig{AppRootDir: root, FilesEventHandlers: []FilesEventHandlers{handler}, Logger: func(message ...any) {}})
+
+	for file, want := range map[string]bool{
+		"a/cmd/main.go":   true,  // same module: dependency finder of module a
+		"b/lib/lib.go":    true,  // module b package imported by a
+		"b/other/o.go":    false, // module b package a doesn't import
+		"b/cmd/b/main.go": false,
+	} {
+		mine, err := w.goFileIsMine(handler, filepath.Join(root, file), "write")
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if mine != want {
+			t.Errorf("%s: owned = %v, want %v", file, mine, want)
+		}
+	}
+
+	// go.work edits reload the module list
+	w.depsChanged(filepath.Join(root, "go.work"))
+	if w.workspace.loaded || w.deps.dirs != nil {
+		t.Error("expected go.work change to reset the workspace")
+	}
+}
@@ -0,0 +1,178 @@
+package devwatch
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// importGraph is an in-memory graph of the module packages built from the
+// import blocks of their files. Saves update it by parsing only the saved
+// file; go.mod changes rebuild it. See WatchConfig.IncrementalDeps.
+type importGraph struct {
+	mu     sync.Mutex
+	built  bool
+	module string                     // module path declared in go.mod
+	files  map[string]goFileInfo      // absolute file path => its package and imports
+	dirs   map[string]map[string]bool // package dir => its files
+}
+
+// goFileInfo is the import block of a Go file
+type goFileInfo struct {
+	pkg      string   // package name
+	imports  []string // import paths
+	included bool     // matches the build constraints of the host
+}
+
+// reset drops the graph; it is rebuilt on next use
+func (g *importGraph) reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.built = false
+	g.files, g.dirs = nil, nil
+}
+
+// fileChanged updates the graph with the new import block of a Go file, or
+// forgets the files of a removed folder
+func (g *importGraph) fileChanged(path, event string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.built {
+		return
+	}
+	if _, known := g.files[path]; known {
+		g.remove(path)
+	} else if event == "remove" || event == "rename" {
+		for file := range g.files {
+			if strings.HasPrefix(file, path+string(filepath.Separator)) {
+				g.remove(file)
+			}
+		}
+	}
+	if event != "remove" && event != "rename" && filepath.Ext(path) == ".go" {
+		g.add(path)
+	}
+}
+
+// build walks the module the way the go tool does (skipping vendor,
+// testdata and folders starting with "." or "_"). Callers must hold mu.
+func (g *importGraph) build(h *DevWatch) bool {
+	g.module = h.goModulePath()
+	if g.module == "" {
+		return false
+	}
+	g.files = make(map[string]goFileInfo)
+	g.dirs = make(map[string]map[string]bool)
+	root := h.AppRootDir
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(name) == ".go" {
+			g.add(path)
+		}
+		return nil
+	})
+	g.built = true
+	return true
+}
+
+// add parses the import block of path. Callers must hold mu.
+func (g *importGraph) add(path string) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+	if err != nil {
+		return
+	}
+	info := goFileInfo{pkg: file.Name.Name}
+	for _, spec := range file.Imports {
+		info.imports = append(info.imports, strings.Trim(spec.Path.Value, `"`))
+	}
+	dir, name := filepath.Split(path)
+	dir = filepath.Clean(dir)
+	info.included, _ = build.Default.MatchFile(dir, name)
+	g.files[path] = info
+	if g.dirs[dir] == nil {
+		g.dirs[dir] = make(map[string]bool)
+	}
+	g.dirs[dir][path] = true
+}
+
+// remove forgets path. Callers must hold mu.
+func (g *importGraph) remove(path string) {
+	delete(g.files, path)
+	dir := filepath.Dir(path)
+	delete(g.dirs[dir], path)
+}
+
+// owns reports whether the main input main depends on the package of
+// filePath: main packages only own their own directory, other packages are
+// owned when the main file imports them directly or transitively. ok is
+// false when the graph can't tell (test files, files excluded by build
+// constraints, files that don't parse) and the dependency finder must decide.
+func (g *importGraph) owns(h *DevWatch, main, filePath string) (owned, ok bool) {
+	if strings.HasSuffix(filePath, "_test.go") {
+		return false, false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.built && !g.build(h) {
+		return false, false
+	}
+	info, known := g.files[filePath]
+	mainPath := h.mainInputPath(main)
+	mainInfo, mainKnown := g.files[mainPath]
+	if !known || !info.included || !mainKnown {
+		return false, false
+	}
+	dir := filepath.Dir(filePath)
+	if info.pkg == "main" {
+		return dir == filepath.Dir(mainPath), true
+	}
+
+	// breadth first over the module packages imported by the main file
+	visited := make(map[string]bool)
+	queue := g.localDirs(h, mainInfo.imports)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+		if next == dir {
+			return true, true
+		}
+		for file := range g.dirs[next] {
+			if f := g.files[file]; f.included && !strings.HasSuffix(file, "_test.go") {
+				queue = append(queue, g.localDirs(h, f.imports)...)
+			}
+		}
+	}
+	return false, true
+}
+
+// localDirs returns the directories of the module packages among imports.
+// Callers must hold mu.
+func (g *importGraph) localDirs(h *DevWatch, imports []string) []string {
+	var dirs []string
+	for _, imp := range imports {
+		switch {
+		case imp == g.module:
+			dirs = append(dirs, filepath.Clean(h.AppRootDir))
+		case strings.HasPrefix(imp, g.module+"/"):
+			dirs = append(dirs, filepath.Join(h.AppRootDir, filepath.FromSlash(strings.TrimPrefix(imp, g.module+"/"))))
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,74 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportGraph(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("go.mod", "module a\n\ngo 1.20\n")
+	write("cmd/app/main.go", "package main\n\nimport _ \"a/api\"\n\nfunc main() {}\n")
+	api := write("api/api.go", "package api\n\nimport (\n\t_ \"a/db\"\n\t_ \"strings\"\n)\n")
+	db := write("db/db.go", "package db\n")
+	tool := write("tools/tool.go", "package main\n\nfunc main() {}\n")
+	test := write("db/db_test.go", "package db\n")
+
+	w := New(&WatchConfig{AppRootDir: root, IncrementalDeps: true, Logger: func(message ...any) {}})
+	owns := func(path string) (bool, bool) { return w.imports.owns(w, "cmd/app/main.go", path) }
+
+	for path, want := range map[string]bool{
+		api:                                    true,
+		db:                                     true, // transitive
+		tool:                                   false,
+		filepath.Join(root, "cmd/app/main.go"): true,
+	} {
+		if owned, ok := owns(path); !ok || owned != want {
+			t.Errorf("%s: got owned %v ok %v, want %v", path, owned, ok, want)
+		}
+	}
+	if _, ok := owns(test); ok {
+		t.Error("test files must be left to the dependency finder")
+	}
+
+	// only the saved file is parsed again
+	write("api/api.go", "package api\n")
+	w.imports.fileChanged(api, "write")
+	if owned, ok := owns(db); !ok || owned {
+		t.Error("expected db to be released once api stopped importing it")
+	}
+
+	// new packages join the graph on create
+	write("api/api.go", "package api\n\nimport _ \"a/cache\"\n")
+	w.imports.fileChanged(api, "write")
+	cache := write("cache/cache.go", "package cache\n")
+	w.imports.fileChanged(cache, "create")
+	if owned, ok := owns(cache); !ok || !owned {
+		t.Error("expected the created package to be owned")
+	}
+
+	// removed folders leave the graph
+	os.RemoveAll(filepath.Join(root, "cache"))
+	w.imports.fileChanged(filepath.Join(root, "cache"), "remove")
+	if _, ok := owns(cache); ok {
+		t.Error("expected the removed file to be unknown")
+	}
+
+	// go.mod changes rebuild the graph with the new module path
+	write("go.mod", "module b\n\ngo 1.20\n")
+	w.depsChanged(filepath.Join(root, "go.mod"))
+	if owned, ok := owns(api); !ok || owned {
+		t.Error("expected imports of the old module path to be ignored after go.mod changed")
+	}
+}
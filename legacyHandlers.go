@@ -0,0 +1,77 @@
+package devwatch
+
+import "reflect"
+
+// FileEvent is the handler interface of the removed WatchConfig.FileEventAssets field.
+//
+// Deprecated: implement FilesEventHandlers, or wrap with WrapAssetHandler.
+type FileEvent interface {
+	NewFileEvent(fileName, extension, filePath, event string) error
+}
+
+// GoFileHandler is the handler interface of the removed WatchConfig.FilesEventGO field.
+//
+// Deprecated: implement FilesEventHandlers, or wrap with WrapGoHandler.
+type GoFileHandler interface {
+	MainInputFileRelativePath() string
+	NewFileEvent(fileName, extension, filePath, event string) error
+}
+
+// defaultAssetsExtensions were the extensions handled by FileEventAssets
+// when none were added with the removed AddSupportedAssetsExtensions
+var defaultAssetsExtensions = []string{".html", ".css", ".js", ".svg"}
+
+// WrapAssetHandler adapts a legacy FileEventAssets handler to
+// FilesEventHandlers so old configs keep working:
+//
+//	FilesEventHandlers: []devwatch.FilesEventHandlers{devwatch.WrapAssetHandler(assets)}
+//
+// extensions replaces the old supported assets extensions (default .html, .css, .js, .svg).
+func WrapAssetHandler(handler FileEvent, extensions ...string) FilesEventHandlers {
+	if len(extensions) == 0 {
+		extensions = defaultAssetsExtensions
+	}
+	return &legacyHandler{event: handler, extensions: extensions}
+}
+
+// WrapGoHandler adapts a legacy FilesEventGO handler to FilesEventHandlers;
+// it receives .go files it owns according to the dependency analysis.
+func WrapGoHandler(handler GoFileHandler) FilesEventHandlers {
+	return &legacyHandler{event: handler, main: handler.MainInputFileRelativePath, extensions: []string{".go"}}
+}
+
+// legacyHandler is the FilesEventHandlers adapter built by the Wrap functions
+type legacyHandler struct {
+	event      FileEvent
+	main       func() string
+	extensions []string
+}
+
+func (l *legacyHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	return l.event.NewFileEvent(fileName, extension, filePath, event)
+}
+
+func (l *legacyHandler) SupportedExtensions() []string { return l.extensions }
+
+func (l *legacyHandler) MainInputFileRelativePath() string {
+	if l.main == nil {
+		return ""
+	}
+	return l.main()
+}
+
+// UnobservedFiles forwards to the wrapped handler when it declares them
+func (l *legacyHandler) UnobservedFiles() []string {
+	if u, ok := l.event.(interface{ UnobservedFiles() []string }); ok {
+		return u.UnobservedFiles()
+	}
+	return nil
+}
+
+// Name reports the wrapped handler, so logs and profiles don't show the adapter
+func (l *legacyHandler) Name() string {
+	if nh, ok := l.event.(NamedHandler); ok && nh.Name() != "" {
+		return nh.Name()
+	}
+	return reflect.TypeOf(l.event).String()
+}
@@ -0,0 +1,54 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// legacyAssets only implements the old FileEventAssets interface
+type legacyAssets struct{ events []string }
+
+func (l *legacyAssets) NewFileEvent(fileName, extension, filePath, event string) error {
+	l.events = append(l.events, fileName)
+	return nil
+}
+
+// legacyGo only implements the old FilesEventGO interface
+type legacyGo struct{ legacyAssets }
+
+func (l *legacyGo) MainInputFileRelativePath() string { return "cmd/main.go" }
+
+func TestWrapLegacyHandlers(t *testing.T) {
+	root := t.TempDir()
+	css := filepath.Join(root, "style.css")
+	if err := os.WriteFile(css, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	assets := &legacyAssets{}
+	goHandler := &legacyGo{}
+	wrappedGo := WrapGoHandler(goHandler)
+	if handlerMain(wrappedGo) != "cmd/main.go" || !slices.Equal(wrappedGo.SupportedExtensions(), []string{".go"}) {
+		t.Errorf("unexpected go adapter: %v %v", handlerMain(wrappedGo), wrappedGo.SupportedExtensions())
+	}
+	if name := handlerName(wrappedGo); name != "*devwatch.legacyGo" {
+		t.Errorf("expected adapter to report the wrapped handler name, got %q", name)
+	}
+
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		FilesEventHandlers: []FilesEventHandlers{WrapAssetHandler(assets), wrappedGo},
+		Logger:             func(message ...any) {},
+	})
+	if err := w.Trigger("style.css", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(assets.events, []string{"style.css"}) {
+		t.Errorf("expected wrapped asset handler to receive the css event, got %v", assets.events)
+	}
+	if len(goHandler.events) != 0 {
+		t.Errorf("expected go handler to ignore css, got %v", goHandler.events)
+	}
+}
@@ -0,0 +1,104 @@
+package devwatch
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaults used when WatchConfig.LoopLimit / LoopWindow are not set
+const (
+	defaultLoopLimit  = 10
+	defaultLoopWindow = 5 * time.Second
+)
+
+// errCircuitOpen is recorded for handler calls skipped by a tripped breaker
+var errCircuitOpen = errors.New("circuit breaker open: handler paused for this file until its content changes")
+
+// loopBreaker detects a file dispatched to the same handler over and over
+// with identical content (eg: a handler touching its own input) and pauses
+// that handler/path pair until the content changes
+type loopBreaker struct {
+	mu      sync.Mutex
+	calls   map[loopKey][]time.Time // recent calls
+	hashes  map[loopKey]string      // content hash of those calls
+	tripped map[loopKey]string      // content hash it tripped on
+}
+
+// loopKey is a handler/path pair watched by the loopBreaker, the handler
+// by its index in FilesEventHandlers
+type loopKey struct {
+	handler int
+	path    string
+}
+
+// open reports whether calls of handler for path with content hash are paused
+func (b *loopBreaker) open(key loopKey, hash string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	tripped, ok := b.tripped[key]
+	if ok && tripped != hash {
+		delete(b.tripped, key) // content changed: close the breaker
+		return false
+	}
+	return ok
+}
+
+// record counts a call and reports whether it trips the breaker: more than
+// limit calls within window, all with the same content hash
+func (b *loopBreaker) record(key loopKey, hash string, now time.Time, limit int, window time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.calls == nil {
+		b.calls = make(map[loopKey][]time.Time)
+		b.hashes = make(map[loopKey]string)
+		b.tripped = make(map[loopKey]string)
+	}
+	calls := b.calls[key]
+	if b.hashes[key] != hash {
+		calls = calls[:0]
+		b.hashes[key] = hash
+	}
+	for len(calls) > 0 && now.Sub(calls[0]) > window {
+		calls = calls[1:]
+	}
+	calls = append(calls, now)
+	if len(calls) <= limit {
+		b.calls[key] = calls
+		return false
+	}
+	delete(b.calls, key)
+	b.tripped[key] = hash
+	return true
+}
+
+// loopCheck is called before the handler of index id runs for filePath. It returns
+// errCircuitOpen when the pair is paused or this call trips the breaker,
+// which is reported through Logger and OnError.
+func (h *DevWatch) loopCheck(id int, handler FilesEventHandlers, filePath, hash string) error {
+	if hash == "" {
+		return nil // removed or unreadable
+	}
+	key := loopKey{handler: id, path: filePath}
+	if h.loops.open(key, hash) {
+		return errCircuitOpen
+	}
+	limit, window := h.LoopLimit, h.LoopWindow
+	if limit <= 0 {
+		limit = defaultLoopLimit
+	}
+	if window <= 0 {
+		window = defaultLoopWindow
+	}
+	if !h.loops.record(key, hash, time.Now(), limit, window) {
+		return nil
+	}
+	err := errors.New("rebuild loop: " + handlerName(handler) + " triggered more than " + strconv.Itoa(limit) +
+		" times in " + window.String() + " by unchanged " + h.relativePath(filePath) + ", paused until it changes")
+	h.say("rebuild-loop", err, handlerName(handler), h.relativePath(filePath))
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+	return errCircuitOpen
+}
@@ -0,0 +1,138 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoopBreaker(t *testing.T) {
+	root := t.TempDir()
+	cssFile := filepath.Join(root, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var reported []error
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		LoopLimit:  3,
+		LoopWindow: time.Minute,
+		OnError:    func(err error) { reported = append(reported, err) },
+		Logger:     func(message ...any) { t.Log(message...) },
+	})
+	calls := 0
+	_, err := w.Handle(".css").Name("touch").OnEvent(func(fileName, extension, filePath, event string) error {
+		calls++
+		return nil
+	}).Register()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 6 {
+		if err := w.Trigger(cssFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("expected the breaker to stop the handler after 3 calls, got %d", calls)
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "touch triggered more than 3 times") {
+		t.Errorf("expected one loop report, got %v", reported)
+	}
+
+	// a real change closes the breaker
+	if err := os.WriteFile(cssFile, []byte("body { color: red }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(cssFile, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 4 {
+		t.Errorf("expected the handler to run again after the content changed, got %d calls", calls)
+	}
+}
+
+func TestLoopBreaker_Window(t *testing.T) {
+	var b loopBreaker
+	now := time.Now()
+	for i := range 5 {
+		if b.record(loopKey{path: "a.css"}, "v1", now.Add(time.Duration(i)*time.Second), 2, 1500*time.Millisecond) {
+			t.Fatalf("calls spread beyond the window must not trip, call %d", i)
+		}
+	}
+	if !b.record(loopKey{path: "a.css"}, "v1", now.Add(4100*time.Millisecond), 2, 1500*time.Millisecond) {
+		t.Error("expected a trip with 3 calls inside the window")
+	}
+}
+
+func TestLoopBreaker_SameType(t *testing.T) {
+	root := t.TempDir()
+	cssFile := filepath.Join(root, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w := New(&WatchConfig{
+		AppRootDir: root,
+		LoopLimit:  3,
+		LoopWindow: time.Minute,
+		Logger:     func(message ...any) {},
+	})
+	// unnamed handlers of one type count their calls apart
+	calls := make([]int, 2)
+	for i := range calls {
+		w.Handle(".css").OnEvent(func(fileName, extension, filePath, event string) error {
+			calls[i]++
+			return nil
+		}).Register()
+	}
+
+	for range 3 {
+		if err := w.Trigger(cssFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls[0] != 3 || calls[1] != 3 {
+		t.Errorf("expected each handler to run 3 times below the limit, got %v", calls)
+	}
+}
+
+// sliceHandler is a value type handler that can't key a map
+type sliceHandler struct {
+	exts  []string
+	calls *int
+}
+
+func (s sliceHandler) NewFileEvent(fileName, extension, filePath, event string) error {
+	*s.calls++
+	return nil
+}
+
+func (s sliceHandler) SupportedExtensions() []string { return s.exts }
+
+func TestLoopBreaker_ValueHandler(t *testing.T) {
+	root := t.TempDir()
+	cssFile := filepath.Join(root, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		LoopLimit:          2,
+		LoopWindow:         time.Minute,
+		FilesEventHandlers: []FilesEventHandlers{sliceHandler{exts: []string{".css"}, calls: &calls}},
+		Logger:             func(message ...any) {},
+	})
+	for range 3 {
+		if err := w.Trigger(cssFile, "write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the breaker to stop the handler after 2 calls, got %d", calls)
+	}
+}
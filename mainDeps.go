@@ -0,0 +1,118 @@
+package devwatch
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cdvelop/godepfind"
+)
+
+// depCache caches the package directories each main input depends on, for
+// ownership godepfind can't resolve: vendored packages and other workspace modules
+type depCache struct {
+	mu   sync.Mutex
+	dirs map[string][]string // main input => dirs of its non standard deps
+}
+
+// forget drops the cached dependencies; they are listed again when needed
+func (d *depCache) forget() {
+	d.mu.Lock()
+	d.dirs = nil
+	d.mu.Unlock()
+}
+
+// resetDeps starts the dependency analysis from scratch. Callers must hold mainMu.
+func (h *DevWatch) resetDeps() {
+	h.depFinder = godepfind.New(h.AppRootDir)
+	h.workspace.reset()
+	h.deps.forget()
+	h.ownership.clear()
+	h.imports.reset()
+}
+
+// depsChanged resets the dependency analysis when the module layout changes
+// ("go mod vendor", go.work edits) and forgets listed dependencies when
+// project Go files change
+func (h *DevWatch) depsChanged(filePath string) {
+	rel := h.relativePath(filePath)
+	switch {
+	case rel == vendorManifest || rel == goWorkFile:
+		h.mainMu.Lock()
+		h.resetDeps()
+		h.mainMu.Unlock()
+		h.say("deps-reset", rel)
+	case rel == "go.mod":
+		h.imports.reset() // the module path may have changed
+		h.ownership.clear()
+		h.deps.forget()
+	case filepath.Ext(rel) == ".go" && !isVendored(rel):
+		h.deps.forget() // imports may have changed
+	}
+}
+
+// depsOwn reports whether a main input of handler depends on the package
+// in the directory of filePath
+func (h *DevWatch) depsOwn(handler FilesEventHandlers, filePath string) (bool, error) {
+	var lastErr error
+	for _, main := range handlerMains(handler) {
+		if !h.mainInputReady(main) {
+			continue
+		}
+		isMine, err := h.mainDependsOn(main, filepath.Dir(filePath))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if isMine {
+			return true, nil
+		}
+	}
+	return false, lastErr
+}
+
+// mainDependsOn reports whether the package of main depends on the package in dir
+func (h *DevWatch) mainDependsOn(main, dir string) (bool, error) {
+	dirs, err := h.mainDeps(main)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(dirs, func(d string) bool { return h.samePath(d, dir) }), nil
+}
+
+// mainDeps lists the directories of the non standard packages the package
+// of main depends on, resolved the way the go command builds it
+func (h *DevWatch) mainDeps(main string) ([]string, error) {
+	h.deps.mu.Lock()
+	defer h.deps.mu.Unlock()
+	if dirs, ok := h.deps.dirs[main]; ok {
+		return dirs, nil
+	}
+
+	// workspace modules are listed from their own directory; -mod overrides
+	// GOFLAGS (workspaces only allow readonly)
+	moduleDir, mod := h.AppRootDir, "-mod=vendor"
+	mainPath := h.mainInputPath(main)
+	if dir := h.workspace.moduleOf(h, mainPath); dir != "" {
+		moduleDir, mod = dir, "-mod=readonly"
+	}
+	cmd := exec.Command("go", "list", mod, "-deps", "-f", "{{if not .Standard}}{{.Dir}}{{end}}", filepath.Dir(mainPath))
+	cmd.Dir = moduleDir
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, errors.New("mainDeps " + main + ": " + strings.TrimSpace(string(ee.Stderr)))
+		}
+		return nil, errors.New("mainDeps " + main + ": " + err.Error())
+	}
+
+	if h.deps.dirs == nil {
+		h.deps.dirs = make(map[string][]string)
+	}
+	dirs := strings.Split(strings.TrimSpace(string(out)), "\n")
+	h.deps.dirs[main] = dirs
+	return dirs, nil
+}
@@ -0,0 +1,56 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// mainInputPath returns the absolute path of a handler main input file
+func (h *DevWatch) mainInputPath(main string) string {
+	if filepath.IsAbs(main) {
+		return main
+	}
+	return filepath.Join(h.AppRootDir, main)
+}
+
+// mainInputReady reports whether the main input file of a Go handler exists.
+// Missing mains (scaffolded later) are remembered and logged once, instead
+// of erroring on every .go event.
+func (h *DevWatch) mainInputReady(main string) bool {
+	if main == "" {
+		return false
+	}
+	if _, err := os.Stat(h.mainInputPath(main)); err == nil {
+		return true
+	}
+
+	h.mainMu.Lock()
+	defer h.mainMu.Unlock()
+	if h.missingMains == nil {
+		h.missingMains = make(map[string]bool)
+	}
+	if !h.missingMains[main] {
+		h.missingMains[main] = true
+		h.say("main-waiting", main)
+	}
+	return false
+}
+
+// rebindMainInput reacts to the creation of a main input file that was
+// missing: the dependency finder is rebuilt so the new main is analyzed.
+func (h *DevWatch) rebindMainInput(filePath, event string) {
+	if event == "remove" {
+		return
+	}
+
+	h.mainMu.Lock()
+	defer h.mainMu.Unlock()
+	for main := range h.missingMains {
+		if !h.samePath(h.mainInputPath(main), filePath) {
+			continue
+		}
+		delete(h.missingMains, main)
+		h.resetDeps()
+		h.say("main-found", main)
+	}
+}
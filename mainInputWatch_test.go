@@ -0,0 +1,77 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMainInputRebinding(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte("module testapp\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	appDir := filepath.Join(tempDir, "app")
+	if err := os.MkdirAll(appDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	helper := filepath.Join(appDir, "helper.go")
+	if err := os.WriteFile(helper, []byte("package main\n\nfunc helper() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var called int32
+	var logMu sync.Mutex
+	var logs []string
+	w := New(&WatchConfig{
+		AppRootDir: tempDir,
+		FilesEventHandlers: []FilesEventHandlers{&FakeFilesEventHandler{
+			Called:               &called,
+			SupportedExtensions_: []string{".go"},
+			MainInputFile:        "app/main.go",
+		}},
+		Logger: func(message ...any) {
+			logMu.Lock()
+			defer logMu.Unlock()
+			for _, m := range message {
+				if s, ok := m.(string); ok {
+					logs = append(logs, s)
+				}
+			}
+		},
+	})
+
+	// main input missing: handler is skipped, warning logged once
+	w.Trigger(helper, "write")
+	w.Trigger(helper, "write")
+	if atomic.LoadInt32(&called) != 0 {
+		t.Fatal("handler must not run while its main input is missing")
+	}
+	waiting := 0
+	for _, l := range logs {
+		if strings.HasPrefix(l, "waiting for main input file") {
+			waiting++
+		}
+	}
+	if waiting != 1 {
+		t.Errorf("expected one waiting log, got %d in %v", waiting, logs)
+	}
+
+	// scaffolding main.go rebinds the handler
+	mainFile := filepath.Join(appDir, "main.go")
+	if err := os.WriteFile(mainFile, []byte("package main\n\nfunc main() { helper() }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(mainFile, "create"); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&called) != 1 {
+		t.Fatal("expected handler to receive its main input once it exists")
+	}
+	if len(w.missingMains) != 0 {
+		t.Errorf("missing mains not cleared: %v", w.missingMains)
+	}
+}
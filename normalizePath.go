@@ -0,0 +1,78 @@
+package devwatch
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// normalizedPath holds the two forms of a path used across devwatch:
+// Contain, the watch registry, handler dispatch and the dependency finder
+// all compare paths through it instead of converting separators themselves.
+type normalizedPath struct {
+	Slash string // cleaned input with forward slashes (relative paths stay relative)
+	Abs   string // absolute, cleaned, forward slashes
+	Rel   string // relative to AppRootDir; Slash when the path is outside the root
+}
+
+// slashPath cleans p and converts backslashes to forward slashes on every
+// OS, so Windows style paths from configs and handlers compare equal.
+// Drive letters are upper cased ("c:\\app" -> "C:/app").
+func slashPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if len(p) >= 2 && p[1] == ':' {
+		p = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return p
+}
+
+// absSlashPath returns p as an absolute slash path; relative paths are
+// resolved against the working directory like fsnotify event names
+func absSlashPath(p string) string {
+	slash := slashPath(p)
+	if slash == "" || isAbsSlash(slash) {
+		return slash
+	}
+	abs, err := filepath.Abs(filepath.FromSlash(slash))
+	if err != nil {
+		return slash
+	}
+	return slashPath(abs)
+}
+
+// isAbsSlash reports whether a slash path is absolute, including Windows
+// drive letters ("C:/app") on any OS
+func isAbsSlash(p string) bool {
+	if strings.HasPrefix(p, "/") {
+		return true
+	}
+	return len(p) >= 3 && p[1] == ':' && p[2] == '/'
+}
+
+// normalize returns the canonical forms of p
+func (h *DevWatch) normalize(p string) normalizedPath {
+	n := normalizedPath{Slash: slashPath(p)}
+	n.Abs = absSlashPath(n.Slash)
+	n.Rel = n.Slash
+	if h.AppRootDir == "" {
+		return n
+	}
+	if rel, err := GetRelPath(absSlashPath(h.AppRootDir), n.Abs); err == nil {
+		n.Rel = rel
+	}
+	return n
+}
+
+// relativePath returns path relative to AppRootDir using forward slashes.
+// Paths outside AppRootDir are returned normalized but unchanged.
+func (h *DevWatch) relativePath(path string) string {
+	return h.normalize(path).Rel
+}
+
+// samePath reports whether a and b name the same file once normalized
+func (h *DevWatch) samePath(a, b string) bool {
+	return h.normalize(a).Abs == h.normalize(b).Abs
+}
@@ -0,0 +1,41 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	root := t.TempDir()
+	absRoot := slashPath(root)
+	w := New(&WatchConfig{AppRootDir: root})
+
+	tests := []struct {
+		name string
+		path string
+		abs  string
+		rel  string
+	}{
+		{"absolute inside root", filepath.Join(root, "web", "style.css"), absRoot + "/web/style.css", "web/style.css"},
+		{"trailing separator", filepath.Join(root, "web") + string(filepath.Separator), absRoot + "/web", "web"},
+		{"dot segments", root + "/web/../web/./main.go", absRoot + "/web/main.go", "web/main.go"},
+		{"root itself", root, absRoot, "."},
+		{"windows separators", "C:\\app\\web\\main.go", "C:/app/web/main.go", "C:/app/web/main.go"},
+		{"outside root", "/elsewhere/file.go", "/elsewhere/file.go", "/elsewhere/file.go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := w.normalize(tt.path)
+			if got.Abs != tt.abs {
+				t.Errorf("Abs = %q, want %q", got.Abs, tt.abs)
+			}
+			if got.Rel != tt.rel {
+				t.Errorf("Rel = %q, want %q", got.Rel, tt.rel)
+			}
+		})
+	}
+
+	if !w.samePath(filepath.Join(root, "main.go"), root+"/./main.go") {
+		t.Error("expected samePath to match equivalent spellings")
+	}
+}
@@ -0,0 +1,205 @@
+package devwatch
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ownershipCache remembers which Go files each main input owns, so writes
+// that don't change imports skip the dependency analysis. It is cleared
+// whenever the dependency graph may have changed: a Go file created,
+// removed or renamed, a write changing the imports of a file, or a reset of
+// the dependency finder.
+type ownershipCache struct {
+	mu      sync.Mutex
+	owned   map[[2]string]bool // {main input, file path} => owned
+	imports map[string]string  // file path => its sorted imports
+	gen     uint64             // bumped by clear, so results computed before are dropped
+}
+
+// get returns the cached ownership of path by main
+func (c *ownershipCache) get(main, path string) (owned, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	owned, ok = c.owned[[2]string{main, path}]
+	return owned, ok
+}
+
+// generation returns the current cache generation, see put
+func (c *ownershipCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// put stores a result computed during generation gen; it is dropped when
+// the cache was cleared meanwhile
+func (c *ownershipCache) put(gen uint64, main, path string, owned bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	if c.owned == nil {
+		c.owned = make(map[[2]string]bool)
+	}
+	c.owned[[2]string{main, path}] = owned
+}
+
+// clear forgets every result
+func (c *ownershipCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.owned = nil
+	c.gen++
+}
+
+// recordImports stores the imports of a Go file, reporting whether they
+// differ from the recorded ones. Files that don't parse (eg: half written)
+// count as changed.
+func (c *ownershipCache) recordImports(path string) (changed bool) {
+	imports, ok := goImports(path)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.imports == nil {
+		c.imports = make(map[string]string)
+	}
+	previous, known := c.imports[path]
+	if !ok {
+		delete(c.imports, path)
+		return true
+	}
+	c.imports[path] = imports
+	return !known || previous != imports
+}
+
+// importsOf returns the recorded imports of a Go file
+func (c *ownershipCache) importsOf(path string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	imports, ok := c.imports[path]
+	return imports, ok
+}
+
+// fileChanged invalidates the cache for a Go file event before handlers
+// are asked for ownership: only writes keeping the imports keep it
+func (c *ownershipCache) fileChanged(path, event string) {
+	switch event {
+	case "write":
+		if !c.recordImports(path) {
+			return
+		}
+	case "remove", "rename":
+		c.mu.Lock()
+		delete(c.imports, path)
+		c.mu.Unlock()
+	default:
+		c.recordImports(path)
+	}
+	c.clear()
+}
+
+// goImports returns the sorted import paths of a Go file
+func goImports(path string) (string, bool) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+	if err != nil {
+		return "", false
+	}
+	imports := make([]string, 0, len(file.Imports))
+	for _, spec := range file.Imports {
+		imports = append(imports, spec.Path.Value)
+	}
+	slices.Sort(imports)
+	return strings.Join(imports, ","), true
+}
+
+// mainOwnsFile asks the dependency finder whether main owns filePath. With
+// WarmOwnership, writes are answered from the ownership cache when possible;
+// with IncrementalDeps, from the import graph before the dependency finder.
+func (h *DevWatch) mainOwnsFile(main, filePath, event string) (bool, error) {
+	cacheable := h.WarmOwnership && (event == "write" || event == "create")
+	if cacheable && event == "write" {
+		if owned, ok := h.ownership.get(main, filePath); ok {
+			return owned, nil
+		}
+	}
+	if h.IncrementalDeps {
+		if owned, ok := h.imports.owns(h, main, filePath); ok {
+			return owned, nil
+		}
+	}
+	gen := h.ownership.generation()
+	h.mainMu.Lock()
+	owned, err := h.depFinder.ThisFileIsMine(main, filePath, event)
+	h.mainMu.Unlock()
+	if err == nil && cacheable {
+		h.ownership.put(gen, main, filePath, owned)
+	}
+	return owned, err
+}
+
+// warmOwnership computes in the background the ownership of every Go file
+// for every Go handler main input, so the first save after startup doesn't
+// pay for the dependency analysis. See WatchConfig.WarmOwnership.
+func (h *DevWatch) warmOwnership() {
+	var mains []string
+	for _, handler := range h.FilesEventHandlers {
+		if _, ok := handlerSupports(handler, "x.go", ".go"); !ok || ownsAllGoFiles(handler) {
+			continue
+		}
+		for _, main := range handlerMains(handler) {
+			if !slices.Contains(mains, main) && h.mainInputReady(main) {
+				mains = append(mains, main)
+			}
+		}
+	}
+	if len(mains) == 0 {
+		return
+	}
+
+	var files []string
+	for _, root := range h.watchRoots() {
+		h.walkRoot(root, func(path string, isDir bool) error {
+			if h.Contain(path) || isDir && h.beyondDepth(path) {
+				if isDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isDir && filepath.Ext(path) == ".go" && !isVendored(h.relativePath(path)) {
+				files = append(files, path)
+			}
+			return nil
+		})
+	}
+
+	var warmed int
+	for _, path := range files {
+		if h.closing.Load() {
+			return
+		}
+		if _, known := h.ownership.importsOf(path); !known {
+			h.ownership.recordImports(path)
+		}
+		for _, main := range mains {
+			if _, ok := h.ownership.get(main, path); ok {
+				continue
+			}
+			if h.samePath(h.mainInputPath(main), path) {
+				// always owned; asking would rebuild the whole dependency graph
+				h.ownership.put(h.ownership.generation(), main, path, true)
+				continue
+			}
+			if _, err := h.mainOwnsFile(main, path, "write"); err == nil {
+				warmed++
+			}
+		}
+	}
+	if h.Debug {
+		h.say("ownership-warmed", warmed, len(files))
+	}
+}
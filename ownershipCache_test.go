@@ -0,0 +1,75 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWarmOwnership(t *testing.T) {
+	t.Setenv("GOFLAGS", "")
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module a\n\ngo 1.20\n",
+		"cmd/app/main.go": "package main\n\nimport _ \"a/api\"\n\nfunc main() {}\n",
+		"api/api.go":      "package api\n",
+		"tools/tool.go":   "package main\n\nfunc main() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var calls int32
+	w := New(&WatchConfig{AppRootDir: root, WarmOwnership: true, Logger: func(message ...any) {}})
+	if _, err := w.Handle(".go").Name("server").Main("cmd/app/main.go").OnEvent(func(fileName, extension, filePath, event string) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}).Register(); err != nil {
+		t.Fatal(err)
+	}
+	w.warmOwnership()
+
+	api := filepath.Join(root, "api", "api.go")
+	for path, want := range map[string]bool{
+		api:                                    true,
+		filepath.Join(root, "tools/tool.go"):   false,
+		filepath.Join(root, "cmd/app/main.go"): true,
+	} {
+		if owned, ok := w.ownership.get("cmd/app/main.go", path); !ok || owned != want {
+			t.Errorf("%s: expected warmed ownership %v, got %v (cached %v)", path, want, owned, ok)
+		}
+	}
+
+	// writes keeping the imports are answered from the cache
+	w.ownership.put(w.ownership.generation(), "cmd/app/main.go", api, false)
+	if err := os.WriteFile(api, []byte("package api\n\nvar X = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(api, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Fatal("expected the cached ownership to be used")
+	}
+
+	// an import change invalidates it
+	if err := os.WriteFile(api, []byte("package api\n\nimport _ \"strings\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Trigger(api, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected the dependency analysis after an import change, got %d calls", calls)
+	}
+	if owned, ok := w.ownership.get("cmd/app/main.go", api); !ok || !owned {
+		t.Error("expected the fresh result to be cached")
+	}
+}
@@ -180,7 +180,7 @@ func TestContainWithMultipleHandlers(t *testing.T) {
 	}
 
 	for _, handler := range dw.FilesEventHandlers {
-		for _, file := range handler.UnobservedFiles() {
+		for _, file := range handlerUnobserved(handler) {
 			dw.no_add_to_watch[file] = true
 		}
 	}
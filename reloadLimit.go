@@ -0,0 +1,47 @@
+package devwatch
+
+import "time"
+
+// reloadLimiter is a token bucket bounding browser reloads to
+// MaxReloadsPerSecond (burst of max(1, rate)). Guarded by reloadMutex.
+type reloadLimiter struct {
+	tokens float64
+	last   time.Time
+	// deferred is set while a rate limited reload waits for its token;
+	// reload requests meanwhile collapse into it
+	deferred bool
+}
+
+// take consumes a token at now. Returns 0 when the reload may fire, or the
+// wait until the next token.
+func (l *reloadLimiter) take(rate float64, now time.Time) time.Duration {
+	burst := max(1, rate)
+	if l.last.IsZero() {
+		l.tokens = burst
+	} else {
+		l.tokens = min(burst, l.tokens+now.Sub(l.last).Seconds()*rate)
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / rate * float64(time.Second))
+}
+
+// reloadLimited reports whether the reload fired by t exceeds
+// MaxReloadsPerSecond; t is then re-armed for the trailing reload
+func (h *DevWatch) reloadLimited(t *time.Timer) bool {
+	if h.MaxReloadsPerSecond <= 0 {
+		return false
+	}
+	h.reloadMutex.Lock()
+	defer h.reloadMutex.Unlock()
+	if delay := h.limiter.take(h.MaxReloadsPerSecond, time.Now()); delay > 0 {
+		h.limiter.deferred = true
+		t.Reset(delay)
+		return true
+	}
+	h.limiter.deferred = false
+	return false
+}
@@ -0,0 +1,50 @@
+package devwatch
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReloadLimiterTake(t *testing.T) {
+	var l reloadLimiter
+	now := time.Now()
+	if l.take(2, now) != 0 || l.take(2, now) != 0 {
+		t.Fatal("expected a burst of 2 reloads")
+	}
+	if wait := l.take(2, now); wait != 500*time.Millisecond {
+		t.Errorf("expected to wait 500ms for the next token, got %v", wait)
+	}
+	if l.take(2, now.Add(time.Second)) != 0 {
+		t.Error("expected tokens to refill")
+	}
+}
+
+func TestMaxReloadsPerSecond(t *testing.T) {
+	var reloads atomic.Int32
+	w := New(&WatchConfig{
+		AppRootDir:          t.TempDir(),
+		MaxReloadsPerSecond: 1,
+		BrowserReload:       func() error { reloads.Add(1); return nil },
+		Logger:              func(message ...any) {},
+	})
+
+	w.scheduleReload()
+	time.Sleep(100 * time.Millisecond)
+	w.scheduleReload() // fires within the same second: deferred to the next token
+	time.Sleep(200 * time.Millisecond)
+	for range 3 {
+		w.scheduleReload()
+	}
+	if n := reloads.Load(); n != 1 {
+		t.Fatalf("expected 1 reload before the limit resets, got %d", n)
+	}
+
+	time.Sleep(time.Second)
+	if n := reloads.Load(); n != 2 {
+		t.Errorf("expected the storm to collapse into one trailing reload, got %d reloads", n)
+	}
+	if s := w.Stats().Suppressed; s != 3 {
+		t.Errorf("expected 3 suppressed reloads, got %d", s)
+	}
+}
@@ -0,0 +1,153 @@
+package devwatch
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// testCoverage maps each test to the files it executes, per package import
+// path: pkg -> test name -> covered files eg: "example/api/api.go"
+type testCoverage map[string]map[string][]string
+
+// covering returns the tests of pkg that execute file
+func (c testCoverage) covering(pkg, file string) []string {
+	var tests []string
+	for test, files := range c[pkg] {
+		for _, f := range files {
+			if f == file {
+				tests = append(tests, test)
+				break
+			}
+		}
+	}
+	sort.Strings(tests)
+	return tests
+}
+
+// runCovered is the coverage guided variant of NewFileEvent: packages
+// without recorded coverage (or whose tests changed) run every test once
+// per test to record what each one covers; the others only rerun the tests
+// covering the changed file.
+func (r *TestRunner) runCovered(fileName, filePath string, pkgs []string) error {
+	listed, err := r.goList(pkgs)
+	if err != nil {
+		return err
+	}
+	changed := ""
+	if dirPkg, ok := listed[filepath.Dir(filePath)]; ok {
+		changed = path.Join(dirPkg, fileName)
+	}
+	testChanged := strings.HasSuffix(fileName, "_test.go")
+
+	var errs []error
+	for dir, pkg := range listed {
+		r.mu.Lock()
+		_, known := r.cover[pkg]
+		tests := r.cover.covering(pkg, changed)
+		r.mu.Unlock()
+
+		if !known || (testChanged && dir == filepath.Dir(filePath)) {
+			errs = append(errs, r.recordCoverage(pkg))
+		} else if len(tests) > 0 {
+			errs = append(errs, r.runTests(pkg, "^("+strings.Join(tests, "|")+")$", ""))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordCoverage runs each test of pkg on its own with a cover profile
+func (r *TestRunner) recordCoverage(pkg string) error {
+	cmd := exec.Command("go", "test", "-list", ".", pkg)
+	cmd.Dir = r.Dir
+	output, err := runCommand(cmd, r.Name(), nil)
+	if err != nil {
+		return err
+	}
+
+	profile := filepath.Join(os.TempDir(), "devwatch-cover-"+strings.NewReplacer("/", "_", "\\", "_").Replace(pkg)+".out")
+	defer os.Remove(profile)
+
+	coverage := make(map[string][]string)
+	var errs []error
+	for line := range strings.Lines(output) {
+		test := strings.TrimSpace(line)
+		if !testName.MatchString(test) {
+			continue
+		}
+		errs = append(errs, r.runTests(pkg, "^"+test+"$", profile))
+		coverage[test] = coveredFiles(profile)
+	}
+
+	r.mu.Lock()
+	if r.cover == nil {
+		r.cover = make(testCoverage)
+	}
+	r.cover[pkg] = coverage
+	r.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// testName matches the test, benchmark, fuzz and example names listed by "go test -list"
+var testName = regexp.MustCompile(`^(Test|Benchmark|Fuzz|Example)\w*$`)
+
+// runTests runs the tests of pkg matching pattern, writing a cover profile
+// of every module package when profile is set
+func (r *TestRunner) runTests(pkg, pattern, profile string) error {
+	args := append([]string{"test"}, r.Flags...)
+	if profile != "" {
+		args = append(args, "-coverpkg=./...", "-coverprofile="+profile)
+	}
+	cmd := exec.Command("go", append(args, "-run", pattern, pkg)...)
+	cmd.Dir = r.Dir
+	output, err := runCommand(cmd, r.Name(), r.Output)
+	r.record(output)
+	return err
+}
+
+// goList resolves package arguments to directory -> import path
+func (r *TestRunner) goList(pkgs []string) (map[string]string, error) {
+	cmd := exec.Command("go", append([]string{"list", "-f", "{{.Dir}}\t{{.ImportPath}}"}, pkgs...)...)
+	cmd.Dir = r.Dir
+	output, err := runCommand(cmd, r.Name(), nil)
+	if err != nil {
+		return nil, err
+	}
+	listed := make(map[string]string)
+	for line := range strings.Lines(output) {
+		if dir, pkg, ok := strings.Cut(strings.TrimSpace(line), "\t"); ok {
+			listed[dir] = pkg
+		}
+	}
+	return listed, nil
+}
+
+// coveredFiles returns the files with executed statements in a cover profile
+// eg: "example/api/api.go:3.18,5.2 2 1"
+func coveredFiles(profile string) []string {
+	f, err := os.Open(profile)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	seen := make(map[string]bool)
+	var files []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		file, block, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, "mode:") || strings.HasSuffix(block, " 0") || seen[file] {
+			continue
+		}
+		seen[file] = true
+		files = append(files, file)
+	}
+	return files
+}
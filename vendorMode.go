@@ -0,0 +1,17 @@
+package devwatch
+
+import "strings"
+
+// vendorManifest is rewritten by every "go mod vendor" run
+const vendorManifest = "vendor/modules.txt"
+
+// isVendored reports whether rel (relative to AppRootDir) is inside the module vendor/ folder
+func isVendored(rel string) bool {
+	return strings.HasPrefix(rel, "vendor/")
+}
+
+// vendorTracked reports whether rel is watched for vendoring changes even
+// when vendor/ is a default ignored folder: the folder itself and its manifest
+func vendorTracked(rel string) bool {
+	return rel == "vendor" || rel == vendorManifest
+}
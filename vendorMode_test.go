@@ -0,0 +1,86 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// vendoredModule writes a module whose cmd/app main imports the vendored example.com/lib
+func vendoredModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                        "module app\n\ngo 1.22\n\nrequire example.com/lib v0.0.0\n",
+		"vendor/modules.txt":            "# example.com/lib v0.0.0\n## explicit\nexample.com/lib\n",
+		"vendor/example.com/lib/lib.go": "package lib\n\nfunc F() {}\n",
+		"vendor/example.com/other/o.go": "package other\n",
+		"cmd/app/main.go":               "package main\n\nimport \"example.com/lib\"\n\nfunc main() { lib.F() }\n",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestVendorChanges(t *testing.T) {
+	root := vendoredModule(t)
+	var called int32
+	handler := &FakeFilesEventHandler{Called: &called, SupportedExtensions_: []string{".go"}, MainInputFile: "cmd/app/main.go"}
+	w := New(&WatchConfig{AppRootDir: root, FilesEventHandlers: []FilesEventHandlers{handler}, VendorChanges: true, Logger: func(message ...any) {}})
+
+	lib := filepath.Join(root, "vendor", "example.com", "lib", "lib.go")
+	if w.Contain(lib) {
+		t.Fatal("expected vendored files to be observed with VendorChanges")
+	}
+	if mine, err := w.goFileIsMine(handler, lib, "write"); err != nil || !mine {
+		t.Errorf("expected imported vendored package to be owned, got %v %v", mine, err)
+	}
+	if mine, _ := w.goFileIsMine(handler, filepath.Join(root, "vendor", "example.com", "other", "o.go"), "write"); mine {
+		t.Error("expected vendored package not imported by main not to be owned")
+	}
+
+	if err := w.Trigger(lib, "write"); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(&called) != 1 {
+		t.Error("expected handler to receive the vendored change")
+	}
+}
+
+func TestVendorExcluded(t *testing.T) {
+	root := vendoredModule(t)
+	handler := &FakeFilesEventHandler{SupportedExtensions_: []string{".go"}, MainInputFile: "cmd/app/main.go"}
+	w := New(&WatchConfig{AppRootDir: root, FilesEventHandlers: []FilesEventHandlers{handler}, DefaultIgnores: new(bool), Logger: func(message ...any) {}})
+
+	if mine, err := w.goFileIsMine(handler, filepath.Join(root, "vendor", "example.com", "lib", "lib.go"), "write"); mine || err != nil {
+		t.Errorf("expected vendored files to be excluded by default, got %v %v", mine, err)
+	}
+}
+
+func TestVendorManifestResetsDependencies(t *testing.T) {
+	root := vendoredModule(t)
+	w := New(&WatchConfig{AppRootDir: root, Logger: func(message ...any) {}})
+
+	if !w.Contain(filepath.Join(root, "vendor", "example.com", "lib", "lib.go")) {
+		t.Error("expected vendor/ content to be unobserved by default")
+	}
+	manifest := filepath.Join(root, "vendor", "modules.txt")
+	if w.Contain(manifest) || w.Contain(filepath.Join(root, "vendor")) {
+		t.Fatal("expected vendor/modules.txt to be watched")
+	}
+
+	before := w.depFinder
+	w.deps.dirs = map[string][]string{"cmd/app/main.go": {filepath.Join(root, "vendor", "example.com", "lib")}}
+	w.depsChanged(manifest)
+	if w.depFinder == before || w.deps.dirs != nil {
+		t.Error("expected go mod vendor to reset the dependency caches")
+	}
+}
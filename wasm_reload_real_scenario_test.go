@@ -35,11 +35,11 @@ func (h *LoggingHandler) SupportedExtensions() []string {
 }
 
 func (h *LoggingHandler) MainInputFileRelativePath() string {
-	return h.inner.MainInputFileRelativePath()
+	return handlerMain(h.inner)
 }
 
 func (h *LoggingHandler) UnobservedFiles() []string {
-	return h.inner.UnobservedFiles()
+	return handlerUnobserved(h.inner)
 }
 
 // TestWasmReloadRaceCondition_RealWorldScenario simulates the exact scenario
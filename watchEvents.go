@@ -1,158 +1,279 @@
 package devwatch
 
 import (
-	"crypto/sha256"
-	"io"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
-	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 // fileEventKey stores both time and content hash for smarter debouncing
 type fileEventKey struct {
 	lastTime time.Time
-	lastHash [32]byte
+	lastHash string
+}
+
+// debounceMaxEntries is the debounce map size that triggers pruning of stale entries
+const debounceMaxEntries = 1024
+
+// eventTypeOf returns the lower case name of op without allocating for single operations
+func eventTypeOf(op fsnotify.Op) string {
+	switch op {
+	case fsnotify.Create:
+		return "create"
+	case fsnotify.Write:
+		return "write"
+	case fsnotify.Remove:
+		return "remove"
+	case fsnotify.Rename:
+		return "rename"
+	case fsnotify.Chmod:
+		return "chmod"
+	}
+	return strings.ToLower(op.String())
 }
 
 func (h *DevWatch) watchEvents() {
+	h.runLoop(h.beginRun())
+}
+
+// runLoop processes the events of one watcher until exit, Shutdown or Restart
+func (h *DevWatch) runLoop(run *watchRun) {
+	defer close(run.done)
+
 	// Track last event with content hash for smart debouncing
 	// This allows rapid edits while filtering duplicate OS events
-	lastEventInfo := make(map[string]fileEventKey)
-	const debounceWindow = 50 * time.Millisecond // Reduced for faster response
+	lastEventInfo := make(map[string]fileEventKey, debounceMaxEntries)
 
 	// create a stopped reload timer and a single goroutine that will handle its firing.
 	h.reloadMutex.Lock()
-	if h.reloadTimer == nil {
-		h.reloadTimer = time.NewTimer(0)
-		h.reloadTimer.Stop()
-		// goroutine to wait on timer events and invoke reload
-		go func(t *time.Timer) {
-			for {
-				<-t.C
-				h.triggerBrowserReload()
-			}
-		}(h.reloadTimer)
-	}
+	h.initReloadTimer()
 	h.reloadMutex.Unlock()
 
+	// OnIdle notification when no events arrive for IdleTimeout
+	idle := h.newIdleDetector()
+	defer idle.stop()
+
+	// periodic tasks registered with Every live as long as the watch loop
+	h.startScheduler()
+	defer h.stopScheduler()
+
+	// directories beyond WatchBudget are polled as long as the watch loop
+	polled, stopPolling := h.startPolling()
+	defer stopPolling()
+
+	// event workers finish the event in hand when the loop returns
+	stopWorkers := make(chan struct{})
+	workers := h.startWorkers(stopWorkers)
+	var workersOnce sync.Once
+	waitWorkers := func() {
+		workersOnce.Do(func() {
+			close(stopWorkers)
+			workers.Wait()
+		})
+	}
+	defer waitWorkers()
+
 	for {
 		select {
 
-		case event, ok := <-h.watcher.Events:
+		case event, ok := <-run.watcher.Events:
 			if !ok {
-				h.Logger("Error h.watcher.Events")
+				h.say("events-closed")
 				return
 			}
+			h.receiveEvent(event, lastEventInfo, idle)
 
-			// create, write, rename, remove
-			eventType := strings.ToLower(event.Op.String())
-			isDeleteEvent := eventType == "remove" || eventType == "delete"
-
-			// For non-delete events, check if file exists and is not contained
-			var info os.FileInfo
-			if !isDeleteEvent {
-				var statErr error
-				info, statErr = os.Stat(event.Name)
-				if statErr != nil || h.Contain(event.Name) {
-					continue // Skip if file doesn't exist or is already contained
-				}
-			}
+		case event := <-polled:
+			// changes found in directories polled beyond WatchBudget
+			h.receiveEvent(event, lastEventInfo, idle)
 
-			// Get fileName once and reuse for all operations
-			fileName, err := GetFileName(event.Name)
-			if err != nil {
-				continue // Skip if we can't get the filename
+		case err, ok := <-run.watcher.Errors:
+			if !ok {
+				h.say("errors-closed", err)
+				return
 			}
-
-			// Handle directory changes for architecture detection (only for non-delete events)
-			if !isDeleteEvent && info.IsDir() {
-				h.handleDirectoryEvent(fileName, event.Name, eventType)
-				continue
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				go h.handleOverflow()
+			} else {
+				h.say("watcher-error", err)
 			}
 
-			// SMART DEBOUNCE: Filter duplicate OS events but allow rapid user edits
-			// Strategy: Compare both time AND file content hash
-			now := time.Now()
-			shouldProcess := true
+		case <-idle.C:
+			h.notifyIdle(idle)
 
-			if lastInfo, exists := lastEventInfo[event.Name]; exists {
-				timeSinceLastEvent := now.Sub(lastInfo.lastTime)
+		case <-h.ExitChan:
+			run.watcher.Close()
+			// drop waiting events and let the ones in hand finish before the
+			// reload timer stops, so no handler arms it after exit
+			h.queue.clear()
+			waitWorkers()
+			h.stopReload()
+			return
 
-				// If event is very recent (< 50ms), check if content changed
-				if timeSinceLastEvent <= debounceWindow {
-					// Calculate current file hash
-					currentHash := h.calculateFileHash(event.Name)
+		case <-run.stop:
+			// Restart replaces the watcher and starts a new run
+			return
 
-					// Only skip if BOTH time is recent AND content is identical
-					// This filters duplicate OS events but allows rapid real edits
-					if currentHash == lastInfo.lastHash {
-						// Same content, same file, within debounce window = duplicate event
-						shouldProcess = false
-					}
-					// If hash is different, it's a real edit - process it!
-				}
-			}
+		case <-h.stopChan():
+			// Shutdown already drained handlers and handled the pending reload
+			return
+		}
+	}
+}
 
-			if !shouldProcess {
-				continue // Skip duplicate event
-			}
+// receiveEvent filters an event of the watcher and queues it for the workers
+func (h *DevWatch) receiveEvent(event fsnotify.Event, lastEventInfo map[string]fileEventKey, idle *idleDetector) {
+	const debounceWindow = 50 * time.Millisecond // Reduced for faster response
+
+	idle.activity(h.idleTimeout())
+	if h.closing.Load() {
+		return // shutting down: ignore new events
+	}
+	h.activity.record(filepath.Dir(event.Name), time.Now())
+	h.observeRate(event.Name, time.Now())
+	h.suggestIgnore(filepath.Dir(event.Name))
+	if h.isGenerated(event.Name) {
+		return // dispatched by the CodegenHandler that wrote it
+	}
+
+	// create, write, rename, remove
+	eventType := eventTypeOf(event.Op)
+	isDeleteEvent := eventType == "remove" || eventType == "delete"
+	if isDeleteEvent || eventType == "rename" {
+		// a removed directory must be registered again if it comes back
+		released := h.registry.release(event.Name)
+		if eventType == "rename" && len(released) > 0 {
+			h.directoryMovedAway(event.Name, released)
+		}
+	}
 
-			// Record event with content hash for next comparison
-			lastEventInfo[event.Name] = fileEventKey{
-				lastTime: now,
-				lastHash: h.calculateFileHash(event.Name),
+	// For non-delete events, check if file exists and is not contained
+	var info os.FileInfo
+	if !isDeleteEvent {
+		var statErr error
+		info, statErr = statRetry(event.Name)
+		if statErr != nil && eventType == "rename" && os.IsNotExist(statErr) && h.ReloadOnDelete {
+			// moved away (eg: to the trash): handled as a removal
+			eventType, isDeleteEvent = "remove", true
+		} else if statErr != nil {
+			if !os.IsNotExist(statErr) {
+				h.say("skip-event", event.Name, statErr)
 			}
+			return // Skip if file doesn't exist or is still locked
+		} else if h.Contain(event.Name) {
+			return // Skip if file is already contained
+		}
+	}
+	if isDeleteEvent && h.ReloadOnDelete && h.Contain(event.Name) {
+		return // an unobserved file must not reload the browser
+	}
 
-			// Handle file events (both delete and non-delete)
-			// NOTE: This call blocks during compilation! Events arriving during
-			// compilation will queue up in the watcher.Events channel.
-			h.handleFileEvent(fileName, event.Name, eventType, isDeleteEvent)
+	// Get fileName once and reuse for all operations
+	fileName, err := GetFileName(event.Name)
+	if err != nil {
+		return // Skip if we can't get the filename
+	}
 
-		case err, ok := <-h.watcher.Errors:
-			if !ok {
-				h.Logger("h.watcher.Errors:", err)
-				return
+	// Handle directory changes for architecture detection (only for non-delete events)
+	if !isDeleteEvent && info.IsDir() {
+		h.handleDirectoryEvent(fileName, event.Name, eventType)
+		return
+	}
+
+	// SMART DEBOUNCE: Filter duplicate OS events but allow rapid user edits
+	// Strategy: Compare both time AND file content hash
+	// Keyed by canonical path: the same file seen through overlapping
+	// roots or symlinks is dispatched once
+	now := time.Now()
+	shouldProcess := true
+	eventKey := canonicalPath(event.Name)
+
+	if lastInfo, exists := lastEventInfo[eventKey]; exists {
+		timeSinceLastEvent := now.Sub(lastInfo.lastTime)
+
+		// If event is very recent (< 50ms), check if content changed
+		if timeSinceLastEvent <= debounceWindow {
+			// Calculate current file hash
+			currentHash := h.calculateFileHash(event.Name)
+
+			// Only skip if BOTH time is recent AND content is identical
+			// This filters duplicate OS events but allows rapid real edits
+			if currentHash == lastInfo.lastHash {
+				// Same content, same file, within debounce window = duplicate event
+				shouldProcess = false
 			}
+			// If hash is different, it's a real edit - process it!
+		}
+	}
 
-		case <-h.ExitChan:
-			h.watcher.Close()
-			h.stopReload()
-			return
+	if !shouldProcess {
+		return // Skip duplicate event
+	}
+
+	// Record event with content hash for next comparison; entries
+	// older than the window are useless, drop them so storms over
+	// many files don't grow the map forever
+	if len(lastEventInfo) >= debounceMaxEntries {
+		for key, info := range lastEventInfo {
+			if now.Sub(info.lastTime) > debounceWindow {
+				delete(lastEventInfo, key)
+			}
 		}
 	}
+	lastEventInfo[eventKey] = fileEventKey{
+		lastTime: now,
+		lastHash: h.calculateFileHash(event.Name),
+	}
+	if h.echoes.consume(eventKey, lastEventInfo[eventKey].lastHash) {
+		return // devwatch's own rewrite eg: format on save
+	}
+
+	// Handle file events (both delete and non-delete) on the workers,
+	// so long builds don't block reading the watcher.Events channel
+	h.queueSave(fileName, event.Name, eventType, isDeleteEvent)
 }
 
 // handleDirectoryEvent processes directory creation/modification events
 func (h *DevWatch) handleDirectoryEvent(fileName, eventName, eventType string) {
+	// a watched directory moved within the tree keeps its watches
+	if eventType == "create" && h.directoryMovedHere(eventName) {
+		return
+	}
+
 	if h.FolderEvents != nil {
 		err := h.FolderEvents.NewFolderEvent(fileName, eventName, eventType)
 		if err != nil {
-			h.Logger("Watch folder event error:", err)
+			h.say("folder-event-error", err)
 		}
 	}
 
 	// Add new directory to watcher
 	if eventType == "create" {
-		// Create a registry map for the new directory walk
-		reg := make(map[string]struct{})
-
 		// Add the main directory first
-		if err := h.addDirectoryToWatcher(eventName, reg); err == nil {
+		if err := h.addDirectoryToWatcher(eventName); err == nil {
 			// Walk recursively to add any subdirectories that might have been created
 			// This handles cases like os.MkdirAll() where multiple directories are created at once
 			err := filepath.Walk(eventName, func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					return nil // Continue walking even if there's an error
 				}
+				if info.IsDir() && h.beyondDepth(path) {
+					return filepath.SkipDir
+				}
 				if info.IsDir() && path != eventName && !h.Contain(path) {
-					h.addDirectoryToWatcher(path, reg)
+					h.addDirectoryToWatcher(path)
 				}
 				return nil
 			})
 			if err != nil {
-				h.Logger("Watch: Error walking new directory:", eventName, err)
+				h.say("walk-new-dir-error", eventName, err)
 			}
 		}
 	}
@@ -160,14 +281,152 @@ func (h *DevWatch) handleDirectoryEvent(fileName, eventName, eventType string) {
 
 // handleFileEvent processes file creation/modification/deletion events
 func (h *DevWatch) handleFileEvent(fileName, eventName, eventType string, isDeleteEvent bool) {
+	h.handleEvent(fileName, eventName, eventType, isDeleteEvent, nil)
+}
+
+// handleEvent processes a file event, as part of the save transaction tx
+// when not nil (see SaveAllWindow)
+func (h *DevWatch) handleEvent(fileName, eventName, eventType string, isDeleteEvent bool, tx *saveTransaction) {
+	// devwatch's own files (also removals, which skip Contain) never reach handlers
+	if h.isInternalPath(eventName) {
+		return
+	}
+
+	// Go files written by CodegenHandlers are dispatched once this event
+	// released its dispatch slot
+	var generated []string
+	defer func() {
+		for _, path := range generated {
+			if name, err := GetFileName(path); err == nil {
+				h.handleFileEvent(name, path, "write", false)
+			}
+		}
+	}()
+
+	// Limit dispatch to MaxConcurrentEvents: events arrive from the workers and from Trigger
+	received := time.Now()
+	h.acquireDispatch()
+	defer h.releaseDispatch()
+	h.markDispatch()
+	defer h.dispatchDone()
+	defer h.retrySuperseded(eventName, received)
+
+	// files written by the handlers of another event carry its ID
+	parent, loopErr := h.eventParent(eventName, isDeleteEvent)
+	attrs := []string{"path", eventName, "event", eventType}
+	if parent != nil {
+		attrs = append(attrs, "parent", strconv.FormatUint(parent.id, 10))
+	}
+	ctx, span := h.startSpan(context.Background(), "devwatch.event", attrs...)
+	defer span.End(nil)
+
+	rec := h.newEventRecord(eventName, eventType)
+	rec.Audit = h.auditing()
+	if tx != nil {
+		defer tx.merge(rec)
+	} else {
+		defer h.publish(rec)
+	}
+	if parent != nil {
+		rec.Parent, rec.Depth = parent.id, len(parent.chain)
+	}
+	if loopErr != nil {
+		h.say("event-chain", loopErr, eventName)
+		if h.OnError != nil {
+			h.OnError(loopErr)
+		}
+		rec.Skipped = "loop"
+		return
+	}
+	defer h.chains.begin(rec.ID, h.relativePath(eventName), parent)()
+
+	if eventType == "create" {
+		h.warnSensitive(eventName)
+	}
+
 	extension := filepath.Ext(eventName)
-	var processedSuccessfully bool
+	change := FileChange{
+		FileName:  fileName,
+		Extension: extension,
+		FilePath:  eventName,
+		Event:     eventType,
+		Source:    h.sourceOf(eventName),
+	}
+	h.addDiff(&change)
+
+	relPath := h.relativePath(eventName)
+	profile := h.currentProfile()
+	if !profile.allowsPath(relPath) {
+		rec.Skipped = "profile"
+		return
+	}
+
+	if extension == ".go" && !rec.Audit {
+		h.formatOnSave(eventName, eventType)
+	}
+
+	if extension == ".go" && h.isCommentOnlyChange(eventName, eventType) {
+		h.say("comment-only", fileName)
+		rec.Skipped = "comment-only"
+		return
+	}
+
+	// generated Go files only reach the handlers claiming them explicitly
+	skipGenerated := extension == ".go" && h.skipGeneratedGo(eventName, eventType)
+	if skipGenerated && !h.generatedClaimed(eventName) {
+		h.say("generated-skipped", fileName)
+		rec.Skipped = "generated"
+		return
+	}
+
+	if extension == ".go" && !skipGenerated {
+		h.rebindMainInput(eventName, eventType)
+		if h.WarmOwnership {
+			h.ownership.fileChanged(eventName, eventType)
+		}
+	}
+	if h.IncrementalDeps && (extension == ".go" || isDeleteEvent) && !isVendored(relPath) {
+		h.imports.fileChanged(eventName, eventType)
+	}
+	h.depsChanged(eventName)
+
+	var processedSuccessfully, handlerFailed bool
 	isGoFileEvent := extension == ".go"
 	var atLeastOneGoHandlerSucceeded bool
+	var asyncResults []<-chan error
+	// paths reported to BrowserReloadPaths by ReloadTargetHandlers, and
+	// whether a handler without targets succeeded
+	var reloadTargets []string
+	var untargeted bool
+	// stages that failed (or were skipped) for this event
+	failedStages := make(map[string]bool)
+	// identical work shared between handlers runs once per event
+	var shared sharedWork
+	// Go handlers asked for ownership and whether any of them owns the file
+	var consulted []string
+	var goOwned bool
+	// content hash for the rebuild loop breaker, computed on first use
+	var contentHash string
+
+	// Execute ALL handlers in pipeline stage order, don't stop on errors
+	handlers := h.FilesEventHandlers
+	for _, id := range orderedIDs(handlers) {
+		handler := handlers[id]
+		matchedExt, supported := handlerSupports(handler, eventName, extension)
+		if !supported || !profile.allowsHandler(handler) || !handlerInScope(handler, relPath) || !handlerForSource(handler, change.Source) {
+			continue
+		}
+		if skipGenerated && !handlerClaims(handler, eventName) {
+			continue
+		}
+		handlerChange := change
+		handlerChange.Extension = matchedExt
 
-	// Execute ALL handlers, don't stop on errors
-	for _, handler := range h.FilesEventHandlers {
-		if !slices.Contains(handler.SupportedExtensions(), extension) {
+		stage, _ := handlerStage(handler)
+		if upstreamFailed(handler, failedStages) {
+			if stage != "" {
+				failedStages[stage] = true
+			}
 			continue
 		}
 
@@ -175,22 +434,112 @@ func (h *DevWatch) handleFileEvent(fileName, eventName, eventType string, isDele
 		var isMine = true
 		var herr error
 
-		if !isDeleteEvent && extension == ".go" {
-			isMine, herr = h.depFinder.ThisFileIsMine(handler.MainInputFileRelativePath(), eventName, eventType)
+		// a generated file reached only handlers claiming it: no ownership check
+		if !isDeleteEvent && extension == ".go" && !skipGenerated {
+			consulted = append(consulted, handlerName(handler))
+			isMine, herr = h.goFileIsMine(handler, eventName, eventType)
 			if herr != nil {
 				// h.Logger("DEBUG Error from ThisFileIsMine, continuing: %v\n", herr)
 				continue
 			}
+			goOwned = goOwned || isMine
+		}
+
+		// AuditFor: record the call instead of making it
+		if isMine && rec.Audit {
+			h.auditCall(handler)
+			rec.addResult(handler, time.Now(), nil)
+			processedSuccessfully = true
+			atLeastOneGoHandlerSucceeded = atLeastOneGoHandlerSucceeded || isGoFileEvent
+			continue
+		}
+
+		if isMine && !isDeleteEvent && h.LoopLimit >= 0 {
+			if contentHash == "" {
+				contentHash = h.calculateFileHash(eventName)
+			}
+			if err := h.loopCheck(id, handler, eventName, contentHash); err != nil {
+				rec.addResult(handler, time.Now(), err)
+				if stage != "" {
+					failedStages[stage] = true
+				}
+				continue
+			}
+		}
+
+		if isMine && isBackground(handler) {
+			start := time.Now()
+			rec.addResult(handler, start, h.callHandler(ctx, handler, handlerChange))
+			continue
 		}
 
 		if isMine {
-			err := handler.NewFileEvent(fileName, extension, eventName, eventType)
+			if tx != nil && tx.batch(id, handler, handlerChange) {
+				continue // one call at the end of the transaction
+			}
+			wasmBuilt := h.wasmMark(handler)
+			if result, later := h.debounce(id, handler, handlerChange); later {
+				// coalesced into a later call, see DebouncedHandler
+				wasmBuilt()
+				asyncResults = append(asyncResults, result)
+				continue
+			}
+			if ah, ok := handler.(AsyncFileEventHandler); ok {
+				wasmBuilt()
+				asyncResults = append(asyncResults, ah.NewFileEventAsync(fileName, matchedExt, eventName, eventType))
+				continue
+			}
+
+			workKey := shared.key(h, handler, eventName)
+			err, done := shared.lookup(workKey)
+			if !done && tx != nil {
+				// Go handlers build the whole target once per transaction
+				err, done = tx.lookup(id, isGoFileEvent)
+			}
+			if !done {
+				start := time.Now()
+				callCtx, finish := h.interruptible(ctx, id, handler, eventName)
+				err = h.callHandler(callCtx, handler, handlerChange)
+				if finish(handlerChange) {
+					// a newer save of the same target cancelled this stale build
+					rec.Handlers = append(rec.Handlers, HandlerResult{Handler: handlerName(handler), Duration: time.Since(start), Superseded: true})
+					if stage != "" {
+						failedStages[stage] = true
+					}
+					continue
+				}
+				rec.addResult(handler, start, err)
+				shared.record(workKey, err)
+				if tx != nil {
+					tx.record(id, isGoFileEvent, err)
+				}
+				if err == nil && !isDeleteEvent {
+					h.recordBuild(handler, eventName, time.Since(start))
+				}
+			}
 			if err != nil {
 				//h.Logger("DEBUG Watch updating file error:", err)
+				if h.OnError != nil && !done && DiagnosticsOf(err) != nil {
+					h.OnError(err)
+				}
 				// Continue to next handler even if this one failed
+				handlerFailed = true
+				if stage != "" {
+					failedStages[stage] = true
+				}
 			} else {
 				// Track success for both Go and non-Go files
 				processedSuccessfully = true
+				if !isDeleteEvent {
+					h.recordOutputs(handler, eventName)
+				}
+				if targets, ok := h.reloadTargets(handler, eventName); ok {
+					reloadTargets = append(reloadTargets, targets...)
+				} else {
+					untargeted = true
+				}
+				generated = append(generated, h.generatedChanges(handler, received)...)
+				wasmBuilt()
 				if isGoFileEvent {
 					atLeastOneGoHandlerSucceeded = true
 				}
@@ -198,22 +547,76 @@ func (h *DevWatch) handleFileEvent(fileName, eventName, eventType string, isDele
 		}
 	}
 
+	if isDeleteEvent && !rec.Audit {
+		h.sourceRemoved(eventName)
+	}
+
+	if len(consulted) > 0 && !goOwned {
+		h.reportDependencyMiss(eventName, consulted)
+		rec.Skipped = "no-owner"
+	}
+
 	// Schedule reload if AT LEAST ONE handler succeeded
 	// For Go files: reload if any handler succeeded
 	// For non-Go files: reload if any handler succeeded
-	if (isGoFileEvent && atLeastOneGoHandlerSucceeded) || (!isGoFileEvent && processedSuccessfully) {
+	// generated Go files reload the browser once their handlers built them
+	shouldReload := ((isGoFileEvent && atLeastOneGoHandlerSucceeded) || (!isGoFileEvent && processedSuccessfully)) && len(generated) == 0
+	// ReloadOnDelete: a removed asset reloads even when no handler handles it,
+	// unless a handler failed
+	if isDeleteEvent && h.ReloadOnDelete && !isGoFileEvent && !handlerFailed && len(asyncResults) == 0 {
+		shouldReload = true
+	}
+	if processedSuccessfully || len(asyncResults) > 0 {
+		h.activity.handled(filepath.Dir(eventName))
+	}
+	if shouldReload || len(asyncResults) > 0 {
+		// handlers declaring reload targets report them instead of the file
+		rebuilt := processedSuccessfully || len(asyncResults) > 0
+		if untargeted || len(asyncResults) > 0 || len(reloadTargets) == 0 {
+			h.changed.add(relPath, eventType, rebuilt)
+		}
+		for _, target := range reloadTargets {
+			h.changed.add(target, "write", true)
+		}
+	}
+	if processedSuccessfully && !isGoFileEvent {
+		h.fingerprint(eventName, eventType)
+	}
+
+	// Async handlers are still working: reload once they complete
+	if len(asyncResults) > 0 {
+		rec.Reload = true // pending async completion
+		h.waitAsyncResults(eventName, received, asyncResults, shouldReload)
+		return
+	}
+
+	if shouldReload {
+		rec.Reload = true
+		h.stats.handlersDone(received)
 		h.scheduleReload()
 	}
 }
 
 // triggerBrowserReload safely triggers a browser reload in a goroutine
 func (h *DevWatch) triggerBrowserReload() {
-	if h.BrowserReload != nil {
+	if h.auditing() {
+		h.auditReload()
+		h.stats.dropPending()
+		return
+	}
+	if h.focusHold() || !h.wasmReady() || h.verifyArtifacts() != nil || !h.serverReady() {
+		h.stats.dropPending() // no reload: these saves have no end-to-end latency
+		return
+	}
+	if h.reloadConfigured() {
+		_, span := h.startSpan(context.Background(), "devwatch.reload")
 		// Call synchronously so the caller (watchEvents) completes the
 		// reload action before returning. This prevents background reload
 		// goroutines from racing with test teardown and shared counters.
-		_ = h.BrowserReload()
+		span.End(h.browserReload(false))
 	}
+	h.stats.reloaded()
+	h.openBrowser()
 }
 
 // scheduleReload resets or starts a reload timer which will call triggerBrowserReload
@@ -225,11 +628,16 @@ func (h *DevWatch) scheduleReload() {
 	h.reloadMutex.Lock()
 	defer h.reloadMutex.Unlock()
 
-	if h.reloadTimer == nil {
-		h.reloadTimer = time.NewTimer(wait)
+	if h.reloadsClosed {
+		return // Shutdown already flushed or cancelled reloads
+	}
+	if h.limiter.deferred {
+		h.stats.suppressed() // collapses into the rate limited reload already waiting
 		return
 	}
 
+	h.initReloadTimer()
+
 	// Stop existing timer and reset
 	if !h.reloadTimer.Stop() {
 		select {
@@ -240,6 +648,30 @@ func (h *DevWatch) scheduleReload() {
 	h.reloadTimer.Reset(wait)
 }
 
+// initReloadTimer creates a stopped reload timer and the single goroutine that
+// handles its firing. Callers must hold reloadMutex.
+func (h *DevWatch) initReloadTimer() {
+	if h.reloadTimer != nil {
+		return
+	}
+	h.reloadTimer = time.NewTimer(0)
+	h.reloadTimer.Stop()
+	// goroutine to wait on timer events and invoke reload
+	go func(t *time.Timer) {
+		for {
+			<-t.C
+			if h.reloadLimited(t) {
+				continue
+			}
+			// never reload while async handlers are still building
+			if !h.async.wait(h.asyncTimeout()) {
+				h.say("async-running", h.asyncTimeout())
+			}
+			h.triggerBrowserReload()
+		}
+	}(h.reloadTimer)
+}
+
 // stopReload stops and clears the reload timer; used during shutdown
 func (h *DevWatch) stopReload() {
 	h.reloadMutex.Lock()
@@ -263,24 +695,3 @@ func (h *DevWatch) stopReload() {
 		// Don't trigger reload in this case
 	}
 }
-
-// calculateFileHash computes SHA256 hash of file content for smart debouncing
-// Returns zero hash if file cannot be read (will be treated as different)
-func (h *DevWatch) calculateFileHash(filePath string) [32]byte {
-	var zeroHash [32]byte
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		return zeroHash // File doesn't exist or can't be read
-	}
-	defer file.Close()
-
-	hasher := sha256.New()
-	if _, err := io.Copy(hasher, file); err != nil {
-		return zeroHash // Error reading file
-	}
-
-	var hash [32]byte
-	copy(hash[:], hasher.Sum(nil))
-	return hash
-}
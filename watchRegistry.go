@@ -0,0 +1,111 @@
+package devwatch
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// watchRegistry records the directories added to the fsnotify watcher keyed
+// by their canonical path, so overlapping roots, symlinks or repeated create
+// events never register the same directory twice.
+type watchRegistry struct {
+	mu   sync.Mutex
+	dirs map[string]struct{}
+}
+
+// claim registers dir and reports whether it was not registered yet
+func (r *watchRegistry) claim(dir string) bool {
+	key := canonicalPath(dir)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.dirs == nil {
+		r.dirs = make(map[string]struct{})
+	}
+	if _, exists := r.dirs[key]; exists {
+		return false
+	}
+	r.dirs[key] = struct{}{}
+	return true
+}
+
+// release forgets dir and everything below it (eg: after the directory was
+// removed) and returns the canonical paths it forgot
+func (r *watchRegistry) release(dir string) []string {
+	key := canonicalPath(dir)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var released []string
+	for d := range r.dirs {
+		if d == key || strings.HasPrefix(d, key+"/") {
+			delete(r.dirs, d)
+			released = append(released, d)
+		}
+	}
+	return released
+}
+
+// has reports whether dir is registered
+func (r *watchRegistry) has(dir string) bool {
+	key := canonicalPath(dir)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.dirs[key]
+	return exists
+}
+
+// count returns the number of registered directories
+func (r *watchRegistry) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.dirs)
+}
+
+// reset forgets every directory; used when the watcher is rebuilt
+func (r *watchRegistry) reset() {
+	r.mu.Lock()
+	r.dirs = nil
+	r.mu.Unlock()
+}
+
+// canonicalPath resolves path to an absolute slash path with symlinks
+// evaluated. Paths that no longer exist are only made absolute.
+func canonicalPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = filepath.Clean(path)
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	return slashPath(abs)
+}
+
+// watchRoots returns AppRootDir followed by ExtraRootDirs, skipping roots
+// that repeat or are nested in another root
+func (h *DevWatch) watchRoots() []string {
+	var roots, keys []string
+	for _, root := range append([]string{h.AppRootDir}, h.ExtraRootDirs...) {
+		if root == "" || slices.Contains(keys, canonicalPath(root)) {
+			continue
+		}
+		roots = append(roots, root)
+		keys = append(keys, canonicalPath(root))
+	}
+
+	var out []string
+	for i, root := range roots {
+		nested := false
+		for j, k := range keys {
+			if i != j && strings.HasPrefix(keys[i], k+"/") {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			out = append(out, root)
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package devwatch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchRoots(t *testing.T) {
+	root := t.TempDir()
+	shared := filepath.Join(root, "shared")
+	other := t.TempDir()
+	if err := os.Mkdir(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(other, "link")
+	if err := os.Symlink(root, link); err != nil {
+		t.Skip("symlinks not supported:", err)
+	}
+
+	w := New(&WatchConfig{
+		AppRootDir:    shared,
+		ExtraRootDirs: []string{root + string(filepath.Separator), link, other},
+	})
+	roots := w.watchRoots()
+	// shared is nested in root, link resolves to root
+	if len(roots) != 2 || roots[0] != root+string(filepath.Separator) || roots[1] != other {
+		t.Fatalf("unexpected roots: %v", roots)
+	}
+}
+
+func TestOverlappingRootsDispatchOnce(t *testing.T) {
+	root := t.TempDir()
+	shared := filepath.Join(root, "shared")
+	if err := os.Mkdir(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cssFile := filepath.Join(shared, "style.css")
+	if err := os.WriteFile(cssFile, []byte("body {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	calls := []string{}
+	handler := &CountingFileEvent{CallCount: &count, Calls: &calls, SupportedExtensions_: []string{".css"}}
+	exit := make(chan bool)
+	w := New(&WatchConfig{
+		AppRootDir:         root,
+		ExtraRootDirs:      []string{shared},
+		FilesEventHandlers: []FilesEventHandlers{handler},
+		BrowserReload:      func() error { return nil },
+		Logger:             func(message ...any) { t.Log(message...) },
+		ExitChan:           exit,
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.FileWatcherStart(&wg)
+	time.Sleep(100 * time.Millisecond)
+
+	if n, c := handler.GetCounts(); n != 1 {
+		t.Fatalf("expected 1 initial registration call, got %d: %v", n, c)
+	}
+
+	// registering a directory again through another spelling is a no-op
+	if w.registry.claim(shared + string(filepath.Separator)) {
+		t.Error("expected shared directory to be registered already")
+	}
+
+	handler.Reset()
+	if err := os.WriteFile(cssFile, []byte("body { color: red }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if n, c := handler.GetCounts(); n != 1 {
+		t.Errorf("expected a single dispatch for one save, got %d: %v", n, c)
+	}
+
+	close(exit)
+	wg.Wait()
+}